@@ -2,15 +2,23 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	appConfig "hrm-app/config"
+	"hrm-app/internal/domain/boardExport"
+	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/pkg/rabbitmq/config"
 	"hrm-app/internal/pkg/rabbitmq/connection"
 	"hrm-app/internal/pkg/rabbitmq/consumer"
 	"hrm-app/internal/pkg/rabbitmq/setup"
+
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 func main() {
@@ -19,6 +27,9 @@ func main() {
 
 	go shutdown(cancel)
 
+	// Board imports write to PostgreSQL
+	database.ConnectDatabase(appConfig.LoadConfig())
+
 	conn, err := connection.New(config.RabbitURL)
 	if err != nil {
 		log.Fatal(err)
@@ -41,12 +52,68 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if err := startImports(ctx, conn); err != nil {
+		log.Fatal(err)
+	}
+
 	log.Println("RabbitMQ service started")
 	if err := consumer.Start(ctx, ch); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// startImports runs queued board imports on their own channel
+func startImports(ctx context.Context, conn *amqp.Connection) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	if err := setup.DeclareJobQueue(
+		ch,
+		config.ExchangeName,
+		config.ExchangeType,
+		config.ImportQueueName,
+		config.ImportRoutingKey,
+	); err != nil {
+		return err
+	}
+
+	imports := boardExport.NewUseCase(boardExport.NewRepository(), policy.New(policy.NewRepository()), nil)
+	go failStaleImports(ctx, imports)
+
+	return consumer.StartJobs(ctx, ch, config.ImportQueueName, func(ctx context.Context, msg amqp.Delivery) error {
+		var job boardExport.JobMessage
+		if err := json.Unmarshal(msg.Body, &job); err != nil {
+			return err
+		}
+		return imports.RunJob(ctx, job.JobID)
+	})
+}
+
+// failStaleImports fails, at startup and then periodically, imports left
+// running by a worker that stopped. Their messages were already redelivered
+// and skipped as claimed, so nothing else would finish them.
+func failStaleImports(ctx context.Context, imports boardExport.UseCase) {
+	ticker := time.NewTicker(boardExport.StaleJobAfter / 2)
+	defer ticker.Stop()
+
+	for {
+		failed, err := imports.FailStaleJobs()
+		if err != nil {
+			log.Printf("[Import] Stale job check failed: %v", err)
+		} else if failed > 0 {
+			log.Printf("[Import] Marked %d stale job(s) as failed", failed)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func shutdown(cancel context.CancelFunc) {
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
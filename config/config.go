@@ -10,6 +10,9 @@ type Config struct {
 	Env    string
 	Server struct {
 		Port int
+		// TrustedProxies are the proxies whose X-Forwarded-For is believed when
+		// finding the client IP. Empty means the connection's own address is used.
+		TrustedProxies []string `mapstructure:"trusted_proxies"`
 	}
 
 	Database struct {
@@ -29,12 +32,71 @@ type Config struct {
 	}
 
 	JWT struct {
-		Secret               string `mapstructure:"secret"`
+		Secret               string `mapstructure:"secret"` // legacy HS256 key, used when no signing_key_id is set
 		ExpiresInMinutes     int    `mapstructure:"expires_in_minute"`
 		TokenTTLMinutes      int    `mapstructure:"token_ttl_minute"`
 		RefreshExpiresInDays int    `mapstructure:"refresh_expires_in_days"`
+
+		// Asymmetric keys (RS256/EdDSA), see internal/pkg/jwtkeys
+		SigningKeyID string `mapstructure:"signing_key_id"`
+		Keys         []struct {
+			ID             string `mapstructure:"id"`
+			PrivateKeyFile string `mapstructure:"private_key_file"` // empty for verification-only keys
+			PublicKeyFile  string `mapstructure:"public_key_file"`  // optional when private_key_file is set
+		} `mapstructure:"keys"`
 	}
 
+	App struct {
+		BaseURL     string `mapstructure:"base_url"` // public URL of this API
+		FrontendURL string `mapstructure:"frontend_url"`
+	} `mapstructure:"app"`
+
+	Auth struct {
+		ResetTokenTTLMinutes        int  `mapstructure:"reset_token_ttl_minute"`
+		VerificationTokenTTLMinutes int  `mapstructure:"verification_token_ttl_minute"`
+		RequireEmailVerification    bool `mapstructure:"require_email_verification"` // Login refuses unverified accounts
+		InvitationTTLDays           int  `mapstructure:"invitation_ttl_day"`
+		InviteLinkTTLDays           int  `mapstructure:"invite_link_ttl_day"`
+
+		// AdminEmails are promoted to the system admin role at startup once verified
+		AdminEmails []string `mapstructure:"admin_emails"`
+
+		// Failed login throttling, see internal/pkg/loginguard
+		Lockout struct {
+			MaxAttemptsPerEmail int `mapstructure:"max_attempts_per_email"`
+			MaxAttemptsPerIP    int `mapstructure:"max_attempts_per_ip"`
+			DelayAfter          int `mapstructure:"delay_after"` // failures before delays start
+			WindowMinutes       int `mapstructure:"window_minute"`
+			LockoutMinutes      int `mapstructure:"lockout_minute"`
+		} `mapstructure:"lockout"`
+	} `mapstructure:"auth"`
+
+	OIDC struct {
+		Enabled      bool     `mapstructure:"enabled"`
+		Issuer       string   `mapstructure:"issuer"`
+		ClientID     string   `mapstructure:"client_id"`
+		ClientSecret string   `mapstructure:"client_secret"`
+		RedirectURL  string   `mapstructure:"redirect_url"` // must point at /api/v1/oidc/callback
+		Scopes       []string `mapstructure:"scopes"`
+	} `mapstructure:"oidc"`
+
+	Workspace struct {
+		TrashRetentionDays   int `mapstructure:"trash_retention_day"`   // trashed workspaces can be restored this long
+		PurgeIntervalMinutes int `mapstructure:"purge_interval_minute"` // how often expired workspaces are purged
+	} `mapstructure:"workspace"`
+
+	Mailer struct {
+		Driver string `mapstructure:"driver"` // smtp, file or memory
+		From   string `mapstructure:"from"`
+		Dir    string `mapstructure:"dir"` // output directory for the file driver
+		SMTP   struct {
+			Host     string `mapstructure:"host"`
+			Port     int    `mapstructure:"port"`
+			Username string `mapstructure:"username"`
+			Password string `mapstructure:"password"`
+		} `mapstructure:"smtp"`
+	} `mapstructure:"mailer"`
+
 	Kafka struct {
 		Brokers []string
 	}
@@ -1,27 +1,53 @@
 package app
 
 import (
+	"log"
+
 	"hrm-app/config"
+	"hrm-app/internal/domain/accessToken"
+	"hrm-app/internal/domain/account"
+	"hrm-app/internal/domain/activity"
+	"hrm-app/internal/domain/admin"
+	"hrm-app/internal/domain/audit"
 	"hrm-app/internal/domain/auth"
+	"hrm-app/internal/domain/boardExport"
 	"hrm-app/internal/domain/boards"
 	"hrm-app/internal/domain/boardsUsers"
 	"hrm-app/internal/domain/contact"
+	"hrm-app/internal/domain/emailVerification"
+	"hrm-app/internal/domain/invitation"
+	"hrm-app/internal/domain/inviteLink"
+	"hrm-app/internal/domain/joinRequest"
 	"hrm-app/internal/domain/labels"
+	"hrm-app/internal/domain/mfa"
+	"hrm-app/internal/domain/notification"
+	"hrm-app/internal/domain/passwordReset"
 	room_chats "hrm-app/internal/domain/roomChats"
 	room_messages "hrm-app/internal/domain/roomMessages"
 	"hrm-app/internal/domain/roomUsers"
+	"hrm-app/internal/domain/session"
+	"hrm-app/internal/domain/settings"
+	"hrm-app/internal/domain/sso"
 	"hrm-app/internal/domain/storage"
 	"hrm-app/internal/domain/taskCard"
 	"hrm-app/internal/domain/taskCardComment"
 	"hrm-app/internal/domain/taskCardUsers"
 	"hrm-app/internal/domain/taskTab"
+	"hrm-app/internal/domain/template"
 	"hrm-app/internal/domain/user"
 	"hrm-app/internal/domain/workspaces"
 	"hrm-app/internal/domain/workspacesUsers"
 	"hrm-app/internal/infrastructure/storage/supabase"
 	"hrm-app/internal/middleware"
 	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/loginguard"
+	"hrm-app/internal/pkg/mailer"
+	"hrm-app/internal/pkg/oidc"
+	"hrm-app/internal/pkg/policy"
+	rmqConfig "hrm-app/internal/pkg/rabbitmq/config"
 	rmqManager "hrm-app/internal/pkg/rabbitmq/manager"
+	rmqProducer "hrm-app/internal/pkg/rabbitmq/producer"
+	"hrm-app/internal/pkg/utils"
 	"hrm-app/internal/websocket"
 
 	"github.com/gin-contrib/gzip"
@@ -30,6 +56,10 @@ import (
 
 func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager, rateLimiter *middleware.RateLimiter) (*gin.Engine, *websocket.Hub) {
 	r := gin.Default()
+	// Without this gin believes any X-Forwarded-For, and per-IP limits could be dodged
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		panic("Invalid server.trusted_proxies: " + err.Error())
+	}
 
 	// CORS middleware (if needed)
 	r.Use(func(c *gin.Context) {
@@ -57,7 +87,16 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 	hub := websocket.NewHub(database.RDB, rabbitmqURL, channelManager, rateLimiter)
 	go hub.Run()
 
+	// Fail fast on a broken key configuration instead of on the first login
+	if _, err := utils.SigningKeys(cfg); err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+
+	// Response messages follow the caller's language setting
+	settingsUseCase := settings.NewUseCase(settings.NewRepository())
+
 	api := r.Group("/api/v1")
+	api.Use(middleware.Language(settingsUseCase))
 	{
 		api.GET("/ping", func(c *gin.Context) {
 			c.JSON(200, gin.H{"message": "pong"})
@@ -113,6 +152,12 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 		roomChatRepo := room_chats.NewRepository()
 		roomUserRepo := roomUsers.NewRepository()
 		roomMessageRepo := room_messages.NewRepository()
+		passwordResetRepo := passwordReset.NewRepository()
+		emailVerificationRepo := emailVerification.NewRepository()
+		mfaRepo := mfa.NewRepository()
+		ssoRepo := sso.NewRepository()
+		accessTokenRepo := accessToken.NewRepository()
+		accountRepo := account.NewRepository()
 
 		// Initialize UseCases
 		// Initialize UseCases
@@ -122,47 +167,115 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 		}
 
 		uploadService := storage.NewService(storageRepo)
+		authorizer := policy.New(policy.NewRepository())
+		boardLocator := policy.NewBoardLocator()
+		auditUseCase := audit.NewUseCase(audit.NewRepository(), authorizer)
+		activityUseCase := activity.NewUseCase(activity.NewRepository(), hub)
+		mail := mailer.New(cfg)
 
-		userUseCase := user.NewUseCase(userRepo, contactRepo, uploadService)
-		workspaceUseCase := workspaces.NewUseCase(workspaceRepo, workspacesUsersRepo, cfg)
-		boardsUseCase := boards.NewUseCase(boardsRepo, taskTabRepo, taskCardRepo, boardsUsersRepo, labelsRepo, taskCardUsersRepo)
+		notificationUseCase := notification.NewUseCase(hub, settingsUseCase, userRepo, mail)
+		workspaceRepoAdapter := workspaces.NewRepositoryAdapter(workspaceRepo)
+		invitationUseCase := invitation.NewUseCase(invitation.NewRepository(), userRepo, workspaceRepoAdapter, authorizer, mail, settingsUseCase, cfg)
+		emailVerificationUseCase := emailVerification.NewUseCase(emailVerificationRepo, userRepo, mail, settingsUseCase, invitationUseCase, cfg)
+		accountUseCase := account.NewUseCase(accountRepo, userRepo)
+		userUseCase := user.NewUseCase(userRepo, contactRepo, uploadService, emailVerificationUseCase, accountUseCase, settingsUseCase)
+		workspaceUseCase := workspaces.NewUseCase(workspaceRepo, workspacesUsersRepo, authorizer, cfg)
+		go workspaces.NewPurger(workspaceUseCase, cfg).Run()
+		templateUseCase := template.NewUseCase(template.NewRepository(), boardsRepo, authorizer)
+		boardsUseCase := boards.NewUseCase(boardsRepo, taskTabRepo, taskCardRepo, boardsUsersRepo, labelsRepo, taskCardUsersRepo, templateUseCase, authorizer)
+		// Large imports are handed to cmd/rabbitmq_worker
+		importQueue := rmqProducer.NewJobQueue(channelManager, rmqConfig.ImportQueueName, rmqConfig.ImportRoutingKey)
+		boardExportUseCase := boardExport.NewUseCase(boardExport.NewRepository(), authorizer, importQueue)
 		taskTabUseCase := taskTab.NewUseCase(taskTabRepo)
 		taskCardUseCase := taskCard.NewUseCase(taskCardRepo)
 		labelsUseCase := labels.NewUseCase(labelsRepo)
-		taskCardCommentUseCase := taskCardComment.NewUseCase(taskCardCommentRepo)
-		taskCardUsersUseCase := taskCardUsers.NewUseCase(taskCardUsersRepo)
-		workspaceRepoAdapter := workspaces.NewRepositoryAdapter(workspaceRepo)
-		workspacesUsersUseCase := workspacesUsers.NewUseCase(workspacesUsersRepo, workspaceRepoAdapter, cfg)
+		taskCardCommentUseCase := taskCardComment.NewUseCase(taskCardCommentRepo, authorizer, boardLocator)
+		taskCardUsersUseCase := taskCardUsers.NewUseCase(taskCardUsersRepo, notificationUseCase)
 		boardRepoAdapter := boards.NewRepositoryAdapter(boardsRepo)
-		boardWorkspaceRepoAdapter := workspaces.NewBoardWorkspaceRepositoryAdapter(workspaceRepo)
-		boardsUsersUseCase := boardsUsers.NewUseCase(boardsUsersRepo, boardRepoAdapter, boardWorkspaceRepoAdapter, cfg)
+		inviteLinkUseCase := inviteLink.NewUseCase(inviteLink.NewRepository(), userRepo, workspaceRepoAdapter, boardRepoAdapter, authorizer, cfg)
+		workspacesUsersUseCase := workspacesUsers.NewUseCase(workspacesUsersRepo, workspaceRepoAdapter, inviteLink.NewWorkspaceAdapter(inviteLinkUseCase), authorizer, notificationUseCase, cfg)
+		joinRequestUseCase := joinRequest.NewUseCase(joinRequest.NewRepository(), userRepo, workspaceRepoAdapter, workspacesUsersRepo, authorizer, notificationUseCase)
+		boardsUsersUseCase := boardsUsers.NewUseCase(boardsUsersRepo, boardRepoAdapter, inviteLink.NewBoardAdapter(inviteLinkUseCase), authorizer, notificationUseCase, cfg)
 		roomChatUseCase := room_chats.NewUseCase(roomChatRepo, uploadService, cfg.Supabase.S3.Bucket)
 		roomUserUseCase := roomUsers.NewUseCase(roomUserRepo)
 		roomMessageUseCase := room_messages.NewUseCase(roomMessageRepo)
+		passwordResetUseCase := passwordReset.NewUseCase(passwordResetRepo, userRepo, mail, settingsUseCase, cfg)
+		mfaUseCase := mfa.NewUseCase(mfaRepo, userRepo)
+		adminUseCase := admin.NewUseCase(admin.NewRepository(), userRepo, mfaUseCase)
+		if err := adminUseCase.BootstrapAdmins(cfg.Auth.AdminEmails); err != nil {
+			log.Printf("Failed to promote configured admins: %v", err)
+		}
+		sessionUseCase := session.NewUseCase()
+		accessTokenUseCase := accessToken.NewUseCase(accessTokenRepo, userRepo)
+
+		// Single sign-on stays disabled unless configured for this environment
+		var ssoProvider sso.Provider
+		if cfg.OIDC.Enabled {
+			ssoProvider = oidc.NewProvider(oidc.Config{
+				Issuer:       cfg.OIDC.Issuer,
+				ClientID:     cfg.OIDC.ClientID,
+				ClientSecret: cfg.OIDC.ClientSecret,
+				RedirectURL:  cfg.OIDC.RedirectURL,
+				Scopes:       cfg.OIDC.Scopes,
+			}, nil)
+		}
+		ssoUseCase := sso.NewUseCase(ssoRepo, userRepo, ssoProvider, cfg.OIDC.Issuer)
 
 		// Initialize Handlers
 		userHandler := user.NewHandler(userUseCase)
-		workspaceHandler := workspaces.NewHandler(workspaceUseCase)
-		boardsHandler := boards.NewHandler(boardsUseCase)
+		workspaceHandler := workspaces.NewHandler(workspaceUseCase, auditUseCase)
+		boardsHandler := boards.NewHandler(boardsUseCase, auditUseCase)
+		boardExportHandler := boardExport.NewHandler(boardExportUseCase, auditUseCase)
+		templateHandler := template.NewHandler(templateUseCase, auditUseCase)
 		taskTabHandler := taskTab.NewHandler(taskTabUseCase)
 		taskCardHandler := taskCard.NewHandler(taskCardUseCase)
 		labelsHandler := labels.NewHandler(labelsUseCase)
 		taskCardCommentHandler := taskCardComment.NewHandler(taskCardCommentUseCase)
 		taskCardUsersHandler := taskCardUsers.NewHandler(taskCardUsersUseCase)
-		workspacesUsersHandler := workspacesUsers.NewHandler(workspacesUsersUseCase)
-		boardsUsersHandler := boardsUsers.NewHandler(boardsUsersUseCase)
+		workspacesUsersHandler := workspacesUsers.NewHandler(workspacesUsersUseCase, auditUseCase)
+		auditHandler := audit.NewHandler(auditUseCase)
+		activityHandler := activity.NewHandler(activityUseCase)
+		invitationHandler := invitation.NewHandler(invitationUseCase, auditUseCase)
+		inviteLinkHandler := inviteLink.NewHandler(inviteLinkUseCase, auditUseCase)
+		joinRequestHandler := joinRequest.NewHandler(joinRequestUseCase, auditUseCase)
+		boardsUsersHandler := boardsUsers.NewHandler(boardsUsersUseCase, auditUseCase)
 		roomChatHandler := room_chats.NewHandler(roomChatUseCase)
 		roomUserHandler := roomUsers.NewHandler(roomUserUseCase)
+		passwordResetHandler := passwordReset.NewHandler(passwordResetUseCase)
+		emailVerificationHandler := emailVerification.NewHandler(emailVerificationUseCase)
+		mfaHandler := mfa.NewHandler(mfaUseCase)
+		adminHandler := admin.NewHandler(adminUseCase)
+		sessionHandler := session.NewHandler(sessionUseCase)
+		accessTokenHandler := accessToken.NewHandler(accessTokenUseCase)
+		accountHandler := account.NewHandler(accountUseCase)
+		settingsHandler := settings.NewHandler(settingsUseCase)
 
 		// Contact UseCase and Handler
 		contactUseCase := contact.NewUseCase(contactRepo, storageRepo)
 		contactHandler := contact.NewHandler(contactUseCase, cfg.Supabase.S3.Bucket)
 
-		// WebSocket handler
-		wsHandler := websocket.NewHandler(hub, taskCardUseCase, taskTabUseCase, taskCardCommentUseCase, labelsUseCase, taskCardUsersUseCase, boardsUsersUseCase, workspacesUsersUseCase, boardsUseCase, roomMessageUseCase, roomChatUseCase, roomUserUseCase, contactUseCase, userUseCase)
+		// WebSocket handler; its board mutations also feed the board activity
+		wsHandler := websocket.NewHandler(hub, taskCardUseCase, taskTabUseCase, taskCardCommentUseCase, labelsUseCase, taskCardUsersUseCase, boardsUsersUseCase, workspacesUsersUseCase, boardsUseCase, roomMessageUseCase, roomChatUseCase, roomUserUseCase, contactUseCase, userUseCase, authorizer, activity.NewRecorder(auditUseCase, activityUseCase))
+
+		// Board-scoped REST routes check the caller's board role on the board
+		// each request targets, like the WebSocket guard does
+		canView := func(targets ...middleware.BoardTarget) gin.HandlerFunc {
+			return middleware.BoardAccess(authorizer, policy.ActionView, targets...)
+		}
+		canComment := func(targets ...middleware.BoardTarget) gin.HandlerFunc {
+			return middleware.BoardAccess(authorizer, policy.ActionComment, targets...)
+		}
+		canEdit := func(targets ...middleware.BoardTarget) gin.HandlerFunc {
+			return middleware.BoardAccess(authorizer, policy.ActionEdit, targets...)
+		}
+		tabParam := middleware.ParamTarget("id", boardLocator.BoardIDByTaskTab)
+		cardParam := middleware.ParamTarget("id", boardLocator.BoardIDByTaskCard)
+		tabBody := middleware.BodyTarget("task_tab_id", boardLocator.BoardIDByTaskTab)
+		cardBody := middleware.BodyTarget("task_card_id", boardLocator.BoardIDByTaskCard)
+		boardBody := middleware.BodyTarget("board_id", nil)
 
 		// auth handler needs repo + cfg
-		authHandler := auth.NewHandler(userRepo, cfg)
+		authHandler := auth.NewHandler(userRepo, mfaUseCase, ssoUseCase, loginguard.New(database.RDB, cfg), auditUseCase, cfg)
 
 		// Note: NewSupabaseStorageRepository creates its own client internally in current implementation
 		// Ideally we should inject the client if following the user's manual wiring request exactly,
@@ -170,20 +283,95 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 
 		storageHandler := storage.NewHandler(storageRepo, cfg.Supabase.S3.Bucket)
 
+		// Public keys for verifying our tokens, served at the conventional root path
+		r.GET("/.well-known/jwks.json", authHandler.JWKS)
+
 		api.POST("/login", authHandler.Login)
+		api.POST("/login/mfa", authHandler.LoginMFA)
+		api.GET("/oidc/login", authHandler.OIDCLogin)
+		api.GET("/oidc/callback", authHandler.OIDCCallback)
 
 		// Upload route
 		api.POST("/upload", middleware.AuthMiddleware(cfg), storageHandler.UploadFile)
 		api.POST("/logout", authHandler.Logout)
 		api.POST("/refresh-token", authHandler.RefreshToken)
+		api.POST("/forgot-password", passwordResetHandler.ForgotPassword)
+		api.POST("/reset-password", passwordResetHandler.ResetPassword)
+		api.GET("/verify-email", emailVerificationHandler.VerifyEmail)
+		api.POST("/verify-email/resend", emailVerificationHandler.Resend)
 
 		user := api.Group("/users")
 		{
 			user.POST("/", userHandler.Register)
-			user.GET("/", userHandler.GetAll)
-			user.GET("/:id", userHandler.GetByID)
-			user.PUT("/:id", userHandler.Update)
-			user.DELETE("/:id", userHandler.Delete)
+
+			me := user.Group("/me")
+			me.Use(middleware.AuthMiddleware(cfg))
+			{
+				me.DELETE("", accountHandler.Close)
+				me.GET("/export", accountHandler.Export)
+				me.GET("/settings", settingsHandler.Get)
+				me.PUT("/settings", settingsHandler.Update)
+			}
+
+			protected := user.Group("/")
+			protected.Use(middleware.AuthMiddleware(cfg))
+			{
+				protected.GET("/", middleware.AdminOnly(adminUseCase), userHandler.GetAll)
+				protected.GET("/:id", userHandler.GetByID)
+				protected.PUT("/:id", middleware.SelfOrAdmin(adminUseCase, "id"), userHandler.Update)
+				protected.DELETE("/:id", middleware.AdminOnly(adminUseCase), userHandler.Delete)
+			}
+		}
+
+		mfaRoutes := api.Group("/mfa")
+		{
+			protected := mfaRoutes.Group("/")
+			protected.Use(middleware.AuthMiddleware(cfg))
+			{
+				protected.GET("/", mfaHandler.Status)
+				protected.POST("/enroll", mfaHandler.Enroll)
+				protected.POST("/confirm", mfaHandler.Confirm)
+				protected.POST("/disable", mfaHandler.Disable)
+				protected.POST("/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+			}
+		}
+
+		sessions := api.Group("/sessions")
+		{
+			protected := sessions.Group("/")
+			protected.Use(middleware.AuthMiddleware(cfg))
+			{
+				protected.GET("/", sessionHandler.GetAll)
+				protected.DELETE("/", sessionHandler.DeleteAll)
+				protected.DELETE("/:id", sessionHandler.Delete)
+			}
+		}
+
+		adminRoutes := api.Group("/admin")
+		adminRoutes.Use(middleware.AuthMiddleware(cfg), middleware.AdminOnly(adminUseCase))
+		{
+			adminRoutes.POST("/unlock-login", authHandler.UnlockLogin)
+			adminRoutes.GET("/users", adminHandler.ListUsers)
+			adminRoutes.GET("/users/:id", adminHandler.GetUser)
+			adminRoutes.POST("/users/:id/disable", adminHandler.Disable)
+			adminRoutes.POST("/users/:id/enable", adminHandler.Enable)
+			adminRoutes.POST("/users/:id/logout", adminHandler.ForceLogout)
+			adminRoutes.PUT("/users/:id/role", adminHandler.UpdateRole)
+		}
+
+		// Personal access tokens can only be managed from a logged-in session
+
+		accessTokens := api.Group("/access-tokens")
+		{
+			protected := accessTokens.Group("/")
+			protected.Use(middleware.AuthMiddleware(cfg))
+			{
+				protected.POST("/", accessTokenHandler.Create)
+				protected.GET("/", accessTokenHandler.GetAll)
+				protected.GET("/:id", accessTokenHandler.GetByID)
+				protected.PUT("/:id", accessTokenHandler.Update)
+				protected.DELETE("/:id", accessTokenHandler.Delete)
+			}
 		}
 
 		contacts := api.Group("/contacts")
@@ -201,23 +389,100 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 			// workspace.GET("/", workspaceHandler.GetAll)
 
 			protected := workspace.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceWorkspaces))
 			{
 				protected.POST("/", workspaceHandler.Create)
 				protected.GET("/", workspaceHandler.GetByUserID)
 				protected.GET("/guest", workspaceHandler.GetGuestWorkspaces)
+				protected.GET("/directory", workspaceHandler.Directory)
+				protected.GET("/archived", workspaceHandler.GetArchived)
+				protected.GET("/trash", workspaceHandler.GetTrash)
 				protected.GET("/:id", workspaceHandler.GetByID)
 				protected.DELETE("/:id", workspaceHandler.Delete)
 				protected.PUT("/:id", workspaceHandler.Update)
+				protected.POST("/:id/archive", workspaceHandler.Archive)
+				protected.POST("/:id/unarchive", workspaceHandler.Unarchive)
+				protected.POST("/:id/restore", workspaceHandler.Restore)
+				protected.POST("/:id/duplicate", workspaceHandler.Duplicate)
+				protected.POST("/:id/import", boardExportHandler.Import)
+				protected.GET("/:id/templates", templateHandler.GetByWorkspaceID)
 				protected.POST("/join", workspacesUsersHandler.Join)
-				protected.GET("/:id/join-token", workspacesUsersHandler.GenerateJoinToken)
+				protected.POST("/:id/join", workspaceHandler.JoinPublic)
+				protected.POST("/:id/join-requests", joinRequestHandler.Create)
+				protected.GET("/:id/join-requests", joinRequestHandler.GetByWorkspaceID)
+				protected.POST("/:id/leave", workspacesUsersHandler.Leave)
+				protected.POST("/:id/ownership-transfer", workspacesUsersHandler.RequestTransfer)
+				protected.GET("/:id/ownership-transfer", workspacesUsersHandler.GetTransfer)
+				protected.POST("/:id/ownership-transfer/accept", workspacesUsersHandler.AcceptTransfer)
+				protected.POST("/:id/ownership-transfer/decline", workspacesUsersHandler.DeclineTransfer)
+				protected.DELETE("/:id/ownership-transfer", workspacesUsersHandler.CancelTransfer)
+				protected.POST("/:id/invite-links", inviteLinkHandler.CreateForWorkspace)
+				protected.GET("/:id/invite-links", inviteLinkHandler.GetByWorkspaceID)
+				protected.GET("/:id/audit", auditHandler.List)
+				protected.POST("/:id/invitations", invitationHandler.Create)
+				protected.GET("/:id/invitations", invitationHandler.GetByWorkspaceID)
+			}
+		}
+
+		invitations := api.Group("/invitations")
+		{
+			invitations.POST("/decline", invitationHandler.DeclineToken)
+
+			protected := invitations.Group("/")
+			protected.Use(middleware.AuthMiddleware(cfg))
+			{
+				protected.GET("/me", invitationHandler.GetMine)
+				protected.POST("/accept", invitationHandler.AcceptToken)
+				protected.POST("/:id/accept", invitationHandler.Accept)
+				protected.POST("/:id/decline", invitationHandler.Decline)
+				protected.POST("/:id/resend", invitationHandler.Resend)
+				protected.DELETE("/:id", invitationHandler.Revoke)
+			}
+		}
+
+		joinRequests := api.Group("/join-requests")
+		{
+			protected := joinRequests.Group("/")
+			protected.Use(middleware.AuthMiddleware(cfg))
+			{
+				protected.GET("/me", joinRequestHandler.GetMine)
+				protected.POST("/:id/approve", joinRequestHandler.Approve)
+				protected.POST("/:id/reject", joinRequestHandler.Reject)
+				protected.DELETE("/:id", joinRequestHandler.Cancel)
+			}
+		}
+
+		templates := api.Group("/templates")
+		{
+			protected := templates.Group("/")
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceBoards))
+			{
+				protected.DELETE("/:id", templateHandler.Delete)
+			}
+		}
+
+		importJobs := api.Group("/import-jobs")
+		{
+			protected := importJobs.Group("/")
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceBoards))
+			{
+				protected.GET("/:id", boardExportHandler.GetJob)
+			}
+		}
+
+		inviteLinks := api.Group("/invite-links")
+		{
+			protected := inviteLinks.Group("/")
+			protected.Use(middleware.AuthMiddleware(cfg))
+			{
+				protected.DELETE("/:id", inviteLinkHandler.Revoke)
 			}
 		}
 
 		boards := api.Group("/boards")
 		{
 			protected := boards.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceBoards))
 			{
 				protected.POST("/", boardsHandler.CreateBoard)
 				protected.GET("/", boardsHandler.GetByUserID)
@@ -225,84 +490,92 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 				protected.GET("/workspace/:workspace_id", boardsHandler.GetByWorkspaceID)
 				protected.DELETE("/:id", boardsHandler.DeleteBoard)
 				protected.PUT("/:id", boardsHandler.UpdateBoard)
+				protected.POST("/:id/duplicate", boardsHandler.DuplicateBoard)
+				protected.POST("/:id/template", templateHandler.SaveFromBoard)
 				protected.POST("/join", boardsUsersHandler.Join)
-				protected.GET("/:id/join-token", boardsUsersHandler.GenerateJoinToken)
-				protected.GET("/:id/tabs", boardsHandler.GetBoardTabs)
-				protected.GET("/tabs/:tab_id/cards", boardsHandler.GetTabCards)
+				protected.POST("/:id/invite-links", inviteLinkHandler.CreateForBoard)
+				protected.GET("/:id/invite-links", inviteLinkHandler.GetByBoardID)
+				protected.GET("/:id/tabs", canView(middleware.BoardParam("id")), boardsHandler.GetBoardTabs)
+				protected.GET("/:id/activity", canView(middleware.BoardParam("id")), activityHandler.ListByBoard)
+				protected.GET("/:id/export", canView(middleware.BoardParam("id")), boardExportHandler.Export)
+				protected.GET("/tabs/:tab_id/cards", canView(middleware.ParamTarget("tab_id", boardLocator.BoardIDByTaskTab)), boardsHandler.GetTabCards)
 			}
 		}
 
 		taskTab := api.Group("/task-tabs")
 		{
 			protected := taskTab.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceBoards))
 			{
-				protected.POST("/", taskTabHandler.Create)
-				protected.GET("/", taskTabHandler.GetAll)
-				protected.GET("/:id", taskTabHandler.GetByID)
-				protected.DELETE("/:id", taskTabHandler.Delete)
-				protected.PUT("/:id", taskTabHandler.Update)
+				protected.POST("/", canEdit(boardBody), taskTabHandler.Create)
+				protected.GET("/:id", canView(tabParam), taskTabHandler.GetByID)
+				protected.DELETE("/:id", canEdit(tabParam), taskTabHandler.Delete)
+				protected.PUT("/:id", canEdit(tabParam, boardBody), taskTabHandler.Update)
 			}
 		}
 
 		taskCard := api.Group("/task-cards")
 		{
 			protected := taskCard.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceCards))
 			{
-				protected.POST("/", taskCardHandler.Create)
-				protected.GET("/", taskCardHandler.GetAll)
-				protected.GET("/:id", taskCardHandler.GetByID)
-				protected.GET("/task-tab/:task_tab_id", taskCardHandler.GetByTaskTabID)
-				protected.DELETE("/:id", taskCardHandler.Delete)
-				protected.PUT("/:id", taskCardHandler.Update)
+				protected.POST("/", canEdit(tabBody), taskCardHandler.Create)
+				protected.GET("/:id", canView(cardParam), taskCardHandler.GetByID)
+				protected.GET("/:id/activity", canView(cardParam), activityHandler.ListByTaskCard)
+				protected.GET("/task-tab/:task_tab_id", canView(middleware.ParamTarget("task_tab_id", boardLocator.BoardIDByTaskTab)), taskCardHandler.GetByTaskTabID)
+				protected.DELETE("/:id", canEdit(cardParam), taskCardHandler.Delete)
+				protected.PUT("/:id", canEdit(cardParam, tabBody), taskCardHandler.Update)
 			}
 		}
 
 		labels := api.Group("/labels")
 		{
 			protected := labels.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceCards))
 			{
-				protected.POST("/", labelsHandler.Create)
-				protected.GET("/", labelsHandler.GetAll)
-				protected.GET("/:id", labelsHandler.GetByID)
-				protected.GET("/task-card/:task_card_id", labelsHandler.GetByTaskCardID)
-				protected.DELETE("/:id", labelsHandler.Delete)
-				protected.PUT("/:id", labelsHandler.Update)
+				labelParam := middleware.ParamTarget("id", boardLocator.BoardIDByLabel)
+
+				protected.POST("/", canEdit(cardBody), labelsHandler.Create)
+				protected.GET("/:id", canView(labelParam), labelsHandler.GetByID)
+				protected.GET("/task-card/:task_card_id", canView(middleware.ParamTarget("task_card_id", boardLocator.BoardIDByTaskCard)), labelsHandler.GetByTaskCardID)
+				protected.DELETE("/:id", canEdit(labelParam), labelsHandler.Delete)
+				protected.PUT("/:id", canEdit(labelParam, cardBody), labelsHandler.Update)
 			}
 		}
 
 		taskCardComment := api.Group("/task-card-comments")
 		{
 			protected := taskCardComment.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceCards))
 			{
-				protected.POST("/", taskCardCommentHandler.CreateTaskCardComment)
-				protected.GET("/", taskCardCommentHandler.GetAllTaskCardComment)
-				protected.GET("/:id", taskCardCommentHandler.GetTaskCardCommentByID)
-				protected.GET("/task-card/:task_card_id", taskCardCommentHandler.GetTaskCardCommentByTaskCardID)
-				protected.DELETE("/:id", taskCardCommentHandler.DeleteTaskCardComment)
-				protected.PUT("/:id", taskCardCommentHandler.UpdateTaskCardComment)
+				commentParam := middleware.ParamTarget("id", boardLocator.BoardIDByComment)
+
+				protected.POST("/", canComment(cardBody), taskCardCommentHandler.CreateTaskCardComment)
+				protected.GET("/:id", canView(commentParam), taskCardCommentHandler.GetTaskCardCommentByID)
+				protected.GET("/task-card/:task_card_id", canView(middleware.ParamTarget("task_card_id", boardLocator.BoardIDByTaskCard)), taskCardCommentHandler.GetTaskCardCommentByTaskCardID)
+				protected.DELETE("/:id", canComment(commentParam), taskCardCommentHandler.DeleteTaskCardComment)
+				protected.PUT("/:id", canComment(commentParam), taskCardCommentHandler.UpdateTaskCardComment)
 			}
 		}
 
 		taskCardUsers := api.Group("/task-card-users")
 		{
 			protected := taskCardUsers.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceCards))
 			{
-				protected.POST("/", taskCardUsersHandler.CreateTaskCardUser)
-				protected.GET("/task-card/:task_card_id", taskCardUsersHandler.GetTaskCardUserByTaskCardID)
-				protected.PUT("/:id", taskCardUsersHandler.Update)
-				protected.DELETE("/:id", taskCardUsersHandler.Delete)
+				assigneeParam := middleware.ParamTarget("id", boardLocator.BoardIDByTaskCardUser)
+
+				protected.POST("/", canEdit(cardBody), taskCardUsersHandler.CreateTaskCardUser)
+				protected.GET("/task-card/:task_card_id", canView(middleware.ParamTarget("task_card_id", boardLocator.BoardIDByTaskCard)), taskCardUsersHandler.GetTaskCardUserByTaskCardID)
+				protected.PUT("/:id", canEdit(assigneeParam, cardBody), taskCardUsersHandler.Update)
+				protected.DELETE("/:id", canEdit(assigneeParam), taskCardUsersHandler.Delete)
 			}
 		}
 
 		workspacesUsers := api.Group("/workspaces-users")
 		{
 			protected := workspacesUsers.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceWorkspaces))
 			{
 				protected.POST("/", workspacesUsersHandler.Create)
 				protected.GET("/workspace/:workspace_id", workspacesUsersHandler.GetByWorkspaceID)
@@ -316,7 +589,7 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 		roomChats := api.Group("/room-chats")
 		{
 			protected := roomChats.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceChat))
 			{
 				protected.POST("/", roomChatHandler.Create)
 				protected.POST("/upload", roomChatHandler.UploadAttachment)
@@ -330,7 +603,7 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 		roomUsers := api.Group("/room-users")
 		{
 			protected := roomUsers.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceChat))
 			{
 				protected.POST("/join", roomUserHandler.Join)
 				protected.GET("/room/:room_id", roomUserHandler.GetUsersByRoom)
@@ -340,12 +613,12 @@ func SetupRouter(cfg *config.Config, channelManager *rmqManager.ChannelManager,
 		boardsUsers := api.Group("/boards-users")
 		{
 			protected := boardsUsers.Group("/")
-			protected.Use(middleware.AuthMiddleware(cfg))
+			protected.Use(middleware.ScopedAuthMiddleware(cfg, accessTokenUseCase, policy.ResourceBoards))
 			{
 				protected.POST("/", boardsUsersHandler.Create)
-				protected.GET("/board/:board_id", boardsUsersHandler.GetByBoardID)
+				protected.GET("/board/:board_id", canView(middleware.BoardParam("board_id")), boardsUsersHandler.GetByBoardID)
 				protected.GET("/user", boardsUsersHandler.GetByUserID)
-				protected.GET("/:id", boardsUsersHandler.GetByID)
+				protected.GET("/:id", canView(middleware.ParamTarget("id", boardLocator.BoardIDByBoardUser)), boardsUsersHandler.GetByID)
 				protected.PUT("/:id", boardsUsersHandler.Update)
 				protected.DELETE("/:id", boardsUsersHandler.Delete)
 			}
@@ -0,0 +1,74 @@
+package accessToken
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+// TokenPrefix marks personal access tokens so they are recognisable in logs and secret scanners
+const TokenPrefix = "tpat_"
+
+// ScopeList is stored as a space-separated string
+type ScopeList []policy.Scope
+
+func (s ScopeList) Value() (driver.Value, error) {
+	parts := make([]string, len(s))
+	for i, scope := range s {
+		parts[i] = string(scope)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+func (s *ScopeList) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+		raw = ""
+	default:
+		return fmt.Errorf("unsupported scopes type %T", value)
+	}
+
+	*s = ScopeList{}
+	for _, part := range strings.Fields(raw) {
+		*s = append(*s, policy.Scope(part))
+	}
+	return nil
+}
+
+// PersonalAccessToken lets scripts act as a user with limited scopes. Only the hash is stored.
+type PersonalAccessToken struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"user_id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"` // first characters, to tell tokens apart
+	TokenHash   string     `json:"-"`
+	Scopes      ScopeList  `json:"scopes" gorm:"type:text"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type CreateRequest struct {
+	Name      string         `json:"name" binding:"required,max=100"`
+	Scopes    []policy.Scope `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time     `json:"expires_at"` // optional, RFC 3339
+}
+
+type UpdateRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// CreateResponse carries the plain token, which is shown only once
+type CreateResponse struct {
+	Token string `json:"token"`
+	*PersonalAccessToken
+}
@@ -0,0 +1,146 @@
+package accessToken
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := h.usecase.Create(userID.(uint), &req)
+	if err != nil {
+		if errors.Is(err, ErrInvalidScope) || errors.Is(err, ErrInvalidExpiry) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to create access token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": data})
+}
+
+func (h *Handler) GetAll(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	data, err := h.usecase.GetAll(userID.(uint))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, data)
+}
+
+func (h *Handler) GetByID(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	data, err := h.usecase.GetByID(uint(id), userID.(uint))
+	if err != nil {
+		h.handleLookupError(c, err)
+		return
+	}
+
+	response.Success(c, data)
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	var req UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := h.usecase.Update(uint(id), userID.(uint), &req)
+	if err != nil {
+		h.handleLookupError(c, err)
+		return
+	}
+
+	response.Success(c, data)
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	if err := h.usecase.Delete(uint(id), userID.(uint)); err != nil {
+		h.handleLookupError(c, err)
+		return
+	}
+
+	response.DeleteSuccess(c, "Access token revoked successfully")
+}
+
+func (h *Handler) handleLookupError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Error(c, http.StatusNotFound, "Access token not found")
+		return
+	}
+	response.Error(c, http.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,66 @@
+package accessToken
+
+import (
+	"errors"
+	"time"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(token *PersonalAccessToken) error
+	FindByUserID(userID uint) ([]PersonalAccessToken, error)
+	FindByID(id uint) (*PersonalAccessToken, error)
+	FindByTokenHash(tokenHash string) (*PersonalAccessToken, error)
+	Update(token *PersonalAccessToken) error
+	UpdateLastUsed(id uint, usedAt time.Time) error
+	Delete(id uint) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(token *PersonalAccessToken) error {
+	return database.DB.Create(token).Error
+}
+
+func (r *repository) FindByUserID(userID uint) ([]PersonalAccessToken, error) {
+	var tokens []PersonalAccessToken
+	err := database.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *repository) FindByID(id uint) (*PersonalAccessToken, error) {
+	var token PersonalAccessToken
+	err := database.DB.First(&token, id).Error
+	return &token, err
+}
+
+func (r *repository) FindByTokenHash(tokenHash string) (*PersonalAccessToken, error) {
+	var token PersonalAccessToken
+	err := database.DB.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *repository) Update(token *PersonalAccessToken) error {
+	return database.DB.Model(&PersonalAccessToken{ID: token.ID}).Updates(token).Error
+}
+
+func (r *repository) UpdateLastUsed(id uint, usedAt time.Time) error {
+	return database.DB.Model(&PersonalAccessToken{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
+
+func (r *repository) Delete(id uint) error {
+	return database.DB.Delete(&PersonalAccessToken{}, id).Error
+}
@@ -0,0 +1,145 @@
+package accessToken
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// lastUsedResolution limits how often LastUsedAt is written for a busy token
+const lastUsedResolution = time.Minute
+
+var (
+	ErrInvalidScope  = errors.New("invalid scope")
+	ErrInvalidExpiry = errors.New("expires_at must be in the future")
+	ErrInvalidToken  = errors.New("invalid or expired access token")
+)
+
+// UserRepository is the subset of user.Repository needed to check token owners
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+}
+
+type UseCase interface {
+	Create(userID uint, req *CreateRequest) (*CreateResponse, error)
+	GetAll(userID uint) ([]PersonalAccessToken, error)
+	GetByID(id, userID uint) (*PersonalAccessToken, error)
+	Update(id, userID uint, req *UpdateRequest) (*PersonalAccessToken, error)
+	Delete(id, userID uint) error
+	Authenticate(token string) (uint, []policy.Scope, error)
+}
+
+type usecase struct {
+	repo     Repository
+	userRepo UserRepository
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository) UseCase {
+	return &usecase{repo: repo, userRepo: userRepo}
+}
+
+func (u *usecase) Create(userID uint, req *CreateRequest) (*CreateResponse, error) {
+	for _, scope := range req.Scopes {
+		if !scope.Valid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidExpiry
+	}
+
+	secret, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	plain := TokenPrefix + secret
+
+	token := &PersonalAccessToken{
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		TokenPrefix: plain[:len(TokenPrefix)+6],
+		TokenHash:   utils.HashToken(plain),
+		Scopes:      ScopeList(req.Scopes),
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if err := u.repo.Create(token); err != nil {
+		return nil, err
+	}
+
+	return &CreateResponse{Token: plain, PersonalAccessToken: token}, nil
+}
+
+func (u *usecase) GetAll(userID uint) ([]PersonalAccessToken, error) {
+	return u.repo.FindByUserID(userID)
+}
+
+// GetByID hides tokens of other users behind gorm.ErrRecordNotFound
+func (u *usecase) GetByID(id, userID uint) (*PersonalAccessToken, error) {
+	token, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if token.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return token, nil
+}
+
+func (u *usecase) Update(id, userID uint, req *UpdateRequest) (*PersonalAccessToken, error) {
+	token, err := u.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Name = strings.TrimSpace(req.Name)
+	if err := u.repo.Update(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (u *usecase) Delete(id, userID uint) error {
+	if _, err := u.GetByID(id, userID); err != nil {
+		return err
+	}
+	return u.repo.Delete(id)
+}
+
+// Authenticate resolves a plain token to its owner and scopes
+func (u *usecase) Authenticate(plain string) (uint, []policy.Scope, error) {
+	if !strings.HasPrefix(plain, TokenPrefix) {
+		return 0, nil, ErrInvalidToken
+	}
+
+	token, err := u.repo.FindByTokenHash(utils.HashToken(plain))
+	if err != nil {
+		return 0, nil, err
+	}
+	if token == nil {
+		return 0, nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if token.ExpiresAt != nil && now.After(*token.ExpiresAt) {
+		return 0, nil, ErrInvalidToken
+	}
+
+	// Tokens of disabled or closed accounts stop authenticating without being revoked
+	owner, err := u.userRepo.FindByID(token.UserID)
+	if err != nil || owner == nil || owner.IsDisabled() || owner.DeletedAt != nil {
+		return 0, nil, ErrInvalidToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > lastUsedResolution {
+		_ = u.repo.UpdateLastUsed(token.ID, now)
+	}
+
+	return token.UserID, token.Scopes, nil
+}
@@ -0,0 +1,109 @@
+package accessToken
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/policy"
+
+	"gorm.io/gorm"
+)
+
+// mockRepository keeps tokens in memory, keyed by hash
+type mockRepository struct {
+	tokens map[string]*PersonalAccessToken
+}
+
+func (m *mockRepository) Create(token *PersonalAccessToken) error {
+	token.ID = uint(len(m.tokens) + 1)
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockRepository) FindByUserID(userID uint) ([]PersonalAccessToken, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) FindByID(id uint) (*PersonalAccessToken, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockRepository) FindByTokenHash(tokenHash string) (*PersonalAccessToken, error) {
+	return m.tokens[tokenHash], nil
+}
+
+func (m *mockRepository) Update(token *PersonalAccessToken) error {
+	return nil
+}
+
+func (m *mockRepository) UpdateLastUsed(id uint, usedAt time.Time) error {
+	return nil
+}
+
+func (m *mockRepository) Delete(id uint) error {
+	return nil
+}
+
+// mockUserRepository behaves like user.Repository, which does not find closed accounts
+type mockUserRepository struct {
+	users map[uint]*user.User
+}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	if u, ok := m.users[id]; ok && u.DeletedAt == nil {
+		return u, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestUseCase_Authenticate(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name      string
+		owner     user.User
+		expiresAt *time.Time
+		wantErr   bool
+	}{
+		{name: "active owner", owner: user.User{ID: 1}},
+		{name: "expired token", owner: user.User{ID: 1}, expiresAt: &past, wantErr: true},
+		{name: "disabled owner", owner: user.User{ID: 1, DisabledAt: &past}, wantErr: true},
+		{name: "closed account", owner: user.User{ID: 1, DeletedAt: &past}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := tt.owner
+			uc := NewUseCase(
+				&mockRepository{tokens: map[string]*PersonalAccessToken{}},
+				&mockUserRepository{users: map[uint]*user.User{owner.ID: &owner}},
+			)
+			created, err := uc.Create(owner.ID, &CreateRequest{Name: "ci", Scopes: []policy.Scope{policy.ScopeBoardsRead}})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			created.ExpiresAt = tt.expiresAt
+
+			userID, _, err := uc.Authenticate(created.Token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("Authenticate() error = %v, want %v", err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil || userID != owner.ID {
+				t.Errorf("Authenticate() = %d, %v, want %d", userID, err, owner.ID)
+			}
+		})
+	}
+}
+
+func TestUseCase_Authenticate_RejectsUnknownTokens(t *testing.T) {
+	uc := NewUseCase(&mockRepository{tokens: map[string]*PersonalAccessToken{}}, &mockUserRepository{})
+	for _, token := range []string{"not-a-pat", TokenPrefix + "unknown"} {
+		if _, _, err := uc.Authenticate(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Authenticate(%q) error = %v, want %v", token, err, ErrInvalidToken)
+		}
+	}
+}
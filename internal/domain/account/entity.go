@@ -0,0 +1,98 @@
+package account
+
+import (
+	"fmt"
+	"time"
+)
+
+// Anonymized values written over a closed account's personal data
+const (
+	DeletedUsername    = "deleted_user"
+	DeletedContactName = "Deleted User"
+)
+
+// DeletedEmail is the unique placeholder email of a closed account
+func DeletedEmail(userID uint) string {
+	return fmt.Sprintf("deleted-%d@deleted.invalid", userID)
+}
+
+type CloseRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// Export is everything we store about a user, for the "download my data" request
+type Export struct {
+	ExportedAt           time.Time             `json:"exported_at"`
+	Profile              Profile               `json:"profile"`
+	Contact              *ContactRecord        `json:"contact"`
+	Settings             *SettingsRecord       `json:"settings"`
+	WorkspaceMemberships []MembershipRecord    `json:"workspace_memberships"`
+	BoardMemberships     []MembershipRecord    `json:"board_memberships"`
+	Cards                []CardRecord          `json:"cards"`
+	Comments             []CommentRecord       `json:"comments"`
+	RoomMessages         []RoomMessageRecord   `json:"room_messages"`
+	DirectMessages       []DirectMessageRecord `json:"direct_messages"`
+}
+
+type Profile struct {
+	ID              uint       `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+}
+
+type ContactRecord struct {
+	Name      string    `json:"name"`
+	Photo     string    `json:"photo"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SettingsRecord struct {
+	Language     *string `json:"language"`
+	Notification *string `json:"notification"`
+}
+
+// MembershipRecord is a workspace or board the user belongs to
+type MembershipRecord struct {
+	ID       uint      `json:"id"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// CardRecord is a task card assigned to the user
+type CardRecord struct {
+	ID        uint      `json:"id"`
+	BoardID   uint      `json:"board_id"`
+	Name      string    `json:"name"`
+	Content   *string   `json:"content"`
+	Date      time.Time `json:"date"`
+	Status    bool      `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CommentRecord struct {
+	ID         uint      `json:"id"`
+	TaskCardID uint      `json:"task_card_id"`
+	Comment    string    `json:"comment"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type RoomMessageRecord struct {
+	ID             uint      `json:"id"`
+	RoomID         uint      `json:"room_id"`
+	MessageText    string    `json:"message_text"`
+	MessageContent *string   `json:"message_content"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type DirectMessageRecord struct {
+	ID             uint      `json:"id"`
+	UserSender     *uint     `json:"user_sender"`
+	UserReceiver   *uint     `json:"user_receiver"`
+	MessageText    string    `json:"message_text"`
+	MessageContent *string   `json:"message_content"`
+	CreatedAt      time.Time `json:"created_at"`
+}
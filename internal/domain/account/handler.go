@@ -0,0 +1,91 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+// Close deletes the caller's own account
+func (h *Handler) Close(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CloseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.usecase.Close(userID.(uint), req.Password); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidPassword):
+			response.Error(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrSoleOwner):
+			response.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			response.Error(c, http.StatusNotFound, "User not found")
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to close account")
+		}
+		return
+	}
+
+	c.SetCookie("access_token", "", -1, "/", "", false, true)
+	c.SetCookie("refresh_token", "", -1, "/", "", false, true)
+
+	response.DeleteSuccess(c, "Account closed successfully")
+}
+
+// Export downloads the caller's personal data, as JSON or with ?format=zip as a ZIP archive
+func (h *Handler) Export(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		response.Error(c, http.StatusBadRequest, "format must be json or zip")
+		return
+	}
+
+	export, err := h.usecase.Export(userID.(uint))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to export data")
+		return
+	}
+
+	filename := fmt.Sprintf("traspac-export-%d-%s.%s", export.Profile.ID, export.ExportedAt.Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if format == "json" {
+		c.JSON(http.StatusOK, export)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+	if err := h.usecase.WriteZip(export, c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,169 @@
+package account
+
+import (
+	"time"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Anonymize(userID uint) error
+	CollectExport(userID uint, export *Export) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+// Anonymize closes the account in one transaction: the user row is soft-deleted and
+// scrubbed, credentials and memberships are removed, and messages and comments stay
+// in place attributed to the anonymized user. Workspaces the user owns are settled
+// first, with their owner rows locked so a co-owner leaving at the same time cannot
+// strand the members: closing fails with ErrSoleOwner while the user is the only
+// owner of a workspace others still belong to, and workspaces nobody else belongs
+// to are moved to the trash.
+func (r *repository) Anonymize(userID uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		email := DeletedEmail(userID)
+
+		var owners []struct {
+			WorkspaceID uint
+			UserID      uint
+		}
+		if err := tx.Raw(`
+			SELECT workspace_id, user_id FROM workspaces_users
+			WHERE role = 'owner' AND workspace_id IN (
+				SELECT workspace_id FROM workspaces_users WHERE user_id = ? AND role = 'owner'
+			)
+			FOR UPDATE`, userID).Scan(&owners).Error; err != nil {
+			return err
+		}
+		otherOwners := map[uint]bool{}
+		for _, owner := range owners {
+			if owner.UserID != userID {
+				otherOwners[owner.WorkspaceID] = true
+			}
+		}
+		var alone []uint
+		for _, owner := range owners {
+			if owner.UserID != userID || otherOwners[owner.WorkspaceID] {
+				continue
+			}
+			var members int64
+			if err := tx.Table("workspaces_users").
+				Where("workspace_id = ? AND user_id <> ?", owner.WorkspaceID, userID).
+				Count(&members).Error; err != nil {
+				return err
+			}
+			if members > 0 {
+				return ErrSoleOwner
+			}
+			alone = append(alone, owner.WorkspaceID)
+		}
+		if len(alone) > 0 {
+			if err := tx.Exec(`UPDATE workspaces SET trashed_at = ?, trashed_by = ?, updated_at = ?
+				WHERE id IN ? AND trashed_at IS NULL`, now, userID, now, alone).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Exec(`UPDATE users SET username = ?, email = ?, password = '', email_verified_at = NULL, deleted_at = ?, updated_at = ?
+			WHERE id = ? AND deleted_at IS NULL`, DeletedUsername, email, now, now, userID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Exec(`UPDATE contacts SET name = ?, photo = '', email = ?, updated_at = ? WHERE user_id = ?`,
+			DeletedContactName, email, now, userID).Error; err != nil {
+			return err
+		}
+
+		for _, table := range []string{
+			// Credentials and preferences
+			"settings",
+			"user_mfa",
+			"mfa_recovery_codes",
+			"user_identities",
+			"personal_access_tokens",
+			"password_reset_tokens",
+			"email_verification_tokens",
+			// Memberships
+			"task_card_users",
+			"room_users",
+			"boards_users",
+			"workspaces_users",
+		} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", userID).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) CollectExport(userID uint, export *Export) error {
+	db := database.DB
+
+	var contacts []ContactRecord
+	if err := db.Table("contacts").Select("name, photo, email, created_at").
+		Where("user_id = ?", userID).Limit(1).Scan(&contacts).Error; err != nil {
+		return err
+	}
+	if len(contacts) > 0 {
+		export.Contact = &contacts[0]
+	}
+
+	var settings []SettingsRecord
+	if err := db.Table("settings").Select("language, notification").
+		Where("user_id = ?", userID).Limit(1).Scan(&settings).Error; err != nil {
+		return err
+	}
+	if len(settings) > 0 {
+		export.Settings = &settings[0]
+	}
+
+	if err := db.Raw(`
+		SELECT w.id, w.name, wu.role, wu.created_at AS joined_at
+		FROM workspaces_users wu JOIN workspaces w ON w.id = wu.workspace_id
+		WHERE wu.user_id = ? ORDER BY wu.created_at`, userID).Scan(&export.WorkspaceMemberships).Error; err != nil {
+		return err
+	}
+
+	if err := db.Raw(`
+		SELECT b.id, b.name, bu.role, bu.created_at AS joined_at
+		FROM boards_users bu JOIN boards b ON b.id = bu.board_id
+		WHERE bu.user_id = ? ORDER BY bu.created_at`, userID).Scan(&export.BoardMemberships).Error; err != nil {
+		return err
+	}
+
+	if err := db.Raw(`
+		SELECT tc.id, tt.board_id, tc.name, tc.content, tc.date, tc.status, tc.created_at
+		FROM task_card_users tcu
+		JOIN task_cards tc ON tc.id = tcu.task_card_id
+		JOIN task_tabs tt ON tt.id = tc.task_tab_id
+		WHERE tcu.user_id = ? ORDER BY tc.id`, userID).Scan(&export.Cards).Error; err != nil {
+		return err
+	}
+
+	if err := db.Table("task_card_comments").Select("id, task_card_id, comment, created_at").
+		Where("user_id = ?", userID).Order("id").Scan(&export.Comments).Error; err != nil {
+		return err
+	}
+
+	if err := db.Table("room_messages").Select("id, room_id, message_text, message_content, created_at").
+		Where("user_id = ?", userID).Order("id").Scan(&export.RoomMessages).Error; err != nil {
+		return err
+	}
+
+	return db.Table("direct_messages").Select("id, user_sender, user_receiver, message_text, message_content, created_at").
+		Where("user_sender = ? OR user_receiver = ?", userID, userID).Order("id").Scan(&export.DirectMessages).Error
+}
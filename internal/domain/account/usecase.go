@@ -0,0 +1,135 @@
+package account
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/utils"
+)
+
+var (
+	ErrInvalidPassword = errors.New("invalid password")
+	ErrSoleOwner       = errors.New("transfer ownership of your workspaces or remove their members before closing your account")
+)
+
+// UserRepository is the subset of user.Repository needed to close and export accounts
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+}
+
+type UseCase interface {
+	Close(userID uint, password string) error
+	Anonymize(userID uint) error
+	Export(userID uint) (*Export, error)
+	WriteZip(export *Export, w io.Writer) error
+}
+
+type usecase struct {
+	repo           Repository
+	userRepo       UserRepository
+	revokeSessions func(userID uint) error
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository) UseCase {
+	return &usecase{
+		repo:           repo,
+		userRepo:       userRepo,
+		revokeSessions: utils.RevokeAllSessions,
+	}
+}
+
+// Close is the self-service account closure, confirmed with the current password
+func (u *usecase) Close(userID uint, password string) error {
+	existing, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if !utils.CheckPasswordHash(password, existing.Password) {
+		return ErrInvalidPassword
+	}
+
+	return u.Anonymize(userID)
+}
+
+// Anonymize soft-deletes the account, scrubs its personal data and logs it out
+// everywhere. It fails with ErrSoleOwner while the user is the only owner of a
+// workspace with other members; workspaces with no other member go to the trash.
+func (u *usecase) Anonymize(userID uint) error {
+	if err := u.repo.Anonymize(userID); err != nil {
+		return err
+	}
+
+	if err := u.revokeSessions(userID); err != nil {
+		log.Printf("[Account] Failed to revoke sessions for closed UserID=%d: %v", userID, err)
+	}
+	return nil
+}
+
+func (u *usecase) Export(userID uint) (*Export, error) {
+	existing, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		ExportedAt: time.Now(),
+		Profile: Profile{
+			ID:              existing.ID,
+			Username:        existing.Username,
+			Email:           existing.Email,
+			EmailVerifiedAt: existing.EmailVerifiedAt,
+			CreatedAt:       existing.CreatedAt,
+		},
+		WorkspaceMemberships: []MembershipRecord{},
+		BoardMemberships:     []MembershipRecord{},
+		Cards:                []CardRecord{},
+		Comments:             []CommentRecord{},
+		RoomMessages:         []RoomMessageRecord{},
+		DirectMessages:       []DirectMessageRecord{},
+	}
+	if err := u.repo.CollectExport(userID, export); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// WriteZip writes the export as a ZIP archive with one JSON file per section
+func (u *usecase) WriteZip(export *Export, w io.Writer) error {
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", map[string]interface{}{
+			"exported_at": export.ExportedAt,
+			"profile":     export.Profile,
+			"contact":     export.Contact,
+			"settings":    export.Settings,
+		}},
+		{"workspace_memberships.json", export.WorkspaceMemberships},
+		{"board_memberships.json", export.BoardMemberships},
+		{"cards.json", export.Cards},
+		{"comments.json", export.Comments},
+		{"room_messages.json", export.RoomMessages},
+		{"direct_messages.json", export.DirectMessages},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
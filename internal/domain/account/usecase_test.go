@@ -0,0 +1,120 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/utils"
+)
+
+type mockRepository struct {
+	soleOwned  []uint
+	anonymized []uint
+}
+
+func (m *mockRepository) Anonymize(userID uint) error {
+	if len(m.soleOwned) > 0 {
+		return ErrSoleOwner
+	}
+	m.anonymized = append(m.anonymized, userID)
+	return nil
+}
+
+func (m *mockRepository) CollectExport(userID uint, export *Export) error {
+	export.Comments = append(export.Comments, CommentRecord{ID: 1, TaskCardID: 2, Comment: "hello"})
+	return nil
+}
+
+type mockUserRepository struct {
+	users map[uint]*user.User
+}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	return m.users[id], nil
+}
+
+func newTestUseCase(t *testing.T, repo *mockRepository) (*usecase, *[]uint) {
+	t.Helper()
+	hashed, err := utils.HashPassword("correct-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revoked := []uint{}
+	uc := &usecase{
+		repo:     repo,
+		userRepo: &mockUserRepository{users: map[uint]*user.User{1: {ID: 1, Email: "a@example.com", Password: hashed}}},
+		revokeSessions: func(userID uint) error {
+			revoked = append(revoked, userID)
+			return nil
+		},
+	}
+	return uc, &revoked
+}
+
+func TestClose(t *testing.T) {
+	repo := &mockRepository{}
+	uc, revoked := newTestUseCase(t, repo)
+
+	if err := uc.Close(1, "wrong-password"); err != ErrInvalidPassword {
+		t.Fatalf("wrong password: err = %v, want ErrInvalidPassword", err)
+	}
+	if len(repo.anonymized) != 0 {
+		t.Fatal("account anonymized with a wrong password")
+	}
+
+	if err := uc.Close(1, "correct-password"); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(repo.anonymized) != 1 || repo.anonymized[0] != 1 {
+		t.Errorf("anonymized = %v, want [1]", repo.anonymized)
+	}
+	if len(*revoked) != 1 {
+		t.Errorf("sessions revoked %d times, want 1", len(*revoked))
+	}
+}
+
+func TestClose_SoleOwner(t *testing.T) {
+	repo := &mockRepository{soleOwned: []uint{7}}
+	uc, revoked := newTestUseCase(t, repo)
+
+	if err := uc.Close(1, "correct-password"); err != ErrSoleOwner {
+		t.Fatalf("err = %v, want ErrSoleOwner", err)
+	}
+	if len(repo.anonymized) != 0 || len(*revoked) != 0 {
+		t.Error("sole owner account was closed")
+	}
+}
+
+func TestExportZip(t *testing.T) {
+	uc, _ := newTestUseCase(t, &mockRepository{})
+
+	export, err := uc.Export(1)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if export.Profile.Email != "a@example.com" || len(export.Comments) != 1 || export.Cards == nil {
+		t.Fatalf("export = %+v", export)
+	}
+
+	var buf bytes.Buffer
+	if err := uc.WriteZip(export, &buf); err != nil {
+		t.Fatalf("WriteZip: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, want := range []string{"profile.json", "comments.json", "room_messages.json", "direct_messages.json"} {
+		if !names[want] {
+			t.Errorf("zip is missing %s", want)
+		}
+	}
+}
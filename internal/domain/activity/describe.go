@@ -0,0 +1,142 @@
+package activity
+
+import (
+	"fmt"
+
+	"hrm-app/internal/domain/audit"
+)
+
+// Names looks up the current names of what a change touched. Lookups return
+// "" (or 0) for rows that no longer exist.
+type Names interface {
+	TaskCardName(id uint) string
+	TaskTabName(id uint) string
+	Username(id uint) string
+	// TaskCardOf finds the card a label, comment or assignee belongs to
+	TaskCardOf(entityType string, id uint) uint
+}
+
+// describe turns an audited board mutation into the feed line that follows the
+// actor's name, and the card it concerns. Actions that are not part of the
+// feed give an empty message.
+func describe(entry audit.Entry, names Names) (string, uint) {
+	c := entry.Changes
+	if len(c) == 0 {
+		return "", 0 // an update that changed nothing
+	}
+
+	switch entry.Action {
+	case "create_task_card":
+		return fmt.Sprintf("added card %s to %s", str(value(c, "name")), names.TaskTabName(id(value(c, "task_tab_id")))), entry.EntityID
+
+	case "update_task_tab_id", "move_task_card", "update_task_card":
+		card := names.TaskCardName(entry.EntityID)
+		if change, ok := c["task_tab_id"]; ok {
+			from, to := names.TaskTabName(id(change.Before)), names.TaskTabName(id(change.After))
+			return fmt.Sprintf("moved card %s from %s to %s", card, from, to), entry.EntityID
+		}
+		if entry.Action == "move_task_card" {
+			return fmt.Sprintf("reordered card %s", card), entry.EntityID
+		}
+		return describeCardUpdate(card, c), entry.EntityID
+
+	case "assign_task_card_user", "unassign_task_card_user":
+		cardID := id(value(c, "task_card_id"))
+		user, card := names.Username(id(value(c, "user_id"))), names.TaskCardName(cardID)
+		if entry.Action == "assign_task_card_user" {
+			return fmt.Sprintf("assigned %s to card %s", user, card), cardID
+		}
+		return fmt.Sprintf("removed %s from card %s", user, card), cardID
+
+	case "create_task_card_comment", "update_task_card_comment", "delete_task_card_comment":
+		cardID := id(value(c, "task_card_id"))
+		if cardID == 0 {
+			cardID = names.TaskCardOf(entry.EntityType, entry.EntityID)
+		}
+		card := names.TaskCardName(cardID)
+		switch entry.Action {
+		case "create_task_card_comment":
+			return fmt.Sprintf("commented on card %s", card), cardID
+		case "update_task_card_comment":
+			return fmt.Sprintf("edited a comment on card %s", card), cardID
+		default:
+			return fmt.Sprintf("deleted a comment on card %s", card), cardID
+		}
+
+	case "create_label", "update_label", "delete_label":
+		cardID := id(value(c, "task_card_id"))
+		if cardID == 0 {
+			cardID = names.TaskCardOf(entry.EntityType, entry.EntityID)
+		}
+		card := names.TaskCardName(cardID)
+		switch entry.Action {
+		case "create_label":
+			return fmt.Sprintf("added label %s to card %s", str(value(c, "title")), card), cardID
+		case "delete_label":
+			return fmt.Sprintf("removed label %s from card %s", str(value(c, "title")), card), cardID
+		}
+		if change, ok := c["title"]; ok {
+			return fmt.Sprintf("renamed label %s to %s on card %s", str(change.Before), str(change.After), card), cardID
+		}
+		return fmt.Sprintf("changed the color of a label on card %s", card), cardID
+
+	case "update_task_tab", "move_task_tab":
+		if change, ok := c["name"]; ok {
+			return fmt.Sprintf("renamed tab %s to %s", str(change.Before), str(change.After)), 0
+		}
+		return fmt.Sprintf("moved tab %s", names.TaskTabName(entry.EntityID)), 0
+
+	case "assign_board_user":
+		return fmt.Sprintf("added %s to the board as %s", names.Username(id(value(c, "user_id"))), str(value(c, "role"))), 0
+	case "unassign_board_user":
+		return fmt.Sprintf("removed %s from the board", names.Username(id(value(c, "user_id")))), 0
+	}
+
+	return "", 0
+}
+
+// describeCardUpdate names the most visible field an update changed
+func describeCardUpdate(card string, c audit.Changes) string {
+	if change, ok := c["status"]; ok {
+		if done, _ := change.After.(bool); done {
+			return fmt.Sprintf("completed card %s", card)
+		}
+		return fmt.Sprintf("reopened card %s", card)
+	}
+	if change, ok := c["name"]; ok {
+		return fmt.Sprintf("renamed card %s to %s", str(change.Before), str(change.After))
+	}
+	if change, ok := c["date"]; ok {
+		return fmt.Sprintf("changed the date of card %s to %s", card, str(change.After))
+	}
+	if _, ok := c["content"]; ok {
+		return fmt.Sprintf("updated the description of card %s", card)
+	}
+	return fmt.Sprintf("updated card %s", card)
+}
+
+// value reads a changed field, preferring the new value; deletes only have the
+// old one
+func value(c audit.Changes, key string) interface{} {
+	change, ok := c[key]
+	if !ok {
+		return nil
+	}
+	if change.After != nil {
+		return change.After
+	}
+	return change.Before
+}
+
+// id reads a JSON number as an id
+func id(v interface{}) uint {
+	if f, ok := v.(float64); ok && f > 0 {
+		return uint(f)
+	}
+	return 0
+}
+
+func str(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,46 @@
+package activity
+
+import (
+	"time"
+
+	"hrm-app/internal/domain/audit"
+)
+
+// Activity is one line of a board's feed, e.g. "alice moved card Login from
+// Todo to Done". Message is written when the change happens, so renaming a
+// card later does not rewrite its history.
+type Activity struct {
+	ID            uint          `json:"id" gorm:"primaryKey"`
+	BoardID       uint          `json:"board_id"`
+	TaskCardID    *uint         `json:"task_card_id,omitempty"` // set for changes to a card, its labels, comments and assignees
+	ActorID       *uint         `json:"actor_id,omitempty"`
+	ActorUsername string        `json:"actor_username,omitempty" gorm:"->"`
+	Action        string        `json:"action"`
+	EntityType    string        `json:"entity_type"`
+	EntityID      uint          `json:"entity_id,omitempty"`
+	Message       string        `json:"message"`
+	Changes       audit.Changes `json:"changes,omitempty" gorm:"type:jsonb"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+func (Activity) TableName() string {
+	return "board_activities"
+}
+
+// ListQuery pages through a feed, newest first
+type ListQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalRows  int64 `json:"total_rows"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type List struct {
+	Activities []Activity `json:"activities"`
+	Pagination Pagination `json:"pagination"`
+}
@@ -0,0 +1,51 @@
+package activity
+
+import (
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler serves the activity feeds. Routes check board access with
+// middleware.BoardAccess, so the handlers only read.
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) ListByBoard(c *gin.Context) {
+	h.list(c, h.usecase.ListByBoard)
+}
+
+func (h *Handler) ListByTaskCard(c *gin.Context) {
+	h.list(c, h.usecase.ListByTaskCard)
+}
+
+func (h *Handler) list(c *gin.Context, find func(id uint, query ListQuery) (*List, error)) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	var query ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := find(uint(id), query)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to list activity")
+		return
+	}
+
+	response.Success(c, list)
+}
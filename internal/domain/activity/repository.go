@@ -0,0 +1,93 @@
+package activity
+
+import (
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Names
+	Create(activity *Activity) error
+	FindByBoardID(boardID uint, query ListQuery) ([]Activity, int64, error)
+	FindByTaskCardID(taskCardID uint, query ListQuery) ([]Activity, int64, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(activity *Activity) error {
+	return database.DB.Create(activity).Error
+}
+
+func (r *repository) FindByBoardID(boardID uint, query ListQuery) ([]Activity, int64, error) {
+	return r.find(database.DB.Model(&Activity{}).Where("board_activities.board_id = ?", boardID), query)
+}
+
+func (r *repository) FindByTaskCardID(taskCardID uint, query ListQuery) ([]Activity, int64, error) {
+	return r.find(database.DB.Model(&Activity{}).Where("board_activities.task_card_id = ?", taskCardID), query)
+}
+
+func (r *repository) find(db *gorm.DB, query ListQuery) ([]Activity, int64, error) {
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var activities []Activity
+	err := db.Select("board_activities.*, users.username AS actor_username").
+		Joins("LEFT JOIN users ON users.id = board_activities.actor_id").
+		Order("board_activities.created_at DESC, board_activities.id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&activities).Error
+	return activities, total, err
+}
+
+func (r *repository) TaskCardName(id uint) string {
+	return r.lookup("task_cards", "name", id)
+}
+
+func (r *repository) TaskTabName(id uint) string {
+	return r.lookup("task_tabs", "name", id)
+}
+
+func (r *repository) Username(id uint) string {
+	return r.lookup("users", "username", id)
+}
+
+// cardTables maps entities that belong to a card to their table
+var cardTables = map[string]string{
+	audit.EntityLabel:           "task_card_labels",
+	audit.EntityTaskCardComment: "task_card_comments",
+	audit.EntityTaskCardUser:    "task_card_users",
+}
+
+func (r *repository) TaskCardOf(entityType string, id uint) uint {
+	table, ok := cardTables[entityType]
+	if !ok || id == 0 {
+		return 0
+	}
+	var cardIDs []uint
+	database.DB.Table(table).Where("id = ?", id).Limit(1).Pluck("task_card_id", &cardIDs)
+	if len(cardIDs) == 0 {
+		return 0
+	}
+	return cardIDs[0]
+}
+
+func (r *repository) lookup(table, column string, id uint) string {
+	if id == 0 {
+		return ""
+	}
+	var values []string
+	database.DB.Table(table).Where("id = ?", id).Limit(1).Pluck(column, &values)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
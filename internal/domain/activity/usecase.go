@@ -0,0 +1,141 @@
+package activity
+
+import (
+	"encoding/json"
+	"log"
+
+	"hrm-app/internal/domain/audit"
+)
+
+// Publisher pushes a message to everyone viewing a board (implemented by websocket.Hub)
+type Publisher interface {
+	BroadcastToBoard(boardID uint, message []byte)
+}
+
+// Auditor stores audit entries (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
+type UseCase interface {
+	Record(entry audit.Entry)
+	ListByBoard(boardID uint, query ListQuery) (*List, error)
+	ListByTaskCard(taskCardID uint, query ListQuery) (*List, error)
+}
+
+type usecase struct {
+	repo      Repository
+	publisher Publisher
+}
+
+func NewUseCase(repo Repository, publisher Publisher) UseCase {
+	return &usecase{repo: repo, publisher: publisher}
+}
+
+// Record adds a board mutation to the board's feed and pushes the new line to
+// the board as "board_activity". Entries without a board, and actions that are
+// not part of the feed, are ignored. Like auditing, a failed write is only
+// logged.
+func (u *usecase) Record(entry audit.Entry) {
+	if entry.BoardID == nil {
+		return
+	}
+	message, taskCardID := describe(entry, u.repo)
+	if message == "" {
+		return
+	}
+
+	actor := entry.ActorUsername
+	if actor == "" {
+		actor = "Someone"
+	}
+	activity := &Activity{
+		BoardID:       *entry.BoardID,
+		ActorID:       entry.ActorID,
+		ActorUsername: entry.ActorUsername,
+		Action:        entry.Action,
+		EntityType:    entry.EntityType,
+		EntityID:      entry.EntityID,
+		Message:       actor + " " + message,
+		Changes:       entry.Changes,
+	}
+	if taskCardID != 0 {
+		activity.TaskCardID = &taskCardID
+	}
+
+	if err := u.repo.Create(activity); err != nil {
+		log.Printf("[Activity] Failed to record %s on board %d: %v", entry.Action, activity.BoardID, err)
+		return
+	}
+
+	if u.publisher == nil {
+		return
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"action": "board_activity",
+		"status": "success",
+		"data":   activity,
+	})
+	u.publisher.BroadcastToBoard(activity.BoardID, payload)
+}
+
+// ListByBoard returns a board's feed, newest first
+func (u *usecase) ListByBoard(boardID uint, query ListQuery) (*List, error) {
+	query = normalize(query)
+	activities, total, err := u.repo.FindByBoardID(boardID, query)
+	if err != nil {
+		return nil, err
+	}
+	return newList(activities, total, query), nil
+}
+
+// ListByTaskCard returns the part of the feed about one card, newest first
+func (u *usecase) ListByTaskCard(taskCardID uint, query ListQuery) (*List, error) {
+	query = normalize(query)
+	activities, total, err := u.repo.FindByTaskCardID(taskCardID, query)
+	if err != nil {
+		return nil, err
+	}
+	return newList(activities, total, query), nil
+}
+
+func normalize(query ListQuery) ListQuery {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 || query.Limit > 100 {
+		query.Limit = 50
+	}
+	return query
+}
+
+func newList(activities []Activity, total int64, query ListQuery) *List {
+	if activities == nil {
+		activities = []Activity{}
+	}
+	return &List{
+		Activities: activities,
+		Pagination: Pagination{
+			Page:       query.Page,
+			Limit:      query.Limit,
+			TotalRows:  total,
+			TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+		},
+	}
+}
+
+// Recorder sends WebSocket board mutations to both the audit log and the
+// activity feed. It is the Auditor handed to websocket.NewHandler.
+type Recorder struct {
+	auditor Auditor
+	feed    UseCase
+}
+
+func NewRecorder(auditor Auditor, feed UseCase) *Recorder {
+	return &Recorder{auditor: auditor, feed: feed}
+}
+
+func (r *Recorder) Record(entry audit.Entry) {
+	r.auditor.Record(entry)
+	r.feed.Record(entry)
+}
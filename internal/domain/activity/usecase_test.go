@@ -0,0 +1,206 @@
+package activity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"hrm-app/internal/domain/audit"
+)
+
+type mockRepository struct {
+	created []Activity
+	query   ListQuery
+}
+
+func (m *mockRepository) Create(activity *Activity) error {
+	m.created = append(m.created, *activity)
+	return nil
+}
+
+func (m *mockRepository) FindByBoardID(boardID uint, query ListQuery) ([]Activity, int64, error) {
+	m.query = query
+	return nil, 120, nil
+}
+
+func (m *mockRepository) FindByTaskCardID(taskCardID uint, query ListQuery) ([]Activity, int64, error) {
+	m.query = query
+	return []Activity{{ID: 1}}, 1, nil
+}
+
+func (m *mockRepository) TaskCardName(id uint) string {
+	return map[uint]string{7: "Login page"}[id]
+}
+
+func (m *mockRepository) TaskTabName(id uint) string {
+	return map[uint]string{1: "Todo", 2: "Done"}[id]
+}
+
+func (m *mockRepository) Username(id uint) string {
+	return map[uint]string{5: "bob"}[id]
+}
+
+func (m *mockRepository) TaskCardOf(entityType string, id uint) uint {
+	if entityType == audit.EntityTaskCardComment && id == 30 {
+		return 7
+	}
+	return 0
+}
+
+type mockPublisher struct {
+	boardID  uint
+	messages [][]byte
+}
+
+func (m *mockPublisher) BroadcastToBoard(boardID uint, message []byte) {
+	m.boardID = boardID
+	m.messages = append(m.messages, message)
+}
+
+func boardEntry(action, entityType string, entityID uint, before, after interface{}) audit.Entry {
+	actorID := uint(1)
+	entry := audit.New(action, entityType, entityID).WithBoard(3).WithChanges(before, after)
+	entry.ActorID = &actorID
+	entry.ActorUsername = "alice"
+	return entry
+}
+
+type card struct {
+	TaskTabID uint   `json:"task_tab_id"`
+	Name      string `json:"name"`
+	Status    bool   `json:"status"`
+}
+
+type label struct {
+	TaskCardID uint   `json:"task_card_id"`
+	Title      string `json:"title"`
+}
+
+type comment struct {
+	TaskCardID int    `json:"task_card_id"`
+	Comment    string `json:"comment"`
+}
+
+func TestRecord_Messages(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    audit.Entry
+		want     string
+		wantCard uint
+	}{
+		{
+			name:     "card moved between tabs",
+			entry:    boardEntry("move_task_card", audit.EntityTaskCard, 7, card{TaskTabID: 1, Name: "Login page"}, card{TaskTabID: 2, Name: "Login page"}),
+			want:     "alice moved card Login page from Todo to Done",
+			wantCard: 7,
+		},
+		{
+			name:     "card completed",
+			entry:    boardEntry("update_task_card", audit.EntityTaskCard, 7, card{TaskTabID: 1, Name: "Login page"}, card{TaskTabID: 1, Name: "Login page", Status: true}),
+			want:     "alice completed card Login page",
+			wantCard: 7,
+		},
+		{
+			name:     "card created",
+			entry:    boardEntry("create_task_card", audit.EntityTaskCard, 7, nil, card{TaskTabID: 1, Name: "Login page"}),
+			want:     "alice added card Login page to Todo",
+			wantCard: 7,
+		},
+		{
+			name:     "label added",
+			entry:    boardEntry("create_label", audit.EntityLabel, 12, nil, label{TaskCardID: 7, Title: "Urgent"}),
+			want:     "alice added label Urgent to card Login page",
+			wantCard: 7,
+		},
+		{
+			name:     "label removed",
+			entry:    boardEntry("delete_label", audit.EntityLabel, 12, label{TaskCardID: 7, Title: "Urgent"}, nil),
+			want:     "alice removed label Urgent from card Login page",
+			wantCard: 7,
+		},
+		{
+			name:     "comment edited finds its card",
+			entry:    boardEntry("update_task_card_comment", audit.EntityTaskCardComment, 30, comment{TaskCardID: 7, Comment: "a"}, comment{TaskCardID: 7, Comment: "b"}),
+			want:     "alice edited a comment on card Login page",
+			wantCard: 7,
+		},
+		{
+			name:  "member added to the board",
+			entry: boardEntry("assign_board_user", audit.EntityBoardUser, 4, nil, map[string]interface{}{"user_id": 5, "role": "editor"}),
+			want:  "alice added bob to the board as editor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{}
+			uc := NewUseCase(repo, nil)
+
+			uc.Record(tt.entry)
+
+			if len(repo.created) != 1 {
+				t.Fatalf("created %d activities, want 1", len(repo.created))
+			}
+			got := repo.created[0]
+			if got.Message != tt.want {
+				t.Errorf("message = %q, want %q", got.Message, tt.want)
+			}
+			var cardID uint
+			if got.TaskCardID != nil {
+				cardID = *got.TaskCardID
+			}
+			if cardID != tt.wantCard || got.BoardID != 3 {
+				t.Errorf("card = %d, board = %d, want card %d on board 3", cardID, got.BoardID, tt.wantCard)
+			}
+		})
+	}
+}
+
+func TestRecord_SkipsOtherEntries(t *testing.T) {
+	repo := &mockRepository{}
+	uc := NewUseCase(repo, nil)
+
+	uc.Record(audit.New("update_workspace", audit.EntityWorkspace, 1).WithWorkspace(1).WithChanges(nil, map[string]string{"name": "x"}))
+	uc.Record(boardEntry("join_board", audit.EntityBoard, 3, nil, map[string]uint{"board_id": 3}))
+	uc.Record(boardEntry("update_task_card", audit.EntityTaskCard, 7, card{Name: "Login page"}, card{Name: "Login page"}))
+
+	if len(repo.created) != 0 {
+		t.Errorf("created %v, want nothing", repo.created)
+	}
+}
+
+func TestRecord_PublishesToBoard(t *testing.T) {
+	publisher := &mockPublisher{}
+	uc := NewUseCase(&mockRepository{}, publisher)
+
+	uc.Record(boardEntry("create_label", audit.EntityLabel, 12, nil, label{TaskCardID: 7, Title: "Urgent"}))
+
+	if len(publisher.messages) != 1 || publisher.boardID != 3 {
+		t.Fatalf("published %d messages to board %d, want 1 to board 3", len(publisher.messages), publisher.boardID)
+	}
+	var message struct {
+		Action string   `json:"action"`
+		Data   Activity `json:"data"`
+	}
+	if err := json.Unmarshal(publisher.messages[0], &message); err != nil {
+		t.Fatal(err)
+	}
+	if message.Action != "board_activity" || message.Data.Message != "alice added label Urgent to card Login page" {
+		t.Errorf("published %+v", message)
+	}
+}
+
+func TestListByBoard_Pagination(t *testing.T) {
+	repo := &mockRepository{}
+	uc := NewUseCase(repo, nil)
+
+	list, err := uc.ListByBoard(3, ListQuery{Page: 0, Limit: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.query.Page != 1 || repo.query.Limit != 50 {
+		t.Errorf("query = %+v, want page 1 limit 50", repo.query)
+	}
+	if list.Activities == nil || list.Pagination.TotalPages != 3 {
+		t.Errorf("list = %+v, want an empty list over 3 pages", list)
+	}
+}
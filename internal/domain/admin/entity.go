@@ -0,0 +1,42 @@
+package admin
+
+import "time"
+
+// UserSummary is a user as seen by administrators, with membership counts
+type UserSummary struct {
+	ID              uint       `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	Name            *string    `json:"name"`
+	SystemRole      string     `json:"system_role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	DisabledAt      *time.Time `json:"disabled_at"`
+	CreatedAt       *time.Time `json:"created_at"`
+	WorkspaceCount  int64      `json:"workspace_count"`
+	BoardCount      int64      `json:"board_count"`
+}
+
+// ListQuery filters the user list. Search matches username, email and contact name.
+type ListQuery struct {
+	Search string `form:"q"`
+	Role   string `form:"role" binding:"omitempty,oneof=user admin"`
+	Status string `form:"status" binding:"omitempty,oneof=active disabled"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalRows  int64 `json:"total_rows"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type UserList struct {
+	Users      []UserSummary `json:"users"`
+	Pagination Pagination    `json:"pagination"`
+}
+
+type UpdateRoleRequest struct {
+	SystemRole string `json:"system_role" binding:"required,oneof=user admin"`
+}
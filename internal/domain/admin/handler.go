@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) ListUsers(c *gin.Context) {
+	var query ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := h.usecase.ListUsers(query)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to list users")
+		return
+	}
+
+	response.Success(c, data)
+}
+
+func (h *Handler) GetUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	data, err := h.usecase.GetUser(id)
+	if err != nil {
+		respondError(c, err, "Failed to get user")
+		return
+	}
+
+	response.Success(c, data)
+}
+
+func (h *Handler) Disable(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.Disable(c.GetUint("user_id"), id); err != nil {
+		respondError(c, err, "Failed to disable user")
+		return
+	}
+
+	response.DeleteSuccess(c, "User disabled successfully")
+}
+
+func (h *Handler) Enable(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.Enable(id); err != nil {
+		respondError(c, err, "Failed to enable user")
+		return
+	}
+
+	response.DeleteSuccess(c, "User enabled successfully")
+}
+
+func (h *Handler) ForceLogout(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ForceLogout(id); err != nil {
+		respondError(c, err, "Failed to log out user")
+		return
+	}
+
+	response.DeleteSuccess(c, "User logged out from all sessions")
+}
+
+func (h *Handler) UpdateRole(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.usecase.UpdateRole(c.GetUint("user_id"), id, req.SystemRole); err != nil {
+		respondError(c, err, "Failed to update role")
+		return
+	}
+
+	response.DeleteSuccess(c, "Role updated successfully")
+}
+
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrSelfLockout):
+		response.Error(c, http.StatusConflict, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback)
+	}
+}
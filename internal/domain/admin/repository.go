@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"strings"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindUsers(query ListQuery) ([]UserSummary, int64, error)
+	FindUser(id uint) (*UserSummary, error)
+	SetDisabledAt(id uint, disabledAt *time.Time) error
+	SetSystemRole(id uint, role string) error
+	PromoteByEmails(emails []string) (int64, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+const summarySelect = `users.id, users.username, users.email, contacts.name, users.system_role,
+	users.email_verified_at, users.disabled_at, users.created_at,
+	(SELECT COUNT(*) FROM workspaces_users wu WHERE wu.user_id = users.id) AS workspace_count,
+	(SELECT COUNT(*) FROM boards_users bu WHERE bu.user_id = users.id) AS board_count`
+
+func summaries() *gorm.DB {
+	return database.DB.Table("users").
+		Joins("LEFT JOIN contacts ON contacts.user_id = users.id").
+		Where("users.deleted_at IS NULL")
+}
+
+func (r *repository) FindUsers(query ListQuery) ([]UserSummary, int64, error) {
+	db := summaries()
+	if search := strings.TrimSpace(query.Search); search != "" {
+		like := "%" + search + "%"
+		db = db.Where("users.username ILIKE ? OR users.email ILIKE ? OR contacts.name ILIKE ?", like, like, like)
+	}
+	if query.Role != "" {
+		db = db.Where("users.system_role = ?", query.Role)
+	}
+	switch query.Status {
+	case "active":
+		db = db.Where("users.disabled_at IS NULL")
+	case "disabled":
+		db = db.Where("users.disabled_at IS NOT NULL")
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []UserSummary
+	err := db.Select(summarySelect).
+		Order("users.id").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Scan(&users).Error
+	return users, total, err
+}
+
+func (r *repository) FindUser(id uint) (*UserSummary, error) {
+	var users []UserSummary
+	if err := summaries().Select(summarySelect).Where("users.id = ?", id).Limit(1).Scan(&users).Error; err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	return &users[0], nil
+}
+
+func (r *repository) SetDisabledAt(id uint, disabledAt *time.Time) error {
+	return r.updateUser(id, map[string]interface{}{"disabled_at": disabledAt})
+}
+
+// SetSystemRole records that an administrator chose the role, which takes it
+// out of PromoteByEmails
+func (r *repository) SetSystemRole(id uint, role string) error {
+	return r.updateUser(id, map[string]interface{}{"system_role": role, "system_role_set_at": time.Now()})
+}
+
+func (r *repository) updateUser(id uint, values map[string]interface{}) error {
+	values["updated_at"] = time.Now()
+	result := database.DB.Model(&user.User{}).Where("id = ? AND deleted_at IS NULL", id).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PromoteByEmails grants the admin role to existing users with the given
+// emails. Only verified addresses count, since anyone can register an email
+// before its owner does, and users whose role an administrator has set are
+// left alone.
+func (r *repository) PromoteByEmails(emails []string) (int64, error) {
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			lowered = append(lowered, email)
+		}
+	}
+	if len(lowered) == 0 {
+		return 0, nil
+	}
+
+	result := database.DB.Model(&user.User{}).
+		Where("LOWER(email) IN ? AND system_role <> ? AND deleted_at IS NULL", lowered, user.SystemRoleAdmin).
+		Where("email_verified_at IS NOT NULL AND system_role_set_at IS NULL").
+		Update("system_role", user.SystemRoleAdmin)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotAdmin        = errors.New("administrator access required")
+	ErrMFARequired     = errors.New("administrators must enable two-factor authentication")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrSelfLockout     = errors.New("administrators cannot disable or demote themselves")
+	ErrAccountDisabled = errors.New("account is disabled")
+)
+
+// UserRepository is the subset of user.Repository needed to check administrators
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+}
+
+// MFAChecker reports whether a user has two-factor authentication enabled
+type MFAChecker interface {
+	IsEnabled(userID uint) (bool, error)
+}
+
+type UseCase interface {
+	AuthorizeAdmin(userID uint) error
+	BootstrapAdmins(emails []string) error
+	ListUsers(query ListQuery) (*UserList, error)
+	GetUser(id uint) (*UserSummary, error)
+	Disable(actorID, id uint) error
+	Enable(id uint) error
+	ForceLogout(id uint) error
+	UpdateRole(actorID, id uint, role string) error
+}
+
+type usecase struct {
+	repo           Repository
+	userRepo       UserRepository
+	mfa            MFAChecker
+	revokeSessions func(userID uint) error
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository, mfa MFAChecker) UseCase {
+	return &usecase{
+		repo:           repo,
+		userRepo:       userRepo,
+		mfa:            mfa,
+		revokeSessions: utils.RevokeAllSessions,
+	}
+}
+
+// AuthorizeAdmin allows active administrators with two-factor authentication,
+// since the admin API exposes every user's personal data.
+func (u *usecase) AuthorizeAdmin(userID uint) error {
+	actor, err := u.userRepo.FindByID(userID)
+	if err != nil || actor == nil || !actor.IsAdmin() {
+		return ErrNotAdmin
+	}
+	if actor.IsDisabled() {
+		return ErrAccountDisabled
+	}
+
+	enabled, err := u.mfa.IsEnabled(userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrMFARequired
+	}
+	return nil
+}
+
+// BootstrapAdmins promotes the configured admin emails, so a fresh deployment has
+// an administrator without touching the database
+func (u *usecase) BootstrapAdmins(emails []string) error {
+	promoted, err := u.repo.PromoteByEmails(emails)
+	if err != nil {
+		return err
+	}
+	if promoted > 0 {
+		log.Printf("[Admin] Promoted %d configured admin account(s)", promoted)
+	}
+	return nil
+}
+
+func (u *usecase) ListUsers(query ListQuery) (*UserList, error) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 || query.Limit > 100 {
+		query.Limit = 20
+	}
+
+	users, total, err := u.repo.FindUsers(query)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []UserSummary{}
+	}
+
+	return &UserList{
+		Users: users,
+		Pagination: Pagination{
+			Page:       query.Page,
+			Limit:      query.Limit,
+			TotalRows:  total,
+			TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+		},
+	}, nil
+}
+
+func (u *usecase) GetUser(id uint) (*UserSummary, error) {
+	summary, err := u.repo.FindUser(id)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, ErrUserNotFound
+	}
+	return summary, nil
+}
+
+// Disable blocks the account from logging in and ends its sessions
+func (u *usecase) Disable(actorID, id uint) error {
+	if actorID == id {
+		return ErrSelfLockout
+	}
+
+	now := time.Now()
+	if err := u.repo.SetDisabledAt(id, &now); err != nil {
+		return notFound(err)
+	}
+	return u.revokeSessions(id)
+}
+
+func (u *usecase) Enable(id uint) error {
+	return notFound(u.repo.SetDisabledAt(id, nil))
+}
+
+func (u *usecase) ForceLogout(id uint) error {
+	if _, err := u.GetUser(id); err != nil {
+		return err
+	}
+	return u.revokeSessions(id)
+}
+
+func (u *usecase) UpdateRole(actorID, id uint, role string) error {
+	if actorID == id && role != user.SystemRoleAdmin {
+		return ErrSelfLockout
+	}
+	return notFound(u.repo.SetSystemRole(id, role))
+}
+
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	return err
+}
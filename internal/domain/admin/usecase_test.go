@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hrm-app/internal/domain/user"
+
+	"gorm.io/gorm"
+)
+
+type mockRepository struct {
+	disabled map[uint]*time.Time
+	roles    map[uint]string
+}
+
+func (m *mockRepository) FindUsers(query ListQuery) ([]UserSummary, int64, error) {
+	return nil, 45, nil
+}
+
+func (m *mockRepository) FindUser(id uint) (*UserSummary, error) {
+	if _, ok := m.roles[id]; !ok {
+		return nil, nil
+	}
+	return &UserSummary{ID: id}, nil
+}
+
+func (m *mockRepository) SetDisabledAt(id uint, disabledAt *time.Time) error {
+	if _, ok := m.roles[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	m.disabled[id] = disabledAt
+	return nil
+}
+
+func (m *mockRepository) SetSystemRole(id uint, role string) error {
+	if _, ok := m.roles[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	m.roles[id] = role
+	return nil
+}
+
+func (m *mockRepository) PromoteByEmails(emails []string) (int64, error) {
+	return 0, nil
+}
+
+type mockUserRepository struct {
+	users map[uint]*user.User
+}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	return m.users[id], nil
+}
+
+type mockMFA map[uint]bool
+
+func (m mockMFA) IsEnabled(userID uint) (bool, error) {
+	return m[userID], nil
+}
+
+func newTestUseCase() (*usecase, *mockRepository, *[]uint) {
+	now := time.Now()
+	repo := &mockRepository{
+		disabled: map[uint]*time.Time{},
+		roles:    map[uint]string{1: user.SystemRoleAdmin, 2: user.SystemRoleUser},
+	}
+	revoked := []uint{}
+	uc := &usecase{
+		repo: repo,
+		userRepo: &mockUserRepository{users: map[uint]*user.User{
+			1: {ID: 1, SystemRole: user.SystemRoleAdmin},
+			2: {ID: 2, SystemRole: user.SystemRoleUser},
+			3: {ID: 3, SystemRole: user.SystemRoleAdmin},
+			4: {ID: 4, SystemRole: user.SystemRoleAdmin, DisabledAt: &now},
+		}},
+		mfa: mockMFA{1: true, 4: true},
+		revokeSessions: func(userID uint) error {
+			revoked = append(revoked, userID)
+			return nil
+		},
+	}
+	return uc, repo, &revoked
+}
+
+func TestAuthorizeAdmin(t *testing.T) {
+	uc, _, _ := newTestUseCase()
+
+	tests := []struct {
+		userID uint
+		want   error
+	}{
+		{1, nil},
+		{2, ErrNotAdmin},
+		{3, ErrMFARequired},
+		{4, ErrAccountDisabled},
+		{99, ErrNotAdmin},
+	}
+	for _, tt := range tests {
+		if err := uc.AuthorizeAdmin(tt.userID); !errors.Is(err, tt.want) {
+			t.Errorf("user %d: got %v, want %v", tt.userID, err, tt.want)
+		}
+	}
+}
+
+func TestDisable(t *testing.T) {
+	uc, repo, revoked := newTestUseCase()
+
+	if err := uc.Disable(1, 1); !errors.Is(err, ErrSelfLockout) {
+		t.Fatalf("self disable: got %v, want ErrSelfLockout", err)
+	}
+	if err := uc.Disable(1, 99); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("missing user: got %v, want ErrUserNotFound", err)
+	}
+
+	if err := uc.Disable(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if repo.disabled[2] == nil {
+		t.Error("user was not disabled")
+	}
+	if len(*revoked) != 1 || (*revoked)[0] != 2 {
+		t.Errorf("revoked = %v, want [2]", *revoked)
+	}
+
+	if err := uc.Enable(2); err != nil {
+		t.Fatal(err)
+	}
+	if repo.disabled[2] != nil {
+		t.Error("user was not enabled")
+	}
+}
+
+func TestUpdateRole(t *testing.T) {
+	uc, repo, _ := newTestUseCase()
+
+	if err := uc.UpdateRole(1, 1, user.SystemRoleUser); !errors.Is(err, ErrSelfLockout) {
+		t.Fatalf("self demotion: got %v, want ErrSelfLockout", err)
+	}
+	if err := uc.UpdateRole(1, 2, user.SystemRoleAdmin); err != nil {
+		t.Fatal(err)
+	}
+	if repo.roles[2] != user.SystemRoleAdmin {
+		t.Errorf("role = %q, want admin", repo.roles[2])
+	}
+}
+
+func TestListUsersPagination(t *testing.T) {
+	uc, _, _ := newTestUseCase()
+
+	list, err := uc.ListUsers(ListQuery{Limit: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Pagination.Page != 1 || list.Pagination.Limit != 20 || list.Pagination.TotalPages != 3 {
+		t.Errorf("pagination = %+v", list.Pagination)
+	}
+	if list.Users == nil {
+		t.Error("users should be an empty slice, not nil")
+	}
+}
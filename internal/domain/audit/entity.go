@@ -0,0 +1,199 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// Entity types
+const (
+	EntityUser                = "user"
+	EntityWorkspace           = "workspace"
+	EntityWorkspaceUser       = "workspace_user"
+	EntityWorkspaceInvitation = "workspace_invitation"
+	EntityInviteLink          = "invite_link"
+	EntityOwnershipTransfer   = "workspace_ownership_transfer"
+	EntityJoinRequest         = "workspace_join_request"
+	EntityBoard               = "board"
+	EntityBoardUser           = "board_user"
+	EntityBoardTemplate       = "board_template"
+	EntityBoardImportJob      = "board_import_job"
+	EntityTaskTab             = "task_tab"
+	EntityTaskCard            = "task_card"
+	EntityTaskCardUser        = "task_card_user"
+	EntityTaskCardComment     = "task_card_comment"
+	EntityLabel               = "label"
+)
+
+// Entry is one recorded change. WorkspaceID and BoardID are plain columns, not
+// foreign keys, so the history of a deleted board stays readable.
+type Entry struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	WorkspaceID   *uint     `json:"workspace_id,omitempty"`
+	BoardID       *uint     `json:"board_id,omitempty"`
+	ActorID       *uint     `json:"actor_id,omitempty"`
+	ActorUsername string    `json:"actor_username,omitempty" gorm:"->"`
+	Action        string    `json:"action"`
+	EntityType    string    `json:"entity_type"`
+	EntityID      uint      `json:"entity_id,omitempty"`
+	Changes       Changes   `json:"changes,omitempty" gorm:"type:jsonb"`
+	IP            string    `json:"ip,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (Entry) TableName() string {
+	return "audit_logs"
+}
+
+// New starts an entry; the caller adds scope, changes and the actor
+func New(action, entityType string, entityID uint) Entry {
+	return Entry{Action: action, EntityType: entityType, EntityID: entityID}
+}
+
+// WithWorkspace scopes the entry to a workspace
+func (e Entry) WithWorkspace(workspaceID uint) Entry {
+	e.WorkspaceID = &workspaceID
+	return e
+}
+
+// WithBoard scopes the entry to a board. The workspace is looked up when the
+// entry is stored unless WithWorkspace was also called.
+func (e Entry) WithBoard(boardID uint) Entry {
+	e.BoardID = &boardID
+	return e
+}
+
+// WithChanges records the difference between before and after, see Diff
+func (e Entry) WithChanges(before, after interface{}) Entry {
+	e.Changes = Diff(before, after)
+	return e
+}
+
+// Change holds the old and new value of one field
+type Change struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Changes maps a JSON field name to its change and is stored as JSONB
+type Changes map[string]Change
+
+func (c Changes) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *Changes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("audit: unsupported changes type")
+	}
+}
+
+// ignoredFields never appear in a diff: timestamps change on every write and
+// secrets must not be copied into the log
+var ignoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+	"password":   true,
+	"pass_code":  true,
+	"passcode":   true,
+}
+
+// Diff compares the JSON form of two values and returns the scalar fields that
+// differ. Pass nil as before for a create, or nil as after for a delete.
+// Nested objects and lists (preloaded relations) are skipped; they are audited
+// as entities of their own.
+func Diff(before, after interface{}) Changes {
+	old := fields(before)
+	cur := fields(after)
+
+	changes := Changes{}
+	for key, value := range old {
+		if next, ok := cur[key]; !ok || !reflect.DeepEqual(value, next) {
+			changes[key] = Change{Before: value, After: cur[key]}
+		}
+	}
+	for key, value := range cur {
+		if _, ok := old[key]; !ok {
+			changes[key] = Change{After: value}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+func fields(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil
+	}
+
+	out := make(map[string]interface{}, len(raw))
+	for key, value := range raw {
+		if ignoredFields[key] || value == nil {
+			continue
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// ListQuery filters GET /workspaces/:id/audit
+type ListQuery struct {
+	Action     string     `form:"action"`
+	EntityType string     `form:"entity_type"`
+	ActorID    uint       `form:"actor_id"`
+	BoardID    uint       `form:"board_id"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page       int        `form:"page"`
+	Limit      int        `form:"limit"`
+}
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalRows  int64 `json:"total_rows"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type EntryList struct {
+	Entries    []Entry    `json:"entries"`
+	Pagination Pagination `json:"pagination"`
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) List(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	var query ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	entries, err := h.usecase.List(uint(id), userID.(uint), query)
+	if err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to list audit log")
+		return
+	}
+
+	response.Success(c, entries)
+}
+
+// FromRequest starts an entry for a REST mutation by the authenticated caller
+func FromRequest(c *gin.Context, action, entityType string, entityID uint) Entry {
+	entry := New(action, entityType, entityID)
+	entry.IP = c.ClientIP()
+	if userID, ok := c.Get("user_id"); ok {
+		id := userID.(uint)
+		entry.ActorID = &id
+	}
+	return entry
+}
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(entry *Entry) error
+	FindByWorkspaceID(workspaceID uint, query ListQuery) ([]Entry, int64, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+// Create stores the entry, filling in the workspace of board-scoped entries
+func (r *repository) Create(entry *Entry) error {
+	if entry.WorkspaceID == nil && entry.BoardID != nil {
+		var workspaceIDs []uint
+		if err := database.DB.Table("boards").Where("id = ?", *entry.BoardID).Limit(1).Pluck("workspace_id", &workspaceIDs).Error; err != nil {
+			return err
+		}
+		if len(workspaceIDs) > 0 {
+			entry.WorkspaceID = &workspaceIDs[0]
+		}
+	}
+	return database.DB.Create(entry).Error
+}
+
+func (r *repository) FindByWorkspaceID(workspaceID uint, query ListQuery) ([]Entry, int64, error) {
+	db := database.DB.Model(&Entry{}).Where("audit_logs.workspace_id = ?", workspaceID)
+	if query.Action != "" {
+		db = db.Where("audit_logs.action = ?", query.Action)
+	}
+	if query.EntityType != "" {
+		db = db.Where("audit_logs.entity_type = ?", query.EntityType)
+	}
+	if query.ActorID != 0 {
+		db = db.Where("audit_logs.actor_id = ?", query.ActorID)
+	}
+	if query.BoardID != 0 {
+		db = db.Where("audit_logs.board_id = ?", query.BoardID)
+	}
+	if query.From != nil {
+		db = db.Where("audit_logs.created_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("audit_logs.created_at < ?", *query.To)
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []Entry
+	err := db.Select("audit_logs.*, users.username AS actor_username").
+		Joins("LEFT JOIN users ON users.id = audit_logs.actor_id").
+		Order("audit_logs.created_at DESC, audit_logs.id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&entries).Error
+	return entries, total, err
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"log"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+type UseCase interface {
+	Record(entry Entry)
+	List(workspaceID, userID uint, query ListQuery) (*EntryList, error)
+}
+
+type usecase struct {
+	repo  Repository
+	authz policy.Authorizer
+}
+
+func NewUseCase(repo Repository, authz policy.Authorizer) UseCase {
+	return &usecase{repo: repo, authz: authz}
+}
+
+// Record stores an entry. A failed write is logged rather than returned, so
+// auditing never undoes a change that already succeeded.
+func (u *usecase) Record(entry Entry) {
+	if err := u.repo.Create(&entry); err != nil {
+		log.Printf("[Audit] Failed to record %s on %s %d: %v", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+}
+
+// List returns a workspace's audit trail, newest first. Only owners may read it.
+func (u *usecase) List(workspaceID, userID uint, query ListQuery) (*EntryList, error) {
+	role, err := u.authz.WorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != policy.RoleOwner {
+		return nil, policy.ErrForbidden
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 || query.Limit > 100 {
+		query.Limit = 50
+	}
+
+	entries, total, err := u.repo.FindByWorkspaceID(workspaceID, query)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	return &EntryList{
+		Entries: entries,
+		Pagination: Pagination{
+			Page:       query.Page,
+			Limit:      query.Limit,
+			TotalRows:  total,
+			TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+		},
+	}, nil
+}
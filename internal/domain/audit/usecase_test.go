@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"errors"
+	"testing"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+type mockRepository struct {
+	created []Entry
+	query   ListQuery
+}
+
+func (m *mockRepository) Create(entry *Entry) error {
+	m.created = append(m.created, *entry)
+	return nil
+}
+
+func (m *mockRepository) FindByWorkspaceID(workspaceID uint, query ListQuery) ([]Entry, int64, error) {
+	m.query = query
+	return []Entry{{ID: 1, Action: "delete_board"}}, 1, nil
+}
+
+// mockPolicyRepository resolves workspace roles from an in-memory map keyed by user ID
+type mockPolicyRepository struct {
+	workspaceRoles map[uint]policy.Role
+}
+
+func (m *mockPolicyRepository) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	return m.workspaceRoles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	return "", nil
+}
+
+func (m *mockPolicyRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 0, nil
+}
+
+func (m *mockPolicyRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return false, nil
+}
+
+func TestList_OwnersOnly(t *testing.T) {
+	repo := &mockRepository{}
+	authz := policy.New(&mockPolicyRepository{workspaceRoles: map[uint]policy.Role{
+		1: policy.RoleOwner,
+		2: policy.RoleAdmin,
+	}})
+	uc := NewUseCase(repo, authz)
+
+	for _, userID := range []uint{2, 3} {
+		if _, err := uc.List(10, userID, ListQuery{}); !errors.Is(err, policy.ErrForbidden) {
+			t.Errorf("user %d: got %v, want ErrForbidden", userID, err)
+		}
+	}
+
+	list, err := uc.List(10, 1, ListQuery{Limit: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Entries) != 1 || list.Pagination.TotalPages != 1 {
+		t.Errorf("list = %+v", list)
+	}
+	if repo.query.Page != 1 || repo.query.Limit != 50 {
+		t.Errorf("query not normalized: %+v", repo.query)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type card struct {
+		ID        uint     `json:"id"`
+		Name      string   `json:"name"`
+		Status    bool     `json:"status"`
+		Password  string   `json:"password"`
+		UpdatedAt string   `json:"updated_at"`
+		Users     []string `json:"users"`
+	}
+
+	before := card{ID: 1, Name: "Draft", Password: "a", UpdatedAt: "t1", Users: []string{"a"}}
+	after := &card{ID: 1, Name: "Final", Status: true, Password: "b", UpdatedAt: "t2", Users: []string{"a", "b"}}
+
+	changes := Diff(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("changes = %v, want name and status only", changes)
+	}
+	if changes["name"].Before != "Draft" || changes["name"].After != "Final" {
+		t.Errorf("name change = %+v", changes["name"])
+	}
+	if changes["status"].Before != false || changes["status"].After != true {
+		t.Errorf("status change = %+v", changes["status"])
+	}
+
+	created := Diff(nil, after)
+	if created["name"].Before != nil || created["name"].After != "Final" {
+		t.Errorf("create diff = %v", created)
+	}
+
+	var missing *card
+	deleted := Diff(before, missing)
+	if deleted["name"].Before != "Draft" || deleted["name"].After != nil {
+		t.Errorf("delete diff = %v", deleted)
+	}
+
+	if Diff(before, before) != nil {
+		t.Error("identical values should produce no changes")
+	}
+}
+
+func TestChangesRoundTrip(t *testing.T) {
+	changes := Changes{"role": {Before: "member", After: "admin"}}
+	value, err := changes.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scanned Changes
+	if err := scanned.Scan([]byte(value.(string))); err != nil {
+		t.Fatal(err)
+	}
+	if scanned["role"].Before != "member" || scanned["role"].After != "admin" {
+		t.Errorf("scanned = %v", scanned)
+	}
+
+	if v, _ := Changes(nil).Value(); v != nil {
+		t.Errorf("empty changes should be stored as NULL, got %v", v)
+	}
+}
@@ -1,25 +1,51 @@
 package auth
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"hrm-app/config"
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/domain/mfa"
+	"hrm-app/internal/domain/sso"
 	"hrm-app/internal/domain/user"
 	"hrm-app/internal/pkg/utils"
+	"hrm-app/internal/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// LoginGuard throttles failed logins (implemented by loginguard.Guard)
+type LoginGuard interface {
+	Check(ctx context.Context, email, ip string) (time.Duration, error)
+	Fail(ctx context.Context, email, ip string) (time.Duration, error)
+	Succeed(ctx context.Context, email string) error
+	Unlock(ctx context.Context, email, ip string) error
+}
+
+// Auditor records security events (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
 type Handler struct {
 	userRepo user.Repository
+	mfaUC    mfa.UseCase
+	ssoUC    sso.UseCase
+	guard    LoginGuard
+	audit    Auditor
 	cfg      *config.Config
 }
 
-func NewHandler(repo user.Repository, cfg *config.Config) *Handler {
-	return &Handler{userRepo: repo, cfg: cfg}
+func NewHandler(repo user.Repository, mfaUC mfa.UseCase, ssoUC sso.UseCase, guard LoginGuard, auditor Auditor, cfg *config.Config) *Handler {
+	return &Handler{userRepo: repo, mfaUC: mfaUC, ssoUC: ssoUC, guard: guard, audit: auditor, cfg: cfg}
 }
 
 type loginRequest struct {
@@ -27,6 +53,16 @@ type loginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type unlockLoginRequest struct {
+	Email string `json:"email"`
+	IP    string `json:"ip"`
+}
+
+type loginMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
 func (h *Handler) Login(c *gin.Context) {
 	var req loginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -37,45 +73,322 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
+	if h.throttled(c, req.Email) {
+		return
+	}
+
 	user, err := h.userRepo.FindByEmail(req.Email)
-	if err != nil || user.ID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "Unauthorized",
-			"message": "Invalid email or password",
+	if err != nil || user == nil || user.ID == 0 || !utils.CheckPasswordHash(req.Password, user.Password) {
+		h.loginFailed(c, req.Email, "Invalid email or password")
+		return
+	}
+
+	h.completeLogin(c, user)
+}
+
+// throttled rejects the request with 429 while the email or IP is delayed or locked out.
+// Redis errors are logged and let the attempt through rather than locking everyone out.
+func (h *Handler) throttled(c *gin.Context, email string) bool {
+	wait, err := h.guard.Check(c.Request.Context(), email, c.ClientIP())
+	if err != nil {
+		log.Printf("login guard check failed: %v", err)
+		return false
+	}
+	if wait <= 0 {
+		return false
+	}
+
+	tooManyAttempts(c, wait)
+	return true
+}
+
+// loginFailed records the failure and answers 401, or 429 once the caller has to wait
+func (h *Handler) loginFailed(c *gin.Context, email, message string) {
+	h.record(c, "login_failed", 0, audit.Changes{"email": {After: email}})
+
+	wait, err := h.guard.Fail(c.Request.Context(), email, c.ClientIP())
+	if err != nil {
+		log.Printf("login guard failed to record attempt: %v", err)
+	}
+	if wait > 0 {
+		tooManyAttempts(c, wait)
+		return
+	}
+
+	c.JSON(http.StatusUnauthorized, gin.H{
+		"error":   "Unauthorized",
+		"message": message,
+	})
+}
+
+func (h *Handler) loginSucceeded(c *gin.Context, email string) {
+	if err := h.guard.Succeed(c.Request.Context(), email); err != nil {
+		log.Printf("login guard failed to reset attempts: %v", err)
+	}
+}
+
+// record stores an auth event about userID (0 when the account is unknown). The
+// actor is the authenticated caller, or the user themselves on login and logout.
+func (h *Handler) record(c *gin.Context, action string, userID uint, changes audit.Changes) {
+	entry := audit.FromRequest(c, action, audit.EntityUser, userID)
+	if entry.ActorID == nil && userID != 0 {
+		entry.ActorID = &userID
+	}
+	entry.Changes = changes
+	h.audit.Record(entry)
+}
+
+func tooManyAttempts(c *gin.Context, wait time.Duration) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":       "TooManyRequests",
+		"message":     response.Message(c, "Too many failed login attempts, please try again later"),
+		"retry_after": seconds,
+	})
+}
+
+// JWKS publishes the public signing keys so other services can verify tokens offline
+func (h *Handler) JWKS(c *gin.Context) {
+	keys, err := utils.SigningKeys(h.cfg)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "InternalServerError",
+			"message": response.Message(c, "Signing keys unavailable"),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, keys.JWKS())
+}
+
+// UnlockLogin clears failed attempts and lockouts for an email and/or IP (admin only)
+func (h *Handler) UnlockLogin(c *gin.Context) {
+	var req unlockLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "BadRequest",
+			"message": err.Error(),
+		})
+		return
+	}
+	if req.Email == "" && req.IP == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "BadRequest",
+			"message": response.Message(c, "email or ip is required"),
+		})
+		return
+	}
+
+	if err := h.guard.Unlock(c.Request.Context(), req.Email, req.IP); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "InternalServerError",
+			"message": response.Message(c, "Failed to unlock login"),
+		})
+		return
+	}
+
+	h.record(c, "unlock_login", 0, audit.Changes{"email": {After: req.Email}, "ip": {After: req.IP}})
+
+	c.JSON(http.StatusOK, gin.H{"message": response.Message(c, "Login unlocked")})
+}
+
+// completeLogin runs the checks shared by password and SSO logins, then starts the session
+func (h *Handler) completeLogin(c *gin.Context, user *user.User) {
+	if user.IsDisabled() {
+		accountDisabled(c)
+		return
+	}
+
+	if h.cfg.Auth.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "Forbidden",
+			"message": response.Message(c, "Email address has not been verified"),
+		})
+		return
+	}
+
+	mfaEnabled, err := h.mfaUC.IsEnabled(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "InternalServerError",
+			"message": response.Message(c, "Failed to check two-factor authentication"),
+		})
+		return
+	}
+
+	// Second step: the client exchanges the mfa_token and a code at /login/mfa
+	if mfaEnabled {
+		mfaToken, err := utils.GenerateMFAPendingToken(h.cfg, user.ID, user.Email)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "InternalServerError",
+				"message": response.Message(c, "Failed to generate tokens"),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":      response.Message(c, "Two-factor authentication required"),
+			"mfa_required": true,
+			"mfa_token":    mfaToken,
 		})
 		return
 	}
 
-	if !utils.CheckPasswordHash(req.Password, user.Password) {
+	h.startSession(c, user.ID, user.Email)
+}
+
+func (h *Handler) LoginMFA(c *gin.Context) {
+	var req loginMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "BadRequest",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	claims, err := utils.ValidateMFAPendingToken(h.cfg, req.MFAToken)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error":   "Unauthorized",
-			"message": "Invalid email or password",
+			"message": response.Message(c, "Invalid or expired MFA token"),
 		})
 		return
 	}
 
-	accessToken, refreshToken, err := utils.GenerateTokens(h.cfg, user.ID, user.Email)
+	// Codes are throttled like passwords, otherwise a stolen password lets 6 digits be brute-forced
+	if h.throttled(c, claims.Email) {
+		return
+	}
+
+	if err := h.mfaUC.Verify(claims.UserID, req.Code); err != nil {
+		h.loginFailed(c, claims.Email, "Invalid authentication code")
+		return
+	}
+
+	// The account may have been disabled between the password and the code step
+	user, err := h.userRepo.FindByID(claims.UserID)
+	if err != nil || user == nil || user.IsDisabled() {
+		accountDisabled(c)
+		return
+	}
+
+	h.startSession(c, claims.UserID, claims.Email)
+}
+
+func accountDisabled(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":   "Forbidden",
+		"message": response.Message(c, "Account is disabled"),
+	})
+}
+
+// oidcStateCookie ties a login state to the browser that started it, so a
+// callback link carrying someone else's state is refused (login CSRF)
+const oidcStateCookie = "oidc_state"
+
+// OIDCLogin redirects the browser to the identity provider
+func (h *Handler) OIDCLogin(c *gin.Context) {
+	authURL, state, err := h.ssoUC.Begin(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, sso.ErrDisabled) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": err.Error()})
+			return
+		}
+		log.Printf("[SSO] Failed to start login: %v", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "BadGateway", "message": response.Message(c, "Identity provider is unavailable")})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, int(sso.StateTTL.Seconds()), "/", "", false, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback finishes the provider login and starts a session like Login does
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "Identity provider returned " + providerErr})
+		return
+	}
+
+	state := c.Query("state")
+	cookieState, _ := c.Cookie(oidcStateCookie)
+	c.SetCookie(oidcStateCookie, "", -1, "/", "", false, true)
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": sso.ErrInvalidState.Error()})
+		return
+	}
+
+	user, err := h.ssoUC.Complete(c.Request.Context(), state, c.Query("code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, sso.ErrDisabled):
+			c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": err.Error()})
+		case errors.Is(err, sso.ErrInvalidState), errors.Is(err, sso.ErrEmailNotVerified):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": err.Error()})
+		case errors.Is(err, sso.ErrNoAccount), errors.Is(err, sso.ErrAccountUnverified):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden", "message": err.Error()})
+		default:
+			log.Printf("[SSO] Callback failed: %v", err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": response.Message(c, "Single sign-on failed")})
+		}
+		return
+	}
+
+	h.completeLogin(c, user)
+}
+
+// startSession issues the token pair, stores the session and sets the auth cookies
+func (h *Handler) startSession(c *gin.Context, userID uint, email string) {
+	family, err := utils.NewRefreshFamily()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "InternalServerError",
-			"message": "Failed to generate tokens",
+			"message": response.Message(c, "Failed to generate tokens"),
 		})
 		return
 	}
 
-	log.Printf("[Login Debug] UserID: %d, Time: %v (Unix: %d)", user.ID, time.Now(), time.Now().Unix())
-	log.Printf("[Login Debug] Generated Token: %s", accessToken)
+	accessToken, refreshToken, err := utils.GenerateTokens(h.cfg, userID, email, family)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "InternalServerError",
+			"message": response.Message(c, "Failed to generate tokens"),
+		})
+		return
+	}
 
 	// Store session in Redis (Access Token)
 	expMinutes := h.cfg.JWT.ExpiresInMinutes
 	if expMinutes == 0 {
 		expMinutes = 15
 	}
-	err = utils.SetSession(user.ID, accessToken, time.Duration(expMinutes)*time.Minute)
+	now := time.Now()
+	meta := utils.SessionMeta{
+		Family:     family.ID,
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	err = utils.SetSession(userID, accessToken, meta, time.Duration(expMinutes)*time.Minute)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "InternalServerError",
-			"message": "Failed to store session",
+			"message": response.Message(c, "Failed to store session"),
+		})
+		return
+	}
+
+	// Store the refresh token family so the refresh token can be rotated
+	if err := utils.SetRefreshFamily(userID, family, h.refreshTTL()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "InternalServerError",
+			"message": response.Message(c, "Failed to store session"),
 		})
 		return
 	}
@@ -84,29 +397,33 @@ func (h *Handler) Login(c *gin.Context) {
 	c.SetCookie("access_token", accessToken, h.cfg.JWT.ExpiresInMinutes*60, "/", "", false, true)
 	c.SetCookie("refresh_token", refreshToken, h.cfg.JWT.RefreshExpiresInDays*24*3600, "/", "", false, true)
 
+	// Attempts are only forgiven once a session exists, not after the password
+	// step alone, or MFA guesses could be reset by logging in again
+	h.loginSucceeded(c, email)
+	h.record(c, "login", userID, nil)
+
 	c.JSON(http.StatusOK, gin.H{
-		"message":      "Login successful",
+		"message":      response.Message(c, "Login successful"),
 		"access_token": accessToken, // Tetap return buat client yang nggak pake cookie
 	})
 }
 
 func (h *Handler) Logout(c *gin.Context) {
-	// Ambil token dari cookie atau header
-	token := ""
-	cookie, err := c.Cookie("access_token")
-	if err == nil {
-		token = cookie
-	} else {
-		authHeader := c.GetHeader("Authorization")
-		if strings.HasPrefix(authHeader, "Bearer ") {
-			token = strings.TrimPrefix(authHeader, "Bearer ")
-		}
-	}
+	token := h.accessTokenFromRequest(c)
 
 	if token != "" {
 		claims, err := utils.ValidateToken(h.cfg, token)
 		if err == nil {
 			_ = utils.DeleteSession(claims.UserID, token)
+			_ = utils.PublishSessionRevoked(claims.UserID, utils.SessionID(token))
+			h.record(c, "logout", claims.UserID, nil)
+		}
+	}
+
+	// Revoke the refresh token family as well, so the refresh cookie cannot outlive the logout
+	if refreshToken, err := c.Cookie("refresh_token"); err == nil {
+		if claims, err := utils.ValidateRefreshToken(h.cfg, refreshToken); err == nil && claims.Family != "" {
+			_ = utils.DeleteRefreshFamily(claims.UserID, claims.Family)
 		}
 	}
 
@@ -114,25 +431,53 @@ func (h *Handler) Logout(c *gin.Context) {
 	c.SetCookie("access_token", "", -1, "/", "", false, true)
 	c.SetCookie("refresh_token", "", -1, "/", "", false, true)
 
-	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": response.Message(c, "Logged out successfully")})
 }
 
 func (h *Handler) RefreshToken(c *gin.Context) {
 	refreshToken, err := c.Cookie("refresh_token")
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "Refresh token missing"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": response.Message(c, "Refresh token missing")})
 		return
 	}
 
 	claims, err := utils.ValidateRefreshToken(h.cfg, refreshToken)
+	if err != nil || claims.Family == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": response.Message(c, "Invalid refresh token")})
+		return
+	}
+
+	current := utils.FamilyOf(claims)
+	next, err := current.Next()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": response.Message(c, "Failed to generate tokens")})
+		return
+	}
+
+	accessToken, newRefreshToken, err := utils.GenerateTokens(h.cfg, claims.UserID, claims.Email, next)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "Invalid refresh token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": response.Message(c, "Failed to generate tokens")})
 		return
 	}
 
-	accessToken, newRefreshToken, err := utils.GenerateTokens(h.cfg, claims.UserID, claims.Email)
+	// Rotate: the presented refresh token is only valid if it is the latest of its family
+	result, err := utils.RotateRefreshFamily(claims.UserID, current, next, h.refreshTTL())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": "Failed to generate tokens"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": response.Message(c, "Failed to rotate refresh token")})
+		return
+	}
+
+	switch result {
+	case utils.RotateReused:
+		// An old refresh token came back: assume it was stolen and log the user out everywhere
+		log.Printf("[Refresh Token] Reuse detected for UserID=%d, family=%s. Revoking all sessions", claims.UserID, current.ID)
+		_ = utils.RevokeReusedFamily(claims.UserID, current.ID)
+		c.SetCookie("access_token", "", -1, "/", "", false, true)
+		c.SetCookie("refresh_token", "", -1, "/", "", false, true)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": response.Message(c, "Refresh token reuse detected, please log in again")})
+		return
+	case utils.RotateUnknown:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": response.Message(c, "Refresh token has been revoked")})
 		return
 	}
 
@@ -141,7 +486,23 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 	if expMinutes == 0 {
 		expMinutes = 15
 	}
-	_ = utils.SetSession(claims.UserID, accessToken, time.Duration(expMinutes)*time.Minute)
+	now := time.Now()
+	meta := utils.SessionMeta{
+		Family:     next.ID,
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+
+	// The new access token replaces the previous one of this device
+	if previous := h.accessTokenFromRequest(c); previous != "" {
+		if old, err := utils.GetSessionMeta(claims.UserID, previous); err == nil && old.Family == current.ID {
+			meta.CreatedAt = old.CreatedAt
+			_ = utils.DeleteSession(claims.UserID, previous)
+		}
+	}
+	_ = utils.SetSession(claims.UserID, accessToken, meta, time.Duration(expMinutes)*time.Minute)
 
 	// Update cookies
 	c.SetCookie("access_token", accessToken, h.cfg.JWT.ExpiresInMinutes*60, "/", "", false, true)
@@ -151,3 +512,25 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 		"access_token": accessToken,
 	})
 }
+
+// refreshTTL is how long a refresh token family stays valid without being used
+func (h *Handler) refreshTTL() time.Duration {
+	refreshExpDays := h.cfg.JWT.RefreshExpiresInDays
+	if refreshExpDays == 0 {
+		refreshExpDays = 7
+	}
+	return time.Duration(refreshExpDays) * 24 * time.Hour
+}
+
+// accessTokenFromRequest reads the access token from the cookie or Authorization header
+func (h *Handler) accessTokenFromRequest(c *gin.Context) string {
+	// Ambil token dari cookie atau header
+	if cookie, err := c.Cookie("access_token"); err == nil {
+		return cookie
+	}
+	authHeader := c.GetHeader("Authorization")
+	if strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	return ""
+}
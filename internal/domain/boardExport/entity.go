@@ -0,0 +1,131 @@
+package boardExport
+
+import (
+	"time"
+
+	"hrm-app/internal/domain/boards"
+)
+
+// Version of the JSON export format. Import rejects other versions.
+const Version = 1
+
+// Import formats
+const (
+	FormatTraspac = "traspac" // our own JSON export
+	FormatTrello  = "trello"  // Trello board JSON export
+	FormatJira    = "jira"    // Jira issue CSV export
+)
+
+// InlineCardLimit is the largest import done within the request; bigger ones
+// run as a background job in cmd/rabbitmq_worker
+const InlineCardLimit = 200
+
+// StaleJobAfter is how long a job may stay running before it is taken for one
+// whose worker died, and failed
+const StaleJobAfter = 30 * time.Minute
+
+// DefaultLabelColor is used for imported labels without a color
+const DefaultLabelColor = "blue"
+
+// Export is a board with everything needed to recreate it elsewhere. Users are
+// referred to by username and email since ids differ between workspaces.
+type Export struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Board      BoardRecord    `json:"board"`
+	Members    []MemberRecord `json:"members"`
+	Tabs       []TabRecord    `json:"tabs"`
+}
+
+type BoardRecord struct {
+	Name   string `json:"name"`
+	Images string `json:"images,omitempty"`
+}
+
+type UserRecord struct {
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+}
+
+type MemberRecord struct {
+	UserRecord
+	Role string `json:"role"`
+}
+
+// TabRecord holds a tab and its cards in board order
+type TabRecord struct {
+	Name  string       `json:"name"`
+	Cards []CardRecord `json:"cards"`
+}
+
+type CardRecord struct {
+	Name      string          `json:"name"`
+	Content   string          `json:"content,omitempty"`
+	Date      string          `json:"date,omitempty"` // YYYY-MM-DD
+	Status    bool            `json:"status"`
+	Labels    []LabelRecord   `json:"labels,omitempty"`
+	Assignees []UserRecord    `json:"assignees,omitempty"`
+	Comments  []CommentRecord `json:"comments,omitempty"`
+}
+
+type LabelRecord struct {
+	Title string `json:"title"`
+	Color string `json:"color"`
+}
+
+type CommentRecord struct {
+	Author    UserRecord `json:"author"`
+	Comment   string     `json:"comment"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ImportRequest recreates a board from an export file in a workspace
+type ImportRequest struct {
+	WorkspaceID uint
+	Format      string
+	Name        string // overrides the board name from the file
+	Data        []byte
+}
+
+// ImportResult holds the new board, or the job that will create it
+type ImportResult struct {
+	Board   *boards.Boards `json:"board,omitempty"`
+	Job     *Job           `json:"job,omitempty"`
+	Skipped []string       `json:"skipped,omitempty"` // users from the file who are not in the workspace
+}
+
+// Import job states
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// Job is a large import waiting for or run by the worker. The uploaded file is
+// kept in Source until the job has run.
+type Job struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	WorkspaceID uint       `json:"workspace_id"`
+	CreatedBy   uint       `json:"created_by"`
+	Format      string     `json:"format"`
+	Name        string     `json:"name,omitempty"`
+	Source      string     `json:"-"`
+	Status      string     `json:"status"`
+	BoardID     *uint      `json:"board_id,omitempty"`
+	Skipped     []string   `json:"skipped,omitempty" gorm:"serializer:json"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClaimedAt   *time.Time `json:"-"` // when a worker started running it
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+func (Job) TableName() string {
+	return "board_import_jobs"
+}
+
+// JobMessage is the RabbitMQ message that hands a job to the worker
+type JobMessage struct {
+	JobID uint `json:"job_id"`
+}
@@ -0,0 +1,181 @@
+package boardExport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxImportSize is the largest file accepted by Import
+const MaxImportSize = 20 << 20
+
+// Auditor records imports (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
+type Handler struct {
+	usecase UseCase
+	audit   Auditor
+}
+
+func NewHandler(usecase UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: usecase, audit: auditor}
+}
+
+// Export downloads a board as versioned JSON, or with ?format=csv its cards as CSV
+func (h *Handler) Export(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		response.Error(c, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+
+	export, err := h.usecase.Export(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to export board")
+		return
+	}
+
+	filename := fmt.Sprintf("traspac-board-%d-%s.%s", id, export.ExportedAt.Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if format == "json" {
+		c.JSON(http.StatusOK, export)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := h.usecase.WriteCSV(export, c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// Import creates a board in the workspace from an uploaded file, sent as the
+// multipart field "file" or as the raw request body. ?format= picks traspac
+// (the default), trello or jira and ?name= overrides the board name. Large
+// files are queued and answered with 202 and the job.
+func (h *Handler) Import(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	data, err := readUpload(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := ImportRequest{WorkspaceID: uint(id), Format: c.Query("format"), Name: c.Query("name"), Data: data}
+	result, err := h.usecase.Import(c.Request.Context(), userID.(uint), req)
+	if err != nil {
+		switch {
+		case policy.IsForbidden(err):
+			response.Error(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrUnknownFormat), errors.Is(err, ErrUnsupportedVersion),
+			errors.Is(err, ErrInvalidFile), errors.Is(err, ErrEmptyImport):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to import board")
+		}
+		return
+	}
+
+	if result.Job != nil {
+		job := result.Job
+		h.audit.Record(audit.FromRequest(c, "import_board", audit.EntityBoardImportJob, job.ID).WithWorkspace(job.WorkspaceID).WithChanges(nil, job))
+		c.JSON(http.StatusAccepted, gin.H{"data": result})
+		return
+	}
+
+	board := result.Board
+	h.audit.Record(audit.FromRequest(c, "import_board", audit.EntityBoard, board.ID).WithBoard(board.ID).WithWorkspace(board.WorkspaceID).
+		WithChanges(nil, map[string]interface{}{"name": board.Name, "format": req.Format}))
+	response.Success(c, result)
+}
+
+// GetJob shows the state of one of the caller's queued imports
+func (h *Handler) GetJob(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	job, err := h.usecase.FindJob(uint(id), userID.(uint))
+	if err != nil {
+		if errors.Is(err, ErrJobNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.Success(c, job)
+}
+
+// readUpload reads the import file from a multipart form or the raw body
+func readUpload(c *gin.Context) ([]byte, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportSize)
+
+	var reader io.Reader = c.Request.Body
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, errors.New("file is required")
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("file could not be read, the limit is %d MB", MaxImportSize>>20)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("file is required")
+	}
+	return data, nil
+}
@@ -0,0 +1,327 @@
+package boardExport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownFormat      = errors.New("format must be traspac, trello or jira")
+	ErrUnsupportedVersion = fmt.Errorf("unsupported export version, expected %d", Version)
+	ErrInvalidFile        = errors.New("file could not be read in the given format")
+)
+
+// Parse reads an export file of the given format into an Export
+func Parse(format string, data []byte) (*Export, error) {
+	var (
+		export *Export
+		err    error
+	)
+	switch format {
+	case FormatTraspac, "":
+		export, err = parseTraspac(data)
+	case FormatTrello:
+		export, err = parseTrello(data)
+	case FormatJira:
+		export, err = parseJira(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range export.Tabs {
+		for j := range export.Tabs[i].Cards {
+			card := &export.Tabs[i].Cards[j]
+			card.Date = normalizeDate(card.Date)
+			for k := range card.Labels {
+				if card.Labels[k].Color == "" {
+					card.Labels[k].Color = DefaultLabelColor
+				}
+			}
+		}
+	}
+	return export, nil
+}
+
+func parseTraspac(data []byte) (*Export, error) {
+	var export Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, ErrInvalidFile
+	}
+	if export.Version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+	return &export, nil
+}
+
+// trelloBoard is the part of Trello's board JSON export we import
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Lists []struct {
+		ID     string  `json:"id"`
+		Name   string  `json:"name"`
+		Closed bool    `json:"closed"`
+		Pos    float64 `json:"pos"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Desc        string   `json:"desc"`
+		IDList      string   `json:"idList"`
+		Closed      bool     `json:"closed"`
+		Pos         float64  `json:"pos"`
+		Due         string   `json:"due"`
+		DueComplete bool     `json:"dueComplete"`
+		IDLabels    []string `json:"idLabels"`
+		IDMembers   []string `json:"idMembers"`
+	} `json:"cards"`
+	Labels []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Members []struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Actions []struct {
+		Type string    `json:"type"`
+		Date time.Time `json:"date"`
+		Data struct {
+			Text string `json:"text"`
+			Card struct {
+				ID string `json:"id"`
+			} `json:"card"`
+		} `json:"data"`
+		MemberCreator struct {
+			Username string `json:"username"`
+		} `json:"memberCreator"`
+	} `json:"actions"`
+}
+
+// parseTrello maps open lists to tabs and open cards to cards, in Trello's
+// order. Archived lists and cards are left out.
+func parseTrello(data []byte) (*Export, error) {
+	var board trelloBoard
+	if err := json.Unmarshal(data, &board); err != nil || (board.Name == "" && len(board.Lists) == 0) {
+		return nil, ErrInvalidFile
+	}
+
+	export := &Export{Version: Version, Board: BoardRecord{Name: board.Name}}
+
+	members := make(map[string]UserRecord, len(board.Members))
+	for _, member := range board.Members {
+		user := UserRecord{Username: member.Username}
+		members[member.ID] = user
+		export.Members = append(export.Members, MemberRecord{UserRecord: user})
+	}
+	labels := make(map[string]LabelRecord, len(board.Labels))
+	for _, label := range board.Labels {
+		title := label.Name
+		if title == "" {
+			title = label.Color
+		}
+		labels[label.ID] = LabelRecord{Title: title, Color: label.Color}
+	}
+
+	// Trello lists actions newest first
+	comments := map[string][]CommentRecord{}
+	for i := len(board.Actions) - 1; i >= 0; i-- {
+		action := board.Actions[i]
+		if action.Type != "commentCard" {
+			continue
+		}
+		comments[action.Data.Card.ID] = append(comments[action.Data.Card.ID], CommentRecord{
+			Author:    UserRecord{Username: action.MemberCreator.Username},
+			Comment:   action.Data.Text,
+			CreatedAt: action.Date,
+		})
+	}
+
+	lists := board.Lists[:0]
+	for _, list := range board.Lists {
+		if !list.Closed {
+			lists = append(lists, list)
+		}
+	}
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+	tabs := make(map[string]int, len(lists))
+	for i, list := range lists {
+		tabs[list.ID] = i
+		export.Tabs = append(export.Tabs, TabRecord{Name: list.Name})
+	}
+
+	cards := board.Cards
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+	for _, card := range cards {
+		tab, ok := tabs[card.IDList]
+		if card.Closed || !ok {
+			continue
+		}
+		record := CardRecord{Name: card.Name, Content: card.Desc, Date: card.Due, Status: card.DueComplete, Comments: comments[card.ID]}
+		for _, id := range card.IDLabels {
+			if label, ok := labels[id]; ok {
+				record.Labels = append(record.Labels, label)
+			}
+		}
+		for _, id := range card.IDMembers {
+			if member, ok := members[id]; ok {
+				record.Assignees = append(record.Assignees, member)
+			}
+		}
+		export.Tabs[tab].Cards = append(export.Tabs[tab].Cards, record)
+	}
+
+	return export, nil
+}
+
+// jiraDone holds the statuses treated as a finished card when the export has
+// no Status Category column
+var jiraDone = map[string]bool{"done": true, "closed": true, "resolved": true}
+
+// parseJira maps each issue status to a tab, in the order statuses first
+// appear, and each issue to a card. Jira CSV repeats the Labels and Comment
+// columns once per value.
+func parseJira(data []byte) (*Export, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrInvalidFile
+	}
+	columns := map[string][]int{}
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[key] = append(columns[key], i)
+	}
+	if len(columns["summary"]) == 0 || len(columns["status"]) == 0 {
+		return nil, ErrInvalidFile
+	}
+
+	export := &Export{Version: Version}
+	tabs := map[string]int{}
+	assignees := map[string]bool{}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidFile
+		}
+		field := func(name string) string {
+			if idx := columns[name]; len(idx) > 0 && idx[0] < len(row) {
+				return strings.TrimSpace(row[idx[0]])
+			}
+			return ""
+		}
+		all := func(name string) []string {
+			var values []string
+			for _, idx := range columns[name] {
+				if idx < len(row) && strings.TrimSpace(row[idx]) != "" {
+					values = append(values, strings.TrimSpace(row[idx]))
+				}
+			}
+			return values
+		}
+
+		if export.Board.Name == "" {
+			export.Board.Name = field("project name")
+		}
+		summary := field("summary")
+		if summary == "" {
+			continue
+		}
+
+		status := field("status")
+		if status == "" {
+			status = "To Do"
+		}
+		tab, ok := tabs[status]
+		if !ok {
+			tab = len(export.Tabs)
+			tabs[status] = tab
+			export.Tabs = append(export.Tabs, TabRecord{Name: status})
+		}
+
+		done := jiraDone[strings.ToLower(status)]
+		if category := field("status category"); category != "" {
+			done = strings.EqualFold(category, "done")
+		}
+		card := CardRecord{Name: summary, Content: field("description"), Date: field("due date"), Status: done}
+		for _, label := range all("labels") {
+			card.Labels = append(card.Labels, LabelRecord{Title: label})
+		}
+		if assignee := field("assignee"); assignee != "" {
+			card.Assignees = append(card.Assignees, UserRecord{Username: assignee})
+			if !assignees[assignee] {
+				assignees[assignee] = true
+				export.Members = append(export.Members, MemberRecord{UserRecord: UserRecord{Username: assignee}})
+			}
+		}
+		for _, comment := range all("comment") {
+			card.Comments = append(card.Comments, parseJiraComment(comment))
+		}
+		export.Tabs[tab].Cards = append(export.Tabs[tab].Cards, card)
+	}
+
+	if export.Board.Name == "" {
+		export.Board.Name = "Jira import"
+	}
+	return export, nil
+}
+
+// parseJiraComment reads a Jira CSV comment cell, "date;author;text"
+func parseJiraComment(cell string) CommentRecord {
+	parts := strings.SplitN(cell, ";", 3)
+	if len(parts) < 3 {
+		return CommentRecord{Comment: cell}
+	}
+	comment := CommentRecord{Author: UserRecord{Username: parts[1]}, Comment: parts[2]}
+	if date, ok := parseDate(parts[0]); ok {
+		comment.CreatedAt = date
+	}
+	return comment
+}
+
+// dateLayouts covers our own dates, Trello's ISO timestamps and Jira's defaults
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"02/Jan/06 3:04 PM",
+	"2/Jan/06 3:04 PM",
+	"02/Jan/06",
+	"2/Jan/06",
+}
+
+func parseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// normalizeDate turns a card date into YYYY-MM-DD, today when it is missing
+// or unreadable since cards always have a date
+func normalizeDate(value string) string {
+	if date, ok := parseDate(value); ok {
+		return date.Format("2006-01-02")
+	}
+	return time.Now().Format("2006-01-02")
+}
@@ -0,0 +1,218 @@
+package boardExport
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"hrm-app/internal/domain/boards"
+	"hrm-app/internal/domain/boardsUsers"
+	"hrm-app/internal/domain/labels"
+	"hrm-app/internal/domain/taskCard"
+	"hrm-app/internal/domain/taskCardComment"
+	"hrm-app/internal/domain/taskCardUsers"
+	"hrm-app/internal/domain/taskTab"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/pkg/rank"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Member is a board member with their account, as loaded for an export
+type Member struct {
+	Role     policy.Role
+	Username string
+	Email    string
+}
+
+type Repository interface {
+	// FindBoard loads a board with its tabs and cards in order, and the
+	// cards' labels, assignees and comments
+	FindBoard(ctx context.Context, boardID uint) (*boards.Boards, []Member, error)
+	// FindWorkspaceUsers lists the members of a workspace, who imported
+	// users are matched against
+	FindWorkspaceUsers(workspaceID uint) ([]user.User, error)
+	Create(ctx context.Context, board *boards.Boards, plan *Plan) error
+
+	CreateJob(job *Job) error
+	FindJob(id uint) (*Job, error)
+	// ClaimJob moves a pending job to running and reports whether this call did
+	ClaimJob(id uint) (bool, error)
+	SaveJob(job *Job) error
+	// FailStaleJobs fails running jobs claimed before staleBefore and drops
+	// their source files
+	FailStaleJobs(staleBefore time.Time, reason string) (int64, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) FindBoard(ctx context.Context, boardID uint) (*boards.Boards, []Member, error) {
+	byRank := func(db *gorm.DB) *gorm.DB { return db.Order("rank, id") }
+	byID := func(db *gorm.DB) *gorm.DB { return db.Order("id") }
+	userFields := func(db *gorm.DB) *gorm.DB { return db.Select("id", "username", "email") }
+
+	var board boards.Boards
+	err := database.DB.WithContext(ctx).
+		Preload("TaskTabs", byRank).
+		Preload("TaskTabs.TaskCards", byRank).
+		Preload("TaskTabs.TaskCards.Labels", byID).
+		Preload("TaskTabs.TaskCards.Members", byID).
+		Preload("TaskTabs.TaskCards.Members.User", userFields).
+		Preload("TaskTabs.TaskCards.Comments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		Preload("TaskTabs.TaskCards.Comments.User", userFields).
+		Select("id", "workspace_id", "created_by", "name", "images", "created_at", "updated_at").
+		First(&board, boardID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var members []Member
+	err = database.DB.WithContext(ctx).
+		Table("boards_users").
+		Select("boards_users.role, users.username, users.email").
+		Joins("JOIN users ON users.id = boards_users.user_id").
+		Where("boards_users.board_id = ?", boardID).
+		Order("boards_users.id").
+		Scan(&members).Error
+	return &board, members, err
+}
+
+func (r *repository) FindWorkspaceUsers(workspaceID uint) ([]user.User, error) {
+	var users []user.User
+	err := database.DB.
+		Select("users.id, users.username, users.email").
+		Joins("JOIN workspaces_users ON workspaces_users.user_id = users.id").
+		Where("workspaces_users.workspace_id = ?", workspaceID).
+		Find(&users).Error
+	return users, err
+}
+
+// Create writes the board and everything in plan in one transaction, so a
+// failed import leaves nothing behind
+func (r *repository) Create(ctx context.Context, board *boards.Boards, plan *Plan) error {
+	return database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(board).Error; err != nil {
+			return err
+		}
+
+		members := make([]boardsUsers.BoardsUsers, 0, len(plan.Members)+1)
+		members = append(members, boardsUsers.BoardsUsers{BoardID: board.ID, UserID: board.CreatedBy, Role: policy.RoleOwner})
+		for _, member := range plan.Members {
+			members = append(members, boardsUsers.BoardsUsers{BoardID: board.ID, UserID: member.UserID, Role: member.Role})
+		}
+		if err := tx.Omit(clause.Associations).Create(&members).Error; err != nil {
+			return err
+		}
+
+		tabRanks := rank.Sequence(len(plan.Tabs))
+		for i, planned := range plan.Tabs {
+			tab := taskTab.TaskTab{BoardID: board.ID, Name: planned.Name, Position: i + 1, Rank: tabRanks[i]}
+			if err := tx.Omit(clause.Associations).Create(&tab).Error; err != nil {
+				return err
+			}
+
+			cardRanks := rank.Sequence(len(planned.Cards))
+			for j, plannedCard := range planned.Cards {
+				if err := createCard(tx, tab.ID, cardRanks[j], plannedCard); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func createCard(tx *gorm.DB, tabID uint, key string, planned PlannedCard) error {
+	card := taskCard.TaskCard{
+		TaskTabID: tabID,
+		Name:      planned.Name,
+		Content:   planned.Content,
+		Date:      planned.Date,
+		Status:    planned.Status,
+		Rank:      key,
+	}
+	if err := tx.Omit(clause.Associations).Create(&card).Error; err != nil {
+		return err
+	}
+
+	if len(planned.Labels) > 0 {
+		cardLabels := make([]labels.TaskCardLabel, 0, len(planned.Labels))
+		for _, label := range planned.Labels {
+			cardLabels = append(cardLabels, labels.TaskCardLabel{TaskCardID: card.ID, Title: label.Title, Color: label.Color})
+		}
+		if err := tx.Create(&cardLabels).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(planned.Assignees) > 0 {
+		assignees := make([]taskCardUsers.TaskCardUsers, 0, len(planned.Assignees))
+		for _, userID := range planned.Assignees {
+			assignees = append(assignees, taskCardUsers.TaskCardUsers{TaskCardID: card.ID, UserID: userID})
+		}
+		if err := tx.Omit(clause.Associations).Create(&assignees).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(planned.Comments) > 0 {
+		comments := make([]taskCardComment.TaskCardComment, 0, len(planned.Comments))
+		for _, comment := range planned.Comments {
+			createdAt := comment.CreatedAt
+			if createdAt.IsZero() {
+				createdAt = time.Now()
+			}
+			comments = append(comments, taskCardComment.TaskCardComment{
+				TaskCardID: int(card.ID),
+				UserID:     comment.UserID,
+				Comment:    comment.Comment,
+				CreatedAt:  createdAt,
+			})
+		}
+		if err := tx.Omit(clause.Associations).Create(&comments).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) CreateJob(job *Job) error {
+	return database.DB.Create(job).Error
+}
+
+func (r *repository) FindJob(id uint) (*Job, error) {
+	var job Job
+	err := database.DB.First(&job, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &job, err
+}
+
+func (r *repository) ClaimJob(id uint) (bool, error) {
+	result := database.DB.Model(&Job{}).
+		Where("id = ? AND status = ?", id, JobPending).
+		Updates(map[string]interface{}{"status": JobRunning, "claimed_at": time.Now()})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *repository) SaveJob(job *Job) error {
+	return database.DB.Save(job).Error
+}
+
+func (r *repository) FailStaleJobs(staleBefore time.Time, reason string) (int64, error) {
+	result := database.DB.Model(&Job{}).
+		Where("status = ? AND claimed_at < ?", JobRunning, staleBefore).
+		Updates(map[string]interface{}{"status": JobFailed, "error": reason, "source": "", "finished_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
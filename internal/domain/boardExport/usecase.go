@@ -0,0 +1,407 @@
+package boardExport
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"hrm-app/internal/domain/boards"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/policy"
+)
+
+var (
+	ErrBoardNotFound = errors.New("board not found")
+	ErrJobNotFound   = errors.New("import job not found")
+	ErrEmptyImport   = errors.New("file has no tabs to import")
+	ErrJobStale      = errors.New("import was interrupted, upload the file again")
+)
+
+// Queue hands import jobs to the worker (implemented by producer.JobQueue)
+type Queue interface {
+	Enqueue(body []byte) error
+}
+
+type UseCase interface {
+	Export(ctx context.Context, boardID uint) (*Export, error)
+	WriteCSV(export *Export, w io.Writer) error
+	Import(ctx context.Context, userID uint, req ImportRequest) (*ImportResult, error)
+	// RunJob performs a queued import; it is called by cmd/rabbitmq_worker
+	RunJob(ctx context.Context, id uint) error
+	// FailStaleJobs fails jobs whose worker stopped before finishing them
+	FailStaleJobs() (int64, error)
+	FindJob(id, userID uint) (*Job, error)
+}
+
+type usecase struct {
+	repo  Repository
+	authz policy.Authorizer
+	queue Queue
+}
+
+// NewUseCase creates the export/import usecase. With a nil queue every import
+// runs inline.
+func NewUseCase(repo Repository, authz policy.Authorizer, queue Queue) UseCase {
+	return &usecase{repo: repo, authz: authz, queue: queue}
+}
+
+func (u *usecase) Export(ctx context.Context, boardID uint) (*Export, error) {
+	board, members, err := u.repo.FindBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil {
+		return nil, ErrBoardNotFound
+	}
+	return newExport(board, members, time.Now()), nil
+}
+
+// newExport copies a loaded board into the export format
+func newExport(board *boards.Boards, members []Member, now time.Time) *Export {
+	export := &Export{
+		Version:    Version,
+		ExportedAt: now,
+		Board:      BoardRecord{Name: board.Name, Images: board.Images},
+		Members:    make([]MemberRecord, 0, len(members)),
+		Tabs:       make([]TabRecord, 0, len(board.TaskTabs)),
+	}
+	for _, member := range members {
+		export.Members = append(export.Members, MemberRecord{
+			UserRecord: UserRecord{Username: member.Username, Email: member.Email},
+			Role:       string(member.Role),
+		})
+	}
+
+	for _, tab := range board.TaskTabs {
+		record := TabRecord{Name: tab.Name, Cards: make([]CardRecord, 0, len(tab.TaskCards))}
+		for _, card := range tab.TaskCards {
+			cardRecord := CardRecord{Name: card.Name, Content: card.Content, Date: card.Date, Status: card.Status}
+			for _, label := range card.Labels {
+				cardRecord.Labels = append(cardRecord.Labels, LabelRecord{Title: label.Title, Color: label.Color})
+			}
+			for _, assignee := range card.Members {
+				cardRecord.Assignees = append(cardRecord.Assignees, UserRecord{Username: assignee.User.Username, Email: assignee.User.Email})
+			}
+			for _, comment := range card.Comments {
+				cardRecord.Comments = append(cardRecord.Comments, CommentRecord{
+					Author:    UserRecord{Username: comment.User.Username, Email: comment.User.Email},
+					Comment:   comment.Comment,
+					CreatedAt: comment.CreatedAt,
+				})
+			}
+			record.Cards = append(record.Cards, cardRecord)
+		}
+		export.Tabs = append(export.Tabs, record)
+	}
+	return export
+}
+
+// csvHeader is the first row of a CSV export, one card per following row
+var csvHeader = []string{"Tab", "Card", "Description", "Date", "Status", "Labels", "Assignees", "Comments"}
+
+// WriteCSV writes the cards of an export as CSV. Labels and assignees are
+// joined with ", "; comments are only counted.
+func (u *usecase) WriteCSV(export *Export, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, tab := range export.Tabs {
+		for _, card := range tab.Cards {
+			status := "open"
+			if card.Status {
+				status = "done"
+			}
+			titles := make([]string, 0, len(card.Labels))
+			for _, label := range card.Labels {
+				titles = append(titles, label.Title)
+			}
+			usernames := make([]string, 0, len(card.Assignees))
+			for _, assignee := range card.Assignees {
+				usernames = append(usernames, assignee.Username)
+			}
+			row := []string{
+				tab.Name,
+				card.Name,
+				card.Content,
+				card.Date,
+				status,
+				strings.Join(titles, ", "),
+				strings.Join(usernames, ", "),
+				fmt.Sprint(len(card.Comments)),
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Import recreates a board from a file in req.WorkspaceID. Files with more than
+// InlineCardLimit cards are queued and the result holds the job instead.
+func (u *usecase) Import(ctx context.Context, userID uint, req ImportRequest) (*ImportResult, error) {
+	if err := u.authz.AuthorizeWorkspace(req.WorkspaceID, userID, policy.ActionEdit); err != nil {
+		return nil, err
+	}
+
+	export, err := Parse(req.Format, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(export.Tabs) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	if u.queue != nil && countCards(export) > InlineCardLimit {
+		job, err := u.enqueue(userID, req)
+		if err != nil {
+			return nil, err
+		}
+		return &ImportResult{Job: job}, nil
+	}
+
+	board, skipped, err := u.create(ctx, userID, req.WorkspaceID, req.Name, export)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportResult{Board: board, Skipped: skipped}, nil
+}
+
+// enqueue stores the file as a pending job and hands it to the worker
+func (u *usecase) enqueue(userID uint, req ImportRequest) (*Job, error) {
+	format := req.Format
+	if format == "" {
+		format = FormatTraspac
+	}
+	job := &Job{
+		WorkspaceID: req.WorkspaceID,
+		CreatedBy:   userID,
+		Format:      format,
+		Name:        req.Name,
+		Source:      string(req.Data),
+		Status:      JobPending,
+	}
+	if err := u.repo.CreateJob(job); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(JobMessage{JobID: job.ID})
+	if err != nil {
+		return nil, err
+	}
+	if err := u.queue.Enqueue(body); err != nil {
+		u.finish(job, nil, nil, fmt.Errorf("failed to queue import: %w", err))
+		return nil, err
+	}
+	return job, nil
+}
+
+func (u *usecase) RunJob(ctx context.Context, id uint) error {
+	claimed, err := u.repo.ClaimJob(id)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil // already run, or being run by another worker
+	}
+
+	job, err := u.repo.FindJob(id)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return ErrJobNotFound
+	}
+
+	// The importer may have lost access while the job waited in the queue
+	if err := u.authz.AuthorizeWorkspace(job.WorkspaceID, job.CreatedBy, policy.ActionEdit); err != nil {
+		return u.finish(job, nil, nil, err)
+	}
+
+	export, err := Parse(job.Format, []byte(job.Source))
+	if err != nil {
+		return u.finish(job, nil, nil, err)
+	}
+	board, skipped, err := u.create(ctx, job.CreatedBy, job.WorkspaceID, job.Name, export)
+	return u.finish(job, board, skipped, err)
+}
+
+// finish records the outcome of a job and drops its source file
+func (u *usecase) finish(job *Job, board *boards.Boards, skipped []string, runErr error) error {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Source = ""
+	if runErr != nil {
+		job.Status, job.Error = JobFailed, runErr.Error()
+	} else {
+		job.Status, job.BoardID, job.Skipped = JobDone, &board.ID, skipped
+	}
+	if err := u.repo.SaveJob(job); err != nil {
+		return err
+	}
+	return runErr
+}
+
+func (u *usecase) FailStaleJobs() (int64, error) {
+	return u.repo.FailStaleJobs(time.Now().Add(-StaleJobAfter), ErrJobStale.Error())
+}
+
+func (u *usecase) FindJob(id, userID uint) (*Job, error) {
+	job, err := u.repo.FindJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.CreatedBy != userID {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// create matches the export's users against the workspace and writes the board
+func (u *usecase) create(ctx context.Context, userID, workspaceID uint, name string, export *Export) (*boards.Boards, []string, error) {
+	users, err := u.repo.FindWorkspaceUsers(workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if name == "" {
+		name = export.Board.Name
+	}
+	if name == "" {
+		name = "Imported board"
+	}
+	board := &boards.Boards{WorkspaceID: workspaceID, CreatedBy: userID, Name: name, Images: export.Board.Images}
+
+	plan, skipped := newPlan(export, users, userID)
+	if err := u.repo.Create(ctx, board, plan); err != nil {
+		return nil, nil, err
+	}
+	return board, skipped, nil
+}
+
+func countCards(export *Export) int {
+	count := 0
+	for _, tab := range export.Tabs {
+		count += len(tab.Cards)
+	}
+	return count
+}
+
+// Plan is an export with its users resolved to workspace members, ready to be
+// written by Repository.Create
+type Plan struct {
+	Members []PlannedMember
+	Tabs    []PlannedTab
+}
+
+type PlannedMember struct {
+	UserID uint
+	Role   policy.Role
+}
+
+type PlannedTab struct {
+	Name  string
+	Cards []PlannedCard
+}
+
+type PlannedCard struct {
+	Name      string
+	Content   string
+	Date      string
+	Status    bool
+	Labels    []LabelRecord
+	Assignees []uint
+	Comments  []PlannedComment
+}
+
+type PlannedComment struct {
+	UserID    uint
+	Comment   string
+	CreatedAt time.Time
+}
+
+// newPlan resolves the users of an export by email, then username, among the
+// workspace users. Unknown members and assignees are dropped and reported in
+// skipped; comments by unknown authors are kept under the importer with the
+// author's name in front.
+func newPlan(export *Export, users []user.User, importerID uint) (*Plan, []string) {
+	byEmail := make(map[string]uint, len(users))
+	byUsername := make(map[string]uint, len(users))
+	for _, u := range users {
+		if u.Email != "" {
+			byEmail[strings.ToLower(u.Email)] = u.ID
+		}
+		if u.Username != "" {
+			byUsername[strings.ToLower(u.Username)] = u.ID
+		}
+	}
+
+	var skipped []string
+	seen := map[string]bool{}
+	resolve := func(record UserRecord) (uint, bool) {
+		if id, ok := byEmail[strings.ToLower(record.Email)]; ok && record.Email != "" {
+			return id, true
+		}
+		if id, ok := byUsername[strings.ToLower(record.Username)]; ok && record.Username != "" {
+			return id, true
+		}
+		name := record.Username
+		if name == "" {
+			name = record.Email
+		}
+		if name != "" && !seen[name] {
+			seen[name] = true
+			skipped = append(skipped, name)
+		}
+		return 0, false
+	}
+
+	plan := &Plan{}
+	members := map[uint]bool{importerID: true} // the importer owns the new board
+	for _, member := range export.Members {
+		id, ok := resolve(member.UserRecord)
+		if !ok || members[id] {
+			continue
+		}
+		members[id] = true
+		role := policy.Role(member.Role)
+		if !role.ValidForBoard() || role == policy.RoleOwner {
+			role = policy.RoleEditor
+		}
+		plan.Members = append(plan.Members, PlannedMember{UserID: id, Role: role})
+	}
+
+	for _, tab := range export.Tabs {
+		planned := PlannedTab{Name: tab.Name, Cards: make([]PlannedCard, 0, len(tab.Cards))}
+		for _, card := range tab.Cards {
+			plannedCard := PlannedCard{Name: card.Name, Content: card.Content, Date: card.Date, Status: card.Status, Labels: card.Labels}
+
+			assigned := map[uint]bool{}
+			for _, assignee := range card.Assignees {
+				if id, ok := resolve(assignee); ok && !assigned[id] {
+					assigned[id] = true
+					plannedCard.Assignees = append(plannedCard.Assignees, id)
+				}
+			}
+			for _, comment := range card.Comments {
+				plannedComment := PlannedComment{UserID: importerID, Comment: comment.Comment, CreatedAt: comment.CreatedAt}
+				if id, ok := resolve(comment.Author); ok {
+					plannedComment.UserID = id
+				} else if comment.Author.Username != "" {
+					plannedComment.Comment = comment.Author.Username + ": " + comment.Comment
+				}
+				plannedCard.Comments = append(plannedCard.Comments, plannedComment)
+			}
+			planned.Cards = append(planned.Cards, plannedCard)
+		}
+		plan.Tabs = append(plan.Tabs, planned)
+	}
+	return plan, skipped
+}
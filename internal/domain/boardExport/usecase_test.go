@@ -0,0 +1,499 @@
+package boardExport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"hrm-app/internal/domain/boards"
+	"hrm-app/internal/domain/labels"
+	"hrm-app/internal/domain/taskCard"
+	"hrm-app/internal/domain/taskCardComment"
+	"hrm-app/internal/domain/taskCardUsers"
+	"hrm-app/internal/domain/taskTab"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/policy"
+)
+
+type mockRepository struct {
+	board   *boards.Boards
+	members []Member
+	users   []user.User
+
+	created *boards.Boards
+	plan    *Plan
+	jobs    map[uint]*Job
+}
+
+func (m *mockRepository) FindBoard(ctx context.Context, boardID uint) (*boards.Boards, []Member, error) {
+	if m.board == nil || m.board.ID != boardID {
+		return nil, nil, nil
+	}
+	return m.board, m.members, nil
+}
+
+func (m *mockRepository) FindWorkspaceUsers(workspaceID uint) ([]user.User, error) {
+	return m.users, nil
+}
+
+func (m *mockRepository) Create(ctx context.Context, board *boards.Boards, plan *Plan) error {
+	board.ID = 99
+	m.created, m.plan = board, plan
+	return nil
+}
+
+func (m *mockRepository) CreateJob(job *Job) error {
+	if m.jobs == nil {
+		m.jobs = map[uint]*Job{}
+	}
+	job.ID = uint(len(m.jobs) + 1)
+	copied := *job
+	m.jobs[job.ID] = &copied
+	return nil
+}
+
+func (m *mockRepository) FindJob(id uint) (*Job, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (m *mockRepository) ClaimJob(id uint) (bool, error) {
+	job, ok := m.jobs[id]
+	if !ok || job.Status != JobPending {
+		return false, nil
+	}
+	now := time.Now()
+	job.Status, job.ClaimedAt = JobRunning, &now
+	return true, nil
+}
+
+func (m *mockRepository) SaveJob(job *Job) error {
+	copied := *job
+	m.jobs[job.ID] = &copied
+	return nil
+}
+
+func (m *mockRepository) FailStaleJobs(staleBefore time.Time, reason string) (int64, error) {
+	var failed int64
+	for _, job := range m.jobs {
+		if job.Status == JobRunning && job.ClaimedAt.Before(staleBefore) {
+			job.Status, job.Error, job.Source = JobFailed, reason, ""
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+type mockQueue struct {
+	bodies [][]byte
+	err    error
+}
+
+func (m *mockQueue) Enqueue(body []byte) error {
+	m.bodies = append(m.bodies, body)
+	return m.err
+}
+
+type mockPolicyRepository struct {
+	workspaceRoles map[uint]policy.Role
+}
+
+func (m *mockPolicyRepository) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	return m.workspaceRoles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	return "", nil
+}
+
+func (m *mockPolicyRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 0, nil
+}
+
+func (m *mockPolicyRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return false, nil
+}
+
+// users 1 (the importer) and 2 are workspace members; 3 only views
+func newTestUseCase(repo *mockRepository, queue Queue) UseCase {
+	authz := policy.New(&mockPolicyRepository{workspaceRoles: map[uint]policy.Role{1: policy.RoleEditor, 2: policy.RoleEditor, 3: policy.RoleViewer}})
+	return NewUseCase(repo, authz, queue)
+}
+
+func testBoard() *boards.Boards {
+	alice := user.User{ID: 1, Username: "alice", Email: "alice@example.com"}
+	bob := user.User{ID: 2, Username: "bob", Email: "bob@example.com"}
+	commentedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	return &boards.Boards{
+		ID:   10,
+		Name: "Launch",
+		TaskTabs: []taskTab.TaskTab{
+			{Name: "Todo", TaskCards: []taskCard.TaskCard{
+				{
+					Name: "Write copy", Content: "Landing page", Date: "2026-03-02",
+					Labels:   []labels.TaskCardLabel{{Title: "marketing", Color: "red"}},
+					Members:  []taskCardUsers.TaskCardUsers{{UserID: 2, User: bob}},
+					Comments: []taskCardComment.TaskCardComment{{UserID: 1, User: alice, Comment: "Draft is up", CreatedAt: commentedAt}},
+				},
+			}},
+			{Name: "Done", TaskCards: []taskCard.TaskCard{
+				{Name: "Pick domain", Date: "2026-02-20", Status: true},
+			}},
+		},
+	}
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	repo := &mockRepository{
+		board:   testBoard(),
+		members: []Member{{Role: policy.RoleOwner, Username: "alice", Email: "alice@example.com"}, {Role: policy.RoleCommenter, Username: "bob", Email: "bob@example.com"}},
+		users:   []user.User{{ID: 1, Username: "alice", Email: "alice@example.com"}, {ID: 2, Username: "bob", Email: "bob@example.com"}},
+	}
+	uc := newTestUseCase(repo, nil)
+
+	export, err := uc.Export(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if export.Version != Version || len(export.Tabs) != 2 || export.Tabs[0].Cards[0].Assignees[0].Username != "bob" {
+		t.Fatalf("unexpected export %+v", export)
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := uc.Import(context.Background(), 1, ImportRequest{WorkspaceID: 4, Data: data})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Board.Name != "Launch" || result.Board.WorkspaceID != 4 || result.Board.CreatedBy != 1 || len(result.Skipped) != 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	plan := repo.plan
+	// alice imports and becomes owner, so only bob is planned, keeping the commenter role
+	if len(plan.Members) != 1 || plan.Members[0] != (PlannedMember{UserID: 2, Role: policy.RoleCommenter}) {
+		t.Errorf("members = %+v", plan.Members)
+	}
+	card := plan.Tabs[0].Cards[0]
+	if card.Name != "Write copy" || card.Date != "2026-03-02" || len(card.Assignees) != 1 || card.Assignees[0] != 2 {
+		t.Errorf("card = %+v", card)
+	}
+	if len(card.Comments) != 1 || card.Comments[0].UserID != 1 || card.Comments[0].Comment != "Draft is up" {
+		t.Errorf("comments = %+v", card.Comments)
+	}
+	if !plan.Tabs[1].Cards[0].Status {
+		t.Error("expected the done card to stay done")
+	}
+}
+
+func TestExport_NotFound(t *testing.T) {
+	uc := newTestUseCase(&mockRepository{}, nil)
+	if _, err := uc.Export(context.Background(), 10); !errors.Is(err, ErrBoardNotFound) {
+		t.Errorf("expected ErrBoardNotFound, got %v", err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	export := newExport(testBoard(), nil, time.Now())
+
+	var buf bytes.Buffer
+	if err := newTestUseCase(&mockRepository{}, nil).WriteCSV(export, &buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := "Tab,Card,Description,Date,Status,Labels,Assignees,Comments\n" +
+		"Todo,Write copy,Landing page,2026-03-02,open,marketing,bob,1\n" +
+		"Done,Pick domain,,2026-02-20,done,,,0\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestParse_Traspac(t *testing.T) {
+	if _, err := Parse(FormatTraspac, []byte(`{"version": 2, "board": {"name": "x"}}`)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+	if _, err := Parse(FormatTraspac, []byte(`not json`)); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("expected ErrInvalidFile, got %v", err)
+	}
+	if _, err := Parse("asana", nil); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+
+	export, err := Parse(FormatTraspac, []byte(`{"version": 1, "board": {"name": "x"}, "tabs": [{"name": "Todo", "cards": [{"name": "a", "labels": [{"title": "bug"}]}]}]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	card := export.Tabs[0].Cards[0]
+	if card.Date != time.Now().Format("2006-01-02") || card.Labels[0].Color != DefaultLabelColor {
+		t.Errorf("expected defaults to be filled in, got %+v", card)
+	}
+}
+
+const trelloFile = `{
+  "name": "Roadmap",
+  "lists": [
+    {"id": "l2", "name": "Doing", "pos": 2},
+    {"id": "l1", "name": "Backlog", "pos": 1},
+    {"id": "l3", "name": "Old", "pos": 3, "closed": true}
+  ],
+  "cards": [
+    {"id": "c2", "name": "Second", "idList": "l1", "pos": 20},
+    {"id": "c1", "name": "First", "desc": "Notes", "idList": "l1", "pos": 10, "due": "2026-05-01T12:00:00.000Z", "dueComplete": true, "idLabels": ["g"], "idMembers": ["m1"]},
+    {"id": "c3", "name": "Archived", "idList": "l2", "pos": 1, "closed": true},
+    {"id": "c4", "name": "In old list", "idList": "l3", "pos": 1}
+  ],
+  "labels": [{"id": "g", "name": "", "color": "green"}],
+  "members": [{"id": "m1", "username": "carol"}],
+  "actions": [
+    {"type": "commentCard", "date": "2026-04-02T10:00:00.000Z", "data": {"text": "later", "card": {"id": "c1"}}, "memberCreator": {"username": "carol"}},
+    {"type": "updateCard", "date": "2026-04-01T11:00:00.000Z", "data": {"card": {"id": "c1"}}},
+    {"type": "commentCard", "date": "2026-04-01T10:00:00.000Z", "data": {"text": "earlier", "card": {"id": "c1"}}, "memberCreator": {"username": "dave"}}
+  ]
+}`
+
+func TestParse_Trello(t *testing.T) {
+	export, err := Parse(FormatTrello, []byte(trelloFile))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if export.Board.Name != "Roadmap" || len(export.Tabs) != 2 || export.Tabs[0].Name != "Backlog" || export.Tabs[1].Name != "Doing" {
+		t.Fatalf("unexpected tabs %+v", export.Tabs)
+	}
+	if len(export.Tabs[1].Cards) != 0 {
+		t.Errorf("expected archived cards to be left out, got %+v", export.Tabs[1].Cards)
+	}
+
+	cards := export.Tabs[0].Cards
+	if len(cards) != 2 || cards[0].Name != "First" || cards[1].Name != "Second" {
+		t.Fatalf("unexpected cards %+v", cards)
+	}
+	first := cards[0]
+	if first.Date != "2026-05-01" || !first.Status || first.Content != "Notes" {
+		t.Errorf("unexpected card fields %+v", first)
+	}
+	if len(first.Labels) != 1 || first.Labels[0] != (LabelRecord{Title: "green", Color: "green"}) {
+		t.Errorf("labels = %+v", first.Labels)
+	}
+	if len(first.Assignees) != 1 || first.Assignees[0].Username != "carol" {
+		t.Errorf("assignees = %+v", first.Assignees)
+	}
+	if len(first.Comments) != 2 || first.Comments[0].Comment != "earlier" || first.Comments[1].Comment != "later" {
+		t.Errorf("expected comments oldest first, got %+v", first.Comments)
+	}
+}
+
+func TestParse_Jira(t *testing.T) {
+	data := "\ufeffSummary,Issue key,Status,Status Category,Project name,Description,Due Date,Labels,Labels,Assignee,Comment,Comment\n" +
+		"Fix login,APP-1,In Progress,In Progress,App,Broken on Safari,05/Mar/26,bug,web,carol,01/Mar/26 9:30 AM;carol;Looking into it,\n" +
+		"Ship release,APP-2,Done,Done,App,,,,,,,\n" +
+		"Write docs,APP-3,In Progress,In Progress,App,,,,,dave,,\n"
+
+	export, err := Parse(FormatJira, []byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if export.Board.Name != "App" || len(export.Tabs) != 2 || export.Tabs[0].Name != "In Progress" || export.Tabs[1].Name != "Done" {
+		t.Fatalf("unexpected tabs %+v", export.Tabs)
+	}
+	if len(export.Tabs[0].Cards) != 2 || len(export.Tabs[1].Cards) != 1 || !export.Tabs[1].Cards[0].Status {
+		t.Fatalf("unexpected cards %+v", export.Tabs)
+	}
+
+	fix := export.Tabs[0].Cards[0]
+	if fix.Name != "Fix login" || fix.Content != "Broken on Safari" || fix.Date != "2026-03-05" || fix.Status {
+		t.Errorf("unexpected card fields %+v", fix)
+	}
+	if len(fix.Labels) != 2 || fix.Labels[1].Title != "web" || fix.Labels[1].Color != DefaultLabelColor {
+		t.Errorf("labels = %+v", fix.Labels)
+	}
+	if len(fix.Comments) != 1 || fix.Comments[0].Author.Username != "carol" || fix.Comments[0].Comment != "Looking into it" {
+		t.Errorf("comments = %+v", fix.Comments)
+	}
+	if len(export.Members) != 2 {
+		t.Errorf("expected each assignee once as a member, got %+v", export.Members)
+	}
+
+	if _, err := Parse(FormatJira, []byte("Key,Title\nA,b\n")); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("expected ErrInvalidFile without Summary and Status, got %v", err)
+	}
+}
+
+func TestImport_UnknownUsers(t *testing.T) {
+	repo := &mockRepository{users: []user.User{{ID: 1, Username: "alice"}, {ID: 2, Username: "Carol"}}}
+	uc := newTestUseCase(repo, nil)
+
+	result, err := uc.Import(context.Background(), 1, ImportRequest{WorkspaceID: 4, Format: FormatTrello, Name: "Imported", Data: []byte(trelloFile)})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Board.Name != "Imported" {
+		t.Errorf("expected the name override, got %q", result.Board.Name)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "dave" {
+		t.Errorf("skipped = %v", result.Skipped)
+	}
+
+	// carol is matched case-insensitively and joins as an editor
+	if len(repo.plan.Members) != 1 || repo.plan.Members[0] != (PlannedMember{UserID: 2, Role: policy.RoleEditor}) {
+		t.Errorf("members = %+v", repo.plan.Members)
+	}
+	comments := repo.plan.Tabs[0].Cards[0].Comments
+	if comments[0].UserID != 1 || comments[0].Comment != "dave: earlier" || comments[1].UserID != 2 {
+		t.Errorf("comments = %+v", comments)
+	}
+}
+
+func TestImport_Forbidden(t *testing.T) {
+	repo := &mockRepository{}
+	uc := newTestUseCase(repo, nil)
+
+	_, err := uc.Import(context.Background(), 3, ImportRequest{WorkspaceID: 4, Format: FormatTrello, Data: []byte(trelloFile)})
+	if !policy.IsForbidden(err) {
+		t.Errorf("expected forbidden, got %v", err)
+	}
+	if repo.created != nil {
+		t.Error("expected nothing to be created")
+	}
+}
+
+// bigExport has one more card than is imported inline
+func bigExport(t *testing.T) []byte {
+	export := Export{Version: Version, Board: BoardRecord{Name: "Big"}, Tabs: []TabRecord{{Name: "Todo"}}}
+	for i := 0; i <= InlineCardLimit; i++ {
+		export.Tabs[0].Cards = append(export.Tabs[0].Cards, CardRecord{Name: fmt.Sprintf("card %d", i)})
+	}
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestImport_QueuesLargeFiles(t *testing.T) {
+	repo := &mockRepository{users: []user.User{{ID: 1, Username: "alice"}}}
+	queue := &mockQueue{}
+	uc := newTestUseCase(repo, queue)
+
+	result, err := uc.Import(context.Background(), 1, ImportRequest{WorkspaceID: 4, Data: bigExport(t)})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Board != nil || result.Job == nil || result.Job.Status != JobPending || result.Job.Format != FormatTraspac {
+		t.Fatalf("expected a pending job, got %+v", result)
+	}
+	if repo.created != nil {
+		t.Fatal("expected the board to wait for the worker")
+	}
+
+	var msg JobMessage
+	if len(queue.bodies) != 1 || json.Unmarshal(queue.bodies[0], &msg) != nil || msg.JobID != result.Job.ID {
+		t.Fatalf("unexpected queue messages %q", queue.bodies)
+	}
+
+	if err := uc.RunJob(context.Background(), msg.JobID); err != nil {
+		t.Fatalf("RunJob() error = %v", err)
+	}
+	job, err := uc.FindJob(msg.JobID, 1)
+	if err != nil {
+		t.Fatalf("FindJob() error = %v", err)
+	}
+	if job.Status != JobDone || job.BoardID == nil || *job.BoardID != 99 || job.Source != "" || job.FinishedAt == nil {
+		t.Errorf("unexpected finished job %+v", job)
+	}
+	if len(repo.plan.Tabs[0].Cards) != InlineCardLimit+1 {
+		t.Errorf("expected every card to be imported, got %d", len(repo.plan.Tabs[0].Cards))
+	}
+
+	// a second delivery of the same message does nothing
+	repo.created = nil
+	if err := uc.RunJob(context.Background(), msg.JobID); err != nil || repo.created != nil {
+		t.Errorf("expected a finished job not to run again, err = %v", err)
+	}
+
+	if _, err := uc.FindJob(msg.JobID, 2); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected other users not to see the job, got %v", err)
+	}
+}
+
+func TestImport_QueueFailure(t *testing.T) {
+	repo := &mockRepository{}
+	uc := newTestUseCase(repo, &mockQueue{err: errors.New("broker down")})
+
+	if _, err := uc.Import(context.Background(), 1, ImportRequest{WorkspaceID: 4, Data: bigExport(t)}); err == nil {
+		t.Fatal("expected the enqueue error")
+	}
+	job := repo.jobs[1]
+	if job.Status != JobFailed || !strings.Contains(job.Error, "broker down") {
+		t.Errorf("expected the job to be marked failed, got %+v", job)
+	}
+}
+
+func TestRunJob_InvalidSource(t *testing.T) {
+	repo := &mockRepository{jobs: map[uint]*Job{1: {ID: 1, CreatedBy: 1, Format: FormatJira, Source: "nothing here", Status: JobPending}}}
+	uc := newTestUseCase(repo, nil)
+
+	if err := uc.RunJob(context.Background(), 1); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("expected ErrInvalidFile, got %v", err)
+	}
+	if job := repo.jobs[1]; job.Status != JobFailed || job.Error == "" {
+		t.Errorf("expected the job to be marked failed, got %+v", job)
+	}
+}
+
+func TestRunJob_AccessRevoked(t *testing.T) {
+	// user 3 could edit when queueing the job but only views now
+	repo := &mockRepository{jobs: map[uint]*Job{1: {ID: 1, WorkspaceID: 4, CreatedBy: 3, Source: string(bigExport(t)), Status: JobPending}}}
+	uc := newTestUseCase(repo, nil)
+
+	if err := uc.RunJob(context.Background(), 1); !policy.IsForbidden(err) {
+		t.Errorf("expected forbidden, got %v", err)
+	}
+	if repo.created != nil {
+		t.Error("expected nothing to be created")
+	}
+	if job := repo.jobs[1]; job.Status != JobFailed || job.Source != "" {
+		t.Errorf("expected the job to be marked failed, got %+v", job)
+	}
+}
+
+func TestFailStaleJobs(t *testing.T) {
+	stale := time.Now().Add(-StaleJobAfter - time.Minute)
+	recent := time.Now().Add(-time.Minute)
+	repo := &mockRepository{jobs: map[uint]*Job{
+		1: {ID: 1, Status: JobRunning, ClaimedAt: &stale, Source: "file"},
+		2: {ID: 2, Status: JobRunning, ClaimedAt: &recent, Source: "file"},
+		3: {ID: 3, Status: JobPending, Source: "file"},
+	}}
+	uc := newTestUseCase(repo, nil)
+
+	failed, err := uc.FailStaleJobs()
+	if err != nil || failed != 1 {
+		t.Fatalf("FailStaleJobs() = %d, %v, want 1", failed, err)
+	}
+	if job := repo.jobs[1]; job.Status != JobFailed || job.Error != ErrJobStale.Error() || job.Source != "" {
+		t.Errorf("expected the stale job to be failed, got %+v", job)
+	}
+	if repo.jobs[2].Status != JobRunning || repo.jobs[3].Status != JobPending {
+		t.Error("expected only the stale job to change")
+	}
+
+	// a late delivery of its message does nothing
+	if err := uc.RunJob(context.Background(), 1); err != nil || repo.created != nil {
+		t.Errorf("expected a failed job not to run again, err = %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package boards
+
+import (
+	"time"
+
+	"hrm-app/internal/domain/boardsUsers"
+	"hrm-app/internal/domain/labels"
+	"hrm-app/internal/domain/taskCard"
+	"hrm-app/internal/domain/taskCardUsers"
+	"hrm-app/internal/domain/taskTab"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/pkg/rank"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TabBlueprint describes a tab, with its cards, that a new board starts with
+type TabBlueprint struct {
+	Name  string          `json:"name"`
+	Cards []CardBlueprint `json:"cards,omitempty"`
+}
+
+type CardBlueprint struct {
+	Name    string           `json:"name"`
+	Content string           `json:"content,omitempty"`
+	Labels  []LabelBlueprint `json:"labels,omitempty"`
+}
+
+type LabelBlueprint struct {
+	Title string `json:"title"`
+	Color string `json:"color"`
+}
+
+// DefaultBlueprint is used for boards created without a template
+var DefaultBlueprint = []TabBlueprint{{Name: "Todo"}, {Name: "In Progress"}, {Name: "Done"}}
+
+// BlueprintOf captures the tabs of a board loaded by Repository.FindByID,
+// with their cards and labels when withCards is set
+func BlueprintOf(board *Boards, withCards bool) []TabBlueprint {
+	tabs := make([]TabBlueprint, 0, len(board.TaskTabs))
+	for _, tab := range board.TaskTabs {
+		blueprint := TabBlueprint{Name: tab.Name}
+		if withCards {
+			for _, card := range tab.TaskCards {
+				cardBlueprint := CardBlueprint{Name: card.Name, Content: card.Content}
+				for _, label := range card.Labels {
+					cardBlueprint.Labels = append(cardBlueprint.Labels, LabelBlueprint{Title: label.Title, Color: label.Color})
+				}
+				blueprint.Cards = append(blueprint.Cards, cardBlueprint)
+			}
+		}
+		tabs = append(tabs, blueprint)
+	}
+	return tabs
+}
+
+// Seed creates the tabs, cards and labels of a blueprint on a new board. Cards
+// are dated today.
+func Seed(tx *gorm.DB, boardID uint, tabs []TabBlueprint) error {
+	today := time.Now().Format("2006-01-02")
+
+	tabRanks := rank.Sequence(len(tabs))
+	for i, blueprint := range tabs {
+		tab := taskTab.TaskTab{BoardID: boardID, Name: blueprint.Name, Position: i + 1, Rank: tabRanks[i]}
+		if err := tx.Omit(clause.Associations).Create(&tab).Error; err != nil {
+			return err
+		}
+
+		cardRanks := rank.Sequence(len(blueprint.Cards))
+		for j, cardBlueprint := range blueprint.Cards {
+			card := taskCard.TaskCard{TaskTabID: tab.ID, Name: cardBlueprint.Name, Content: cardBlueprint.Content, Date: today, Rank: cardRanks[j]}
+			if err := tx.Omit(clause.Associations).Create(&card).Error; err != nil {
+				return err
+			}
+
+			if len(cardBlueprint.Labels) == 0 {
+				continue
+			}
+			cardLabels := make([]labels.TaskCardLabel, 0, len(cardBlueprint.Labels))
+			for _, label := range cardBlueprint.Labels {
+				cardLabels = append(cardLabels, labels.TaskCardLabel{TaskCardID: card.ID, Title: label.Title, Color: label.Color})
+			}
+			if err := tx.Create(&cardLabels).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Clone creates board as a deep copy of the board sourceID: its tabs, cards
+// and labels, and with includeMembers its members and card assignees. IDs are
+// remapped to the copies and ranks kept, so the copy keeps the same order.
+// Comments and attachments stay with the original. board.CreatedBy always
+// becomes an owner of the copy. Run it inside a transaction so a failure
+// leaves nothing behind.
+func Clone(tx *gorm.DB, sourceID uint, board *Boards, includeMembers bool) error {
+	if err := tx.Omit(clause.Associations).Create(board).Error; err != nil {
+		return err
+	}
+
+	var tabs []taskTab.TaskTab
+	if err := tx.Omit(clause.Associations).Where("board_id = ?", sourceID).Order("rank, id").Find(&tabs).Error; err != nil {
+		return err
+	}
+	tabIDs := make(map[uint]uint, len(tabs))
+	for _, tab := range tabs {
+		oldID := tab.ID
+		tab.ID, tab.BoardID = 0, board.ID
+		if err := tx.Omit(clause.Associations).Create(&tab).Error; err != nil {
+			return err
+		}
+		tabIDs[oldID] = tab.ID
+	}
+
+	cardIDs := map[uint]uint{}
+	if len(tabIDs) > 0 {
+		var cards []taskCard.TaskCard
+		if err := tx.Where("task_tab_id IN ?", keys(tabIDs)).Order("id").Find(&cards).Error; err != nil {
+			return err
+		}
+		for _, card := range cards {
+			oldID := card.ID
+			card.ID, card.TaskTabID = 0, tabIDs[card.TaskTabID]
+			if err := tx.Omit(clause.Associations).Create(&card).Error; err != nil {
+				return err
+			}
+			cardIDs[oldID] = card.ID
+		}
+	}
+
+	if len(cardIDs) > 0 {
+		var cardLabels []labels.TaskCardLabel
+		if err := tx.Where("task_card_id IN ?", keys(cardIDs)).Order("id").Find(&cardLabels).Error; err != nil {
+			return err
+		}
+		for i := range cardLabels {
+			cardLabels[i].ID, cardLabels[i].TaskCardID = 0, cardIDs[cardLabels[i].TaskCardID]
+		}
+		if len(cardLabels) > 0 {
+			if err := tx.Create(&cardLabels).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return cloneMembers(tx, sourceID, board, cardIDs, includeMembers)
+}
+
+func cloneMembers(tx *gorm.DB, sourceID uint, board *Boards, cardIDs map[uint]uint, includeMembers bool) error {
+	members := []boardsUsers.BoardsUsers{{BoardID: board.ID, UserID: board.CreatedBy, Role: policy.RoleOwner}}
+	if !includeMembers {
+		return tx.Omit(clause.Associations).Create(&members).Error
+	}
+
+	var sourceMembers []boardsUsers.BoardsUsers
+	if err := tx.Omit(clause.Associations).Where("board_id = ? AND user_id <> ?", sourceID, board.CreatedBy).Order("id").Find(&sourceMembers).Error; err != nil {
+		return err
+	}
+	for _, member := range sourceMembers {
+		members = append(members, boardsUsers.BoardsUsers{BoardID: board.ID, UserID: member.UserID, Role: member.Role})
+	}
+	if err := tx.Omit(clause.Associations).Create(&members).Error; err != nil {
+		return err
+	}
+
+	if len(cardIDs) == 0 {
+		return nil
+	}
+	var assignees []taskCardUsers.TaskCardUsers
+	if err := tx.Omit(clause.Associations).Where("task_card_id IN ?", keys(cardIDs)).Order("id").Find(&assignees).Error; err != nil {
+		return err
+	}
+	if len(assignees) == 0 {
+		return nil
+	}
+	copies := make([]taskCardUsers.TaskCardUsers, 0, len(assignees))
+	for _, assignee := range assignees {
+		copies = append(copies, taskCardUsers.TaskCardUsers{TaskCardID: cardIDs[assignee.TaskCardID], UserID: assignee.UserID})
+	}
+	return tx.Omit(clause.Associations).Create(&copies).Error
+}
+
+func keys(ids map[uint]uint) []uint {
+	list := make([]uint, 0, len(ids))
+	for id := range ids {
+		list = append(list, id)
+	}
+	return list
+}
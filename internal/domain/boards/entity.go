@@ -15,4 +15,16 @@ type Boards struct {
 	TaskCards   interface{}       `json:"task_cards" gorm:"-"`
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
+
+	// Template picks the starting tabs on create: a built-in template key, or
+	// TemplateID for a template saved in the workspace
+	Template   string `json:"template,omitempty" gorm:"-"`
+	TemplateID uint   `json:"template_id,omitempty" gorm:"-"`
+}
+
+// DuplicateRequest copies a board, into another workspace when WorkspaceID is set
+type DuplicateRequest struct {
+	Name           string `json:"name"`
+	WorkspaceID    uint   `json:"workspace_id"`
+	IncludeMembers bool   `json:"include_members"`
 }
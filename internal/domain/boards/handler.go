@@ -1,6 +1,9 @@
 package boards
 
 import (
+	"errors"
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/response"
 	"net/http"
 	"strconv"
@@ -8,12 +11,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Auditor records board changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
 type Handler struct {
 	usecase UseCase
+	audit   Auditor
 }
 
-func NewHandler(usecase UseCase) Handler {
-	return Handler{usecase: usecase}
+func NewHandler(usecase UseCase, auditor Auditor) Handler {
+	return Handler{usecase: usecase, audit: auditor}
 }
 
 func (h *Handler) CreateBoard(c *gin.Context) {
@@ -34,9 +43,19 @@ func (h *Handler) CreateBoard(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	if err := h.usecase.Create(ctx, &boards); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
+		if errors.Is(err, ErrTemplateNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+
+	h.audit.Record(audit.FromRequest(c, "create_board", audit.EntityBoard, boards.ID).WithBoard(boards.ID).WithWorkspace(boards.WorkspaceID).WithChanges(nil, &boards))
 	response.Success(c, "Board created successfully")
 }
 
@@ -97,11 +116,29 @@ func (h *Handler) UpdateBoard(c *gin.Context) {
 
 	boards.ID = uint(idInt)
 
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	ctx := c.Request.Context()
-	if err := h.usecase.Update(ctx, &boards); err != nil {
+	before, _ := h.usecase.FindByID(ctx, boards.ID, userID.(uint))
+
+	if err := h.usecase.Update(ctx, &boards, userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+
+	entry := audit.FromRequest(c, "update_board", audit.EntityBoard, boards.ID).WithBoard(boards.ID)
+	if after, err := h.usecase.FindByID(ctx, boards.ID, userID.(uint)); err == nil && before != nil {
+		entry = entry.WithChanges(before, after)
+	}
+	h.audit.Record(entry)
 	response.Success(c, "Board updated successfully")
 }
 
@@ -112,14 +149,77 @@ func (h *Handler) DeleteBoard(c *gin.Context) {
 		response.Error(c, http.StatusBadRequest, "Invalid board ID")
 		return
 	}
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	ctx := c.Request.Context()
-	if err := h.usecase.Delete(ctx, uint(idInt)); err != nil {
+	before, _ := h.usecase.FindByID(ctx, uint(idInt), userID.(uint))
+
+	if err := h.usecase.Delete(ctx, uint(idInt), userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+
+	// The board row is gone, so the workspace comes from the state read before deleting
+	entry := audit.FromRequest(c, "delete_board", audit.EntityBoard, uint(idInt)).WithBoard(uint(idInt))
+	if before != nil {
+		entry = entry.WithWorkspace(before.WorkspaceID).WithChanges(before, nil)
+	}
+	h.audit.Record(entry)
 	response.Success(c, "Board deleted successfully")
 }
 
+func (h *Handler) DuplicateBoard(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil || idInt < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid board ID")
+		return
+	}
+
+	var req DuplicateRequest
+	// Every field is optional, so an empty body copies the board in place
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	board, err := h.usecase.Duplicate(c.Request.Context(), uint(idInt), userID.(uint), req)
+	if err != nil {
+		switch {
+		case policy.IsForbidden(err):
+			response.Error(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrNotFound):
+			response.Error(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrMembersAcrossWorkspaces):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	h.audit.Record(audit.FromRequest(c, "duplicate_board", audit.EntityBoard, board.ID).WithBoard(board.ID).WithWorkspace(board.WorkspaceID).
+		WithChanges(nil, map[string]interface{}{"source_board_id": idInt, "name": board.Name}))
+	response.Success(c, board)
+}
+
 func (h *Handler) GetByWorkspaceID(c *gin.Context) {
 	workspaceID := c.Param("workspace_id")
 	workspaceIDInt, err := strconv.Atoi(workspaceID)
@@ -127,9 +227,19 @@ func (h *Handler) GetByWorkspaceID(c *gin.Context) {
 		response.Error(c, http.StatusBadRequest, "Invalid workspace ID")
 		return
 	}
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	ctx := c.Request.Context()
-	boards, err := h.usecase.FindByWorkspaceID(ctx, uint(workspaceIDInt))
+	boards, err := h.usecase.FindByWorkspaceID(ctx, uint(workspaceIDInt), userID.(uint))
 	if err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -12,6 +12,7 @@ type Repository interface {
 	FindAll(ctx context.Context) ([]Boards, error)
 	FindByID(ctx context.Context, id uint) (*Boards, error)
 	FindByWorkspaceID(ctx context.Context, workspaceID uint) ([]Boards, error)
+	FindByWorkspaceIDAndMember(ctx context.Context, workspaceID, userID uint) ([]Boards, error)
 	FindByUserID(ctx context.Context, userID uint) ([]Boards, error)
 	FindByIDs(ctx context.Context, ids []uint) ([]Boards, error)
 	FindByUserAccess(ctx context.Context, userID uint) ([]Boards, error)
@@ -39,9 +40,11 @@ func (r *repository) FindByID(ctx context.Context, id uint) (*Boards, error) {
 	var boards Boards
 	err := database.DB.WithContext(ctx).
 		Preload("TaskTabs", func(db *gorm.DB) *gorm.DB {
-			return db.Order("position asc")
+			return db.Order("rank, id")
+		}).
+		Preload("TaskTabs.TaskCards", func(db *gorm.DB) *gorm.DB {
+			return db.Order("rank, id")
 		}).
-		Preload("TaskTabs.TaskCards").
 		Preload("TaskTabs.TaskCards.Labels").
 		Preload("TaskTabs.TaskCards.Members.User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "username")
@@ -74,6 +77,17 @@ func (r *repository) FindByWorkspaceID(ctx context.Context, workspaceID uint) ([
 	return boards, err
 }
 
+// FindByWorkspaceIDAndMember lists the boards of a workspace the user is a board member of
+func (r *repository) FindByWorkspaceIDAndMember(ctx context.Context, workspaceID, userID uint) ([]Boards, error) {
+	var boards []Boards
+	err := database.DB.WithContext(ctx).
+		Select("boards.id", "boards.workspace_id", "boards.created_by", "boards.name", "boards.images").
+		Joins("JOIN boards_users ON boards_users.board_id = boards.id AND boards_users.user_id = ?", userID).
+		Where("boards.workspace_id = ?", workspaceID).
+		Find(&boards).Error
+	return boards, err
+}
+
 func (r *repository) FindByUserID(ctx context.Context, userID uint) ([]Boards, error) {
 	var boards []Boards
 	err := database.DB.WithContext(ctx).
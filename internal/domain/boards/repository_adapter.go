@@ -23,6 +23,7 @@ func (a *RepositoryAdapter) FindByID(id uint) (*boardsUsers.BoardInfo, error) {
 
 	return &boardsUsers.BoardInfo{
 		ID:          board.ID,
+		Name:        board.Name,
 		CreatedBy:   board.CreatedBy,
 		WorkspaceID: board.WorkspaceID,
 	}, nil
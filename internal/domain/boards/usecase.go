@@ -9,18 +9,31 @@ import (
 	"hrm-app/internal/domain/taskCardUsers"
 	"hrm-app/internal/domain/taskTab"
 	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/policy"
 
 	"gorm.io/gorm"
 )
 
+var (
+	ErrNotFound                = errors.New("board not found")
+	ErrTemplateNotFound        = errors.New("board template not found")
+	ErrMembersAcrossWorkspaces = errors.New("members can only be copied within the same workspace")
+)
+
+// Templates resolves the template a board is created from (implemented by template.UseCase)
+type Templates interface {
+	Blueprint(key string, id, workspaceID, userID uint) ([]TabBlueprint, error)
+}
+
 type UseCase interface {
 	Create(ctx context.Context, boards *Boards) error
 	FindAll(ctx context.Context) ([]Boards, error)
 	FindByID(ctx context.Context, id, userID uint) (*Boards, error)
-	FindByWorkspaceID(ctx context.Context, workspaceID uint) ([]Boards, error)
+	FindByWorkspaceID(ctx context.Context, workspaceID, userID uint) ([]Boards, error)
 	FindByUserID(ctx context.Context, userID uint) ([]Boards, error)
-	Update(ctx context.Context, boards *Boards) error
-	Delete(ctx context.Context, id uint) error
+	Update(ctx context.Context, boards *Boards, userID uint) error
+	Delete(ctx context.Context, id, userID uint) error
+	Duplicate(ctx context.Context, id, userID uint, req DuplicateRequest) (*Boards, error)
 
 	// New methods for optimization
 	GetTabsByBoardID(ctx context.Context, boardID uint) ([]TaskTabSummary, error)
@@ -31,6 +44,7 @@ type TaskTabSummary struct {
 	ID       uint   `json:"id"`
 	BoardID  uint   `json:"board_id"`
 	Position int    `json:"position"`
+	Rank     string `json:"rank"`
 	Name     string `json:"name"`
 }
 
@@ -40,6 +54,7 @@ type TaskCardSummary struct {
 	Name      string                        `json:"name"`
 	Date      string                        `json:"date"`
 	Status    bool                          `json:"status"`
+	Rank      string                        `json:"rank"`
 	Labels    []labels.TaskCardLabel        `json:"labels"`
 	Members   []taskCardUsers.TaskCardUsers `json:"members"`
 }
@@ -51,6 +66,8 @@ type usecase struct {
 	boardsUsersRepo   boardsUsers.Repository
 	labelsRepo        labels.Repository
 	taskCardUsersRepo taskCardUsers.Repository
+	templates         Templates
+	authz             policy.Authorizer
 }
 
 func NewUseCase(
@@ -60,6 +77,8 @@ func NewUseCase(
 	boardsUsersRepo boardsUsers.Repository,
 	labelsRepo labels.Repository,
 	taskCardUsersRepo taskCardUsers.Repository,
+	templates Templates,
+	authz policy.Authorizer,
 ) UseCase {
 	return &usecase{
 		repo:              repo,
@@ -68,6 +87,8 @@ func NewUseCase(
 		boardsUsersRepo:   boardsUsersRepo,
 		labelsRepo:        labelsRepo,
 		taskCardUsersRepo: taskCardUsersRepo,
+		templates:         templates,
+		authz:             authz,
 	}
 }
 
@@ -76,33 +97,36 @@ func (u *usecase) Create(ctx context.Context, boards *Boards) error {
 		return errors.New("name is required")
 	}
 
+	// Guests and viewers of a workspace cannot create boards in it
+	if err := u.authz.AuthorizeWorkspace(boards.WorkspaceID, boards.CreatedBy, policy.ActionEdit); err != nil {
+		return err
+	}
+
+	tabs := DefaultBlueprint
+	if boards.Template != "" || boards.TemplateID != 0 {
+		blueprint, err := u.templates.Blueprint(boards.Template, boards.TemplateID, boards.WorkspaceID, boards.CreatedBy)
+		if err != nil {
+			return err
+		}
+		tabs = blueprint
+	}
+
 	err := database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Create the board
 		if err := tx.Create(boards).Error; err != nil {
 			return err
 		}
 
-		// Create default task tabs
-		defaultTabs := []string{"Todo", "In Progress", "Done"}
-		var tabs []taskTab.TaskTab
-		// Prealloc slice
-		tabs = make([]taskTab.TaskTab, 0, len(defaultTabs))
-
-		for i, tabName := range defaultTabs {
-			tabs = append(tabs, taskTab.TaskTab{
-				BoardID:  boards.ID,
-				Name:     tabName,
-				Position: i + 1,
-			})
-		}
-		if err := tx.Create(&tabs).Error; err != nil {
+		// Create the template's task tabs, the default ones without a template
+		if err := Seed(tx, boards.ID, tabs); err != nil {
 			return err
 		}
 
-		// Create board user (creator)
+		// Create board user (creator as owner)
 		boardUser := &boardsUsers.BoardsUsers{
 			BoardID: boards.ID,
 			UserID:  boards.CreatedBy,
+			Role:    policy.RoleOwner,
 		}
 		if err := tx.Create(boardUser).Error; err != nil {
 			return err
@@ -156,18 +180,80 @@ func (u *usecase) FindByUserID(ctx context.Context, userID uint) ([]Boards, erro
 	return boards, nil
 }
 
-func (u *usecase) FindByWorkspaceID(ctx context.Context, workspaceID uint) ([]Boards, error) {
-	return u.repo.FindByWorkspaceID(ctx, workspaceID)
+// FindByWorkspaceID lists the boards of a workspace the caller can view:
+// every board for workspace owners and admins, who inherit a role on each
+// board, and otherwise only the boards the caller is a member of.
+func (u *usecase) FindByWorkspaceID(ctx context.Context, workspaceID, userID uint) ([]Boards, error) {
+	role, err := u.authz.WorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role.AtLeast(policy.RoleAdmin) {
+		return u.repo.FindByWorkspaceID(ctx, workspaceID)
+	}
+
+	boards, err := u.repo.FindByWorkspaceIDAndMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" && len(boards) == 0 {
+		return nil, policy.ErrNotMember
+	}
+	return boards, nil
 }
 
-func (u *usecase) Update(ctx context.Context, boards *Boards) error {
+func (u *usecase) Update(ctx context.Context, boards *Boards, userID uint) error {
+	if err := u.authz.AuthorizeBoard(boards.ID, userID, policy.ActionUpdate); err != nil {
+		return err
+	}
 	return u.repo.Update(ctx, boards)
 }
 
-func (u *usecase) Delete(ctx context.Context, id uint) error {
+func (u *usecase) Delete(ctx context.Context, id, userID uint) error {
+	if err := u.authz.AuthorizeBoard(id, userID, policy.ActionDelete); err != nil {
+		return err
+	}
 	return u.repo.Delete(ctx, id)
 }
 
+// Duplicate deep copies a board with its tabs, cards and labels, into the same
+// workspace unless req.WorkspaceID picks another one the caller can edit.
+// Members and card assignees are only copied within the same workspace.
+func (u *usecase) Duplicate(ctx context.Context, id, userID uint, req DuplicateRequest) (*Boards, error) {
+	if err := u.authz.AuthorizeBoard(id, userID, policy.ActionView); err != nil {
+		return nil, err
+	}
+	source, err := u.repo.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.WorkspaceID == 0 {
+		req.WorkspaceID = source.WorkspaceID
+	}
+	if req.IncludeMembers && req.WorkspaceID != source.WorkspaceID {
+		return nil, ErrMembersAcrossWorkspaces
+	}
+	if err := u.authz.AuthorizeWorkspace(req.WorkspaceID, userID, policy.ActionEdit); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		req.Name = source.Name + " (copy)"
+	}
+	board := &Boards{WorkspaceID: req.WorkspaceID, CreatedBy: userID, Name: req.Name, Images: source.Images}
+	err = database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return Clone(tx, source.ID, board, req.IncludeMembers)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
 func (u *usecase) GetTabsByBoardID(ctx context.Context, boardID uint) ([]TaskTabSummary, error) {
 	tabs, err := u.taskTabRepo.FindByBoardID(boardID) // taskTabRepo likely needs Context update too if we want full consistency, checking later
 	if err != nil {
@@ -180,6 +266,7 @@ func (u *usecase) GetTabsByBoardID(ctx context.Context, boardID uint) ([]TaskTab
 			ID:       t.ID,
 			BoardID:  t.BoardID,
 			Position: t.Position,
+			Rank:     t.Rank,
 			Name:     t.Name,
 		})
 	}
@@ -215,6 +302,7 @@ func (u *usecase) GetCardsByTaskTabID(ctx context.Context, taskTabID uint, limit
 			Name:      c.Name,
 			Date:      c.Date,
 			Status:    c.Status,
+			Rank:      c.Rank,
 			Labels:    c.Labels,
 			Members:   c.Members,
 		})
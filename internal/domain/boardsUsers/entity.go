@@ -2,14 +2,16 @@ package boardsUsers
 
 import (
 	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/policy"
 	"time"
 )
 
 type BoardsUsers struct {
-	ID        uint       `json:"id"`
-	BoardID   uint       `json:"board_id"`
-	UserID    uint       `json:"user_id"`
-	User      *user.User `json:"user,omitempty" gorm:"foreignKey:UserID"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID        uint        `json:"id"`
+	BoardID   uint        `json:"board_id"`
+	UserID    uint        `json:"user_id"`
+	Role      policy.Role `json:"role" gorm:"default:editor"` // admin, editor, commenter or viewer; owner for the creator
+	User      *user.User  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
 }
@@ -1,20 +1,29 @@
 package boardsUsers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Auditor records membership changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
 type Handler struct {
 	usecase UseCase
+	audit   Auditor
 }
 
-func NewHandler(u UseCase) *Handler {
-	return &Handler{usecase: u}
+func NewHandler(u UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: u, audit: auditor}
 }
 
 func (h *Handler) Create(c *gin.Context) {
@@ -32,10 +41,15 @@ func (h *Handler) Create(c *gin.Context) {
 	}
 
 	if err := h.usecase.Create(&boardUsers, userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	h.audit.Record(audit.FromRequest(c, "assign_board_user", audit.EntityBoardUser, boardUsers.ID).WithBoard(boardUsers.BoardID).WithChanges(nil, &boardUsers))
 	response.Success(c, boardUsers)
 }
 
@@ -97,11 +111,25 @@ func (h *Handler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.Delete(uint(id)); err != nil {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	existing, _ := h.usecase.GetByID(uint(id))
+
+	if err := h.usecase.Delete(uint(id), userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to delete board user")
 		return
 	}
 
+	h.audit.Record(audit.FromRequest(c, "unassign_board_user", audit.EntityBoardUser, uint(id)).WithBoard(existing.BoardID).WithChanges(&existing, nil))
 	response.DeleteSuccess(c, "Board user deleted successfully")
 }
 
@@ -121,23 +149,6 @@ func (h *Handler) Update(c *gin.Context) {
 
 	boardUsers.ID = uint(id)
 
-	if err := h.usecase.Update(&boardUsers); err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	response.Success(c, boardUsers)
-}
-
-func (h *Handler) Join(c *gin.Context) {
-	var req struct {
-		Token string `json:"token"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
-		return
-	}
-
 	// Get user_id from context (set by AuthMiddleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -145,19 +156,27 @@ func (h *Handler) Join(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.Join(userID.(uint), req.Token); err != nil {
+	before, _ := h.usecase.GetByID(boardUsers.ID)
+
+	if err := h.usecase.Update(&boardUsers, userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	response.Success(c, gin.H{"message": "Joined board successfully"})
+	h.audit.Record(audit.FromRequest(c, "update_board_user", audit.EntityBoardUser, boardUsers.ID).WithBoard(boardUsers.BoardID).WithChanges(&before, &boardUsers))
+	response.Success(c, boardUsers)
 }
 
-func (h *Handler) GenerateJoinToken(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil || id < 0 {
-		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+func (h *Handler) Join(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -168,11 +187,23 @@ func (h *Handler) GenerateJoinToken(c *gin.Context) {
 		return
 	}
 
-	token, err := h.usecase.GenerateJoinToken(uint(id), userID.(uint))
+	membership, err := h.usecase.Join(userID.(uint), req.Token)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		var linkErr *InviteLinkError
+		switch {
+		case errors.As(err, &linkErr):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		case policy.IsForbidden(err):
+			response.Error(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrAlreadyMember):
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
-	response.Success(c, gin.H{"token": token})
+	h.audit.Record(audit.FromRequest(c, "join_board", audit.EntityBoardUser, membership.ID).WithBoard(membership.BoardID).WithChanges(nil, membership))
+
+	response.Success(c, gin.H{"message": response.Message(c, "Joined board successfully")})
 }
@@ -3,9 +3,12 @@ package boardsUsers
 import (
 	"errors"
 	"hrm-app/config"
-	"hrm-app/internal/pkg/utils"
+	"hrm-app/internal/domain/notification"
+	"hrm-app/internal/pkg/policy"
 )
 
+var ErrAlreadyMember = errors.New("user already assigned to this board")
+
 // BoardRepository defines minimal interface needed to verify board ownership
 type BoardRepository interface {
 	FindByID(id uint) (*BoardInfo, error)
@@ -14,19 +17,36 @@ type BoardRepository interface {
 // BoardInfo contains minimal board information needed for authorization
 type BoardInfo struct {
 	ID          uint
+	Name        string
 	CreatedBy   uint
 	WorkspaceID uint
 }
 
-// WorkspaceRepository defines minimal interface needed to verify workspace passcode
-type WorkspaceRepository interface {
-	FindByID(id uint) (*WorkspaceInfo, error)
+// InviteLinks checks and counts invite link uses (implemented by inviteLink.NewBoardAdapter)
+type InviteLinks interface {
+	Resolve(token string, userID uint) (*InviteLinkInfo, error)
+	Consume(id uint) error
+}
+
+// InviteLinkInfo is what Join needs to know about a usable invite link
+type InviteLinkInfo struct {
+	ID      uint
+	BoardID uint
+	Role    policy.Role
+}
+
+// InviteLinkError marks a Join refused because of the link itself, such as a
+// revoked or used up link, as opposed to a server failure
+type InviteLinkError struct {
+	Err error
 }
 
-// WorkspaceInfo contains minimal workspace information needed for passcode verification
-type WorkspaceInfo struct {
-	ID       uint
-	PassCode string
+func (e *InviteLinkError) Error() string { return e.Err.Error() }
+func (e *InviteLinkError) Unwrap() error { return e.Err }
+
+// Notifier tells users they were added (implemented by notification.UseCase)
+type Notifier interface {
+	Notify(userID uint, n notification.Notification)
 }
 
 type UseCase interface {
@@ -34,48 +54,72 @@ type UseCase interface {
 	GetByBoardID(boardID uint) ([]BoardsUsers, error)
 	GetByUserID(userID uint) ([]BoardsUsers, error)
 	GetByID(id uint) (BoardsUsers, error)
-	Delete(id uint) error
-	Update(boardUsers *BoardsUsers) error
+	Delete(id, requestingUserID uint) error
+	Update(boardUsers *BoardsUsers, requestingUserID uint) error
 	HasAccess(boardID, userID uint) (bool, error)
-	Join(userID uint, token string) error
-	GenerateJoinToken(boardID, userID uint) (string, error)
+	Join(userID uint, token string) (*BoardsUsers, error)
 }
 
 type usecase struct {
-	repo          Repository
-	boardRepo     BoardRepository
-	workspaceRepo WorkspaceRepository
-	cfg           *config.Config
+	repo      Repository
+	boardRepo BoardRepository
+	links     InviteLinks
+	authz     policy.Authorizer
+	notifier  Notifier
+	cfg       *config.Config
 }
 
-func NewUseCase(repo Repository, boardRepo BoardRepository, workspaceRepo WorkspaceRepository, cfg *config.Config) UseCase {
+func NewUseCase(repo Repository, boardRepo BoardRepository, links InviteLinks, authz policy.Authorizer, notifier Notifier, cfg *config.Config) UseCase {
 	return &usecase{
-		repo:          repo,
-		boardRepo:     boardRepo,
-		workspaceRepo: workspaceRepo,
-		cfg:           cfg,
+		repo:      repo,
+		boardRepo: boardRepo,
+		links:     links,
+		authz:     authz,
+		notifier:  notifier,
+		cfg:       cfg,
 	}
 }
 
 func (u *usecase) Create(boardUsers *BoardsUsers, requestingUserID uint) error {
 	board, err := u.boardRepo.FindByID(boardUsers.BoardID)
-
 	if err != nil {
 		return errors.New("board not found")
 	}
 
-	// Check if the requesting user is the creator of the board
-	if board.CreatedBy != requestingUserID {
-		return errors.New("unauthorized: only board creator can add users")
+	if boardUsers.Role == "" {
+		boardUsers.Role = policy.RoleEditor
+	}
+
+	// Only board admins and owners can add users, and only below their own role
+	actorRole, err := u.authz.BoardRole(boardUsers.BoardID, requestingUserID)
+	if err != nil {
+		return err
+	}
+	if !policy.CanAssignBoardRole(actorRole, "", boardUsers.Role) {
+		return policy.ErrForbidden
+	}
+	if err := u.authz.CheckWritable(board.WorkspaceID); err != nil {
+		return err
 	}
 
 	// Check if user is already assigned to the board
 	existingUser, _ := u.repo.GetByBoardIDAndUserID(boardUsers.BoardID, boardUsers.UserID)
 	if existingUser != nil && existingUser.ID != 0 {
-		return errors.New("user already assigned to this board")
+		return ErrAlreadyMember
+	}
+
+	if err := u.repo.Create(boardUsers); err != nil {
+		return err
 	}
 
-	return u.repo.Create(boardUsers)
+	if boardUsers.UserID != requestingUserID {
+		u.notifier.Notify(boardUsers.UserID, notification.Notification{
+			Type: notification.TypeBoardMemberAdded,
+			Args: []interface{}{board.Name, boardUsers.Role},
+			Data: map[string]interface{}{"board_id": board.ID, "workspace_id": board.WorkspaceID},
+		})
+	}
+	return nil
 }
 
 func (u *usecase) GetByBoardID(boardID uint) ([]BoardsUsers, error) {
@@ -99,77 +143,93 @@ func (u *usecase) GetByID(id uint) (BoardsUsers, error) {
 	return u.repo.GetByID(id)
 }
 
-func (u *usecase) Delete(id uint) error {
-	return u.repo.Delete(id)
-}
-
-func (u *usecase) Update(boardUsers *BoardsUsers) error {
-	return u.repo.Update(boardUsers)
-}
-
-func (u *usecase) HasAccess(boardID, userID uint) (bool, error) {
-	return u.repo.HasAccess(boardID, userID)
-}
-
-func (u *usecase) GenerateJoinToken(boardID, userID uint) (string, error) {
-	board, err := u.boardRepo.FindByID(boardID)
+func (u *usecase) Delete(id, requestingUserID uint) error {
+	existing, err := u.repo.GetByID(id)
 	if err != nil {
-		return "", errors.New("board not found")
+		return errors.New("board user not found")
 	}
 
-	// Only board creator can generate join token
-	if board.CreatedBy != userID {
-		return "", errors.New("unauthorized: only board creator can generate join token")
-	}
-
-	workspace, err := u.workspaceRepo.FindByID(board.WorkspaceID)
+	actorRole, err := u.authz.BoardRole(existing.BoardID, requestingUserID)
 	if err != nil {
-		return "", errors.New("workspace not found")
+		return err
+	}
+	if !policy.CanRemoveMember(actorRole, existing.Role) {
+		return policy.ErrForbidden
+	}
+	if err := u.checkWritable(existing.BoardID); err != nil {
+		return err
 	}
 
-	return utils.GenerateJoinToken(u.cfg, boardID, "board", workspace.PassCode)
+	return u.repo.Delete(id)
 }
 
-func (u *usecase) Join(userID uint, token string) error {
-	claims, err := utils.ValidateJoinToken(u.cfg, token)
+// Update changes the role of a board member. Other fields are immutable.
+func (u *usecase) Update(boardUsers *BoardsUsers, requestingUserID uint) error {
+	existing, err := u.repo.GetByID(boardUsers.ID)
+	if err != nil {
+		return errors.New("board user not found")
+	}
+
+	actorRole, err := u.authz.BoardRole(existing.BoardID, requestingUserID)
 	if err != nil {
 		return err
 	}
+	if !policy.CanAssignBoardRole(actorRole, existing.Role, boardUsers.Role) {
+		return policy.ErrForbidden
+	}
+	if err := u.checkWritable(existing.BoardID); err != nil {
+		return err
+	}
 
-	if claims.EntityType != "board" {
-		return errors.New("invalid token type")
+	existing.Role = boardUsers.Role
+	existing.User = nil
+	if err := u.repo.Update(&existing); err != nil {
+		return err
 	}
 
-	boardID := claims.EntityID
-	passCode := claims.PassCode
+	*boardUsers = existing
+	return nil
+}
 
-	// Fetch the board to get workspace_id
-	board, err := u.boardRepo.FindByID(boardID)
-	if err != nil {
-		return errors.New("board not found")
-	}
+func (u *usecase) HasAccess(boardID, userID uint) (bool, error) {
+	return u.repo.HasAccess(boardID, userID)
+}
 
-	// Fetch the parent workspace to verify passcode
-	workspace, err := u.workspaceRepo.FindByID(board.WorkspaceID)
+// Join adds the user with the role the invite link grants. A use is counted
+// only once the user is known not to be a member.
+func (u *usecase) Join(userID uint, token string) (*BoardsUsers, error) {
+	link, err := u.links.Resolve(token, userID)
 	if err != nil {
-		return errors.New("workspace not found")
-	}
-
-	// Check if the passcode matches the workspace passcode
-	if workspace.PassCode != passCode {
-		return errors.New("invalid passcode")
+		return nil, err
 	}
 
 	// Check if user is already assigned to the board
-	existingUser, _ := u.repo.GetByBoardIDAndUserID(boardID, userID)
+	existingUser, _ := u.repo.GetByBoardIDAndUserID(link.BoardID, userID)
 	if existingUser != nil && existingUser.ID != 0 {
-		return errors.New("user already assigned to this board")
+		return nil, ErrAlreadyMember
+	}
+
+	if err := u.links.Consume(link.ID); err != nil {
+		return nil, err
 	}
 
 	boardUsers := &BoardsUsers{
-		BoardID: boardID,
+		BoardID: link.BoardID,
 		UserID:  userID,
+		Role:    link.Role,
 	}
 
-	return u.repo.Create(boardUsers)
+	if err := u.repo.Create(boardUsers); err != nil {
+		return nil, err
+	}
+	return boardUsers, nil
+}
+
+// checkWritable refuses membership changes on a board of an archived or trashed workspace
+func (u *usecase) checkWritable(boardID uint) error {
+	board, err := u.boardRepo.FindByID(boardID)
+	if err != nil || board == nil {
+		return errors.New("board not found")
+	}
+	return u.authz.CheckWritable(board.WorkspaceID)
 }
@@ -0,0 +1,16 @@
+package emailVerification
+
+import "time"
+
+// EmailVerificationToken stores the SHA-256 hash of an email verification token
+type EmailVerificationToken struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id"`
+	TokenHash string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ResendRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
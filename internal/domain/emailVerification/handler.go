@@ -0,0 +1,54 @@
+package emailVerification
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		response.Error(c, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	if err := h.usecase.Verify(c.Request.Context(), token); err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to verify email")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": response.Message(c, "Email verified successfully")})
+}
+
+func (h *Handler) Resend(c *gin.Context) {
+	var req ResendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.usecase.Resend(c.Request.Context(), req.Email); err != nil {
+		log.Printf("[Verify Email] Failed to resend verification link: %v", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to send verification link")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": response.Message(c, "If the email is registered and unverified, a verification link has been sent")})
+}
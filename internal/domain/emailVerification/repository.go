@@ -0,0 +1,41 @@
+package emailVerification
+
+import (
+	"errors"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(token *EmailVerificationToken) error
+	FindByTokenHash(tokenHash string) (*EmailVerificationToken, error)
+	DeleteByUserID(userID uint) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(token *EmailVerificationToken) error {
+	return database.DB.Create(token).Error
+}
+
+func (r *repository) FindByTokenHash(tokenHash string) (*EmailVerificationToken, error) {
+	var token EmailVerificationToken
+	err := database.DB.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *repository) DeleteByUserID(userID uint) error {
+	return database.DB.Where("user_id = ?", userID).Delete(&EmailVerificationToken{}).Error
+}
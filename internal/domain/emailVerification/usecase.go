@@ -0,0 +1,130 @@
+package emailVerification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/pkg/mailer"
+	"hrm-app/internal/pkg/utils"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired verification token")
+
+// UserRepository is the subset of user.Repository needed to verify emails
+type UserRepository interface {
+	FindByEmail(email string) (*user.User, error)
+	MarkEmailVerified(id uint) error
+}
+
+// LanguageResolver returns the language a user reads emails in (implemented by settings.UseCase)
+type LanguageResolver interface {
+	Language(userID uint) string
+}
+
+// InvitationAcceptor joins a user to the workspaces their email was invited to (implemented by invitation.UseCase)
+type InvitationAcceptor interface {
+	AcceptPending(userID uint) error
+}
+
+type UseCase interface {
+	SendVerification(ctx context.Context, u *user.User) error
+	Resend(ctx context.Context, email string) error
+	Verify(ctx context.Context, token string) error
+}
+
+type usecase struct {
+	repo        Repository
+	userRepo    UserRepository
+	mailer      mailer.Mailer
+	langs       LanguageResolver
+	invitations InvitationAcceptor
+	cfg         *config.Config
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository, m mailer.Mailer, langs LanguageResolver, invitations InvitationAcceptor, cfg *config.Config) UseCase {
+	return &usecase{
+		repo:        repo,
+		userRepo:    userRepo,
+		mailer:      m,
+		langs:       langs,
+		invitations: invitations,
+		cfg:         cfg,
+	}
+}
+
+// SendVerification replaces any outstanding token of the user and emails a new link
+func (u *usecase) SendVerification(ctx context.Context, target *user.User) error {
+	if err := u.repo.DeleteByUserID(target.ID); err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	ttl := u.cfg.Auth.VerificationTokenTTLMinutes
+	if ttl == 0 {
+		ttl = 1440
+	}
+
+	verificationToken := &EmailVerificationToken{
+		UserID:    target.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(ttl) * time.Minute),
+	}
+	if err := u.repo.Create(verificationToken); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/api/v1/verify-email?token=%s", u.cfg.App.BaseURL, token)
+	lang := u.langs.Language(target.ID)
+	return u.mailer.Send(ctx, mailer.Message{
+		To:      target.Email,
+		Subject: i18n.T(lang, "email.verification.subject"),
+		Body:    i18n.T(lang, "email.verification.body", target.Username, verificationToken.ExpiresAt.Format(time.RFC1123), link),
+	})
+}
+
+// Resend emails a new link. Unknown and already verified emails are ignored silently.
+func (u *usecase) Resend(ctx context.Context, email string) error {
+	existing, err := u.userRepo.FindByEmail(email)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.ID == 0 || existing.EmailVerifiedAt != nil {
+		return nil
+	}
+	return u.SendVerification(ctx, existing)
+}
+
+func (u *usecase) Verify(ctx context.Context, token string) error {
+	verificationToken, err := u.repo.FindByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if verificationToken == nil || time.Now().After(verificationToken.ExpiresAt) {
+		return ErrInvalidToken
+	}
+
+	if err := u.userRepo.MarkEmailVerified(verificationToken.UserID); err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteByUserID(verificationToken.UserID); err != nil {
+		return err
+	}
+
+	// Invitations are only honored for a proven address. The email is
+	// verified either way, so a failure here is only logged.
+	if err := u.invitations.AcceptPending(verificationToken.UserID); err != nil {
+		log.Printf("[EmailVerification] Failed to accept invitations for UserID=%d: %v", verificationToken.UserID, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package emailVerification
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/pkg/mailer"
+)
+
+type mockRepository struct {
+	tokens map[string]*EmailVerificationToken
+}
+
+func (m *mockRepository) Create(token *EmailVerificationToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockRepository) FindByTokenHash(tokenHash string) (*EmailVerificationToken, error) {
+	return m.tokens[tokenHash], nil
+}
+
+func (m *mockRepository) DeleteByUserID(userID uint) error {
+	for hash, t := range m.tokens {
+		if t.UserID == userID {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
+
+type englishOnly struct{}
+
+func (englishOnly) Language(userID uint) string {
+	return i18n.English
+}
+
+// mockInvitations records the users whose pending invitations were accepted
+type mockInvitations struct {
+	accepted []uint
+}
+
+func (m *mockInvitations) AcceptPending(userID uint) error {
+	m.accepted = append(m.accepted, userID)
+	return nil
+}
+
+type mockUserRepository struct {
+	users map[string]*user.User
+}
+
+func (m *mockUserRepository) FindByEmail(email string) (*user.User, error) {
+	return m.users[email], nil
+}
+
+func (m *mockUserRepository) MarkEmailVerified(id uint) error {
+	for _, u := range m.users {
+		if u.ID == id {
+			now := time.Now()
+			u.EmailVerifiedAt = &now
+		}
+	}
+	return nil
+}
+
+func TestVerify(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.BaseURL = "http://api.test"
+
+	jane := &user.User{ID: 3, Email: "jane@example.com", Username: "jane"}
+	userRepo := &mockUserRepository{users: map[string]*user.User{jane.Email: jane}}
+	mail := mailer.NewMemoryMailer()
+	invitations := &mockInvitations{}
+	uc := NewUseCase(&mockRepository{tokens: map[string]*EmailVerificationToken{}}, userRepo, mail, englishOnly{}, invitations, cfg)
+	ctx := context.Background()
+
+	if err := uc.SendVerification(ctx, jane); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	body := mail.Sent()[0].Body
+	start := strings.Index(body, "token=")
+	if start < 0 {
+		t.Fatalf("verification link not found in email body: %q", body)
+	}
+	token := strings.Fields(body[start+len("token="):])[0]
+
+	if err := uc.Verify(ctx, "bogus"); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for unknown token, got %v", err)
+	}
+	if len(invitations.accepted) != 0 {
+		t.Errorf("expected no invitations accepted before verification, got %v", invitations.accepted)
+	}
+
+	if err := uc.Verify(ctx, token); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if jane.EmailVerifiedAt == nil {
+		t.Errorf("expected email to be verified")
+	}
+	if len(invitations.accepted) != 1 || invitations.accepted[0] != jane.ID {
+		t.Errorf("expected pending invitations accepted for user %d, got %v", jane.ID, invitations.accepted)
+	}
+
+	if err := uc.Verify(ctx, token); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken on reuse, got %v", err)
+	}
+
+	if err := uc.Resend(ctx, jane.Email); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(mail.Sent()) != 1 {
+		t.Errorf("expected no email for a verified account, got %d", len(mail.Sent()))
+	}
+}
@@ -0,0 +1,52 @@
+package invitation
+
+import (
+	"time"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+// Invitation states
+const (
+	StatusPending  = "pending"
+	StatusAccepted = "accepted"
+	StatusDeclined = "declined"
+	StatusExpired  = "expired"
+	StatusRevoked  = "revoked"
+)
+
+// Invitation asks an email address, registered or not, to join a workspace
+type Invitation struct {
+	ID            uint        `json:"id" gorm:"primaryKey"`
+	WorkspaceID   uint        `json:"workspace_id"`
+	WorkspaceName string      `json:"workspace_name,omitempty" gorm:"->"`
+	Email         string      `json:"email"`
+	Role          policy.Role `json:"role"`
+	Status        string      `json:"status"`
+	TokenHash     string      `json:"-"`
+	InvitedBy     *uint       `json:"invited_by,omitempty"`
+	AcceptedBy    *uint       `json:"accepted_by,omitempty"`
+	ExpiresAt     time.Time   `json:"expires_at"`
+	RespondedAt   *time.Time  `json:"responded_at,omitempty"`
+	LastSentAt    time.Time   `json:"last_sent_at"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+func (Invitation) TableName() string {
+	return "workspace_invitations"
+}
+
+// Expired reports whether a pending invitation has run past its expiry
+func (i *Invitation) Expired(now time.Time) bool {
+	return i.Status == StatusPending && now.After(i.ExpiresAt)
+}
+
+type CreateRequest struct {
+	Email string      `json:"email" binding:"required,email"`
+	Role  policy.Role `json:"role"`
+}
+
+type TokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,208 @@
+package invitation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Auditor records invitation changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
+type Handler struct {
+	usecase UseCase
+	audit   Auditor
+}
+
+func NewHandler(u UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: u, audit: auditor}
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	workspaceID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	invitation, err := h.usecase.Invite(c.Request.Context(), workspaceID, userID.(uint), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, "invite_workspace_user", invitation)
+	response.Success(c, invitation)
+}
+
+func (h *Handler) GetByWorkspaceID(c *gin.Context) {
+	workspaceID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	invitations, err := h.usecase.List(workspaceID, userID.(uint), c.Query("status"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, invitations)
+}
+
+func (h *Handler) GetMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	invitations, err := h.usecase.Mine(userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, invitations)
+}
+
+func (h *Handler) Resend(c *gin.Context) {
+	h.manage(c, "resend_workspace_invitation", func(id, userID uint) (*Invitation, error) {
+		return h.usecase.Resend(c.Request.Context(), id, userID)
+	})
+}
+
+func (h *Handler) Revoke(c *gin.Context) {
+	h.manage(c, "revoke_workspace_invitation", h.usecase.Revoke)
+}
+
+func (h *Handler) Accept(c *gin.Context) {
+	h.manage(c, "accept_workspace_invitation", h.usecase.Accept)
+}
+
+func (h *Handler) Decline(c *gin.Context) {
+	h.manage(c, "decline_workspace_invitation", h.usecase.Decline)
+}
+
+func (h *Handler) AcceptToken(c *gin.Context) {
+	var req TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	invitation, err := h.usecase.AcceptToken(req.Token, userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, "accept_workspace_invitation", invitation)
+	response.Success(c, invitation)
+}
+
+// DeclineToken is public, so the link in the email works without logging in
+func (h *Handler) DeclineToken(c *gin.Context) {
+	var req TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invitation, err := h.usecase.DeclineToken(req.Token)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, "decline_workspace_invitation", invitation)
+	response.Success(c, gin.H{"message": response.Message(c, "Invitation declined")})
+}
+
+// manage runs an action on the invitation in the :id param on behalf of the caller
+func (h *Handler) manage(c *gin.Context, action string, run func(id, userID uint) (*Invitation, error)) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	invitation, err := run(id, userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, action, invitation)
+	response.Success(c, invitation)
+}
+
+// record audits the invitation in the state the action left it in
+func (h *Handler) record(c *gin.Context, action string, invitation *Invitation) {
+	h.audit.Record(audit.FromRequest(c, action, audit.EntityWorkspaceInvitation, invitation.ID).
+		WithWorkspace(invitation.WorkspaceID).
+		WithChanges(nil, invitation))
+}
+
+func idParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case policy.IsForbidden(err), errors.Is(err, ErrEmailMismatch), errors.Is(err, ErrEmailNotVerified):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrWorkspaceNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrNotPending), errors.Is(err, ErrAlreadyMember), errors.Is(err, ErrAlreadyInvited):
+		response.Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrExpired):
+		response.Error(c, http.StatusGone, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
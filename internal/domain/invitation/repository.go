@@ -0,0 +1,149 @@
+package invitation
+
+import (
+	"errors"
+	"time"
+
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(invitation *Invitation) error
+	FindByID(id uint) (*Invitation, error)
+	FindByTokenHash(tokenHash string) (*Invitation, error)
+	FindPending(workspaceID uint, email string) (*Invitation, error)
+	FindByWorkspaceID(workspaceID uint, status string) ([]Invitation, error)
+	FindPendingByEmail(email string) ([]Invitation, error)
+	Update(invitation *Invitation) error
+	ExpireOverdue() (int64, error)
+	IsMember(workspaceID uint, email string) (bool, error)
+	Accept(invitation *Invitation, userID uint) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(invitation *Invitation) error {
+	return database.DB.Create(invitation).Error
+}
+
+func (r *repository) FindByID(id uint) (*Invitation, error) {
+	return first(database.DB.Where("workspace_invitations.id = ?", id))
+}
+
+func (r *repository) FindByTokenHash(tokenHash string) (*Invitation, error) {
+	return first(database.DB.Where("workspace_invitations.token_hash = ?", tokenHash))
+}
+
+func (r *repository) FindPending(workspaceID uint, email string) (*Invitation, error) {
+	return first(database.DB.Where("workspace_invitations.workspace_id = ? AND LOWER(workspace_invitations.email) = LOWER(?) AND workspace_invitations.status = ?", workspaceID, email, StatusPending))
+}
+
+func (r *repository) FindByWorkspaceID(workspaceID uint, status string) ([]Invitation, error) {
+	db := withWorkspaceName().Where("workspace_invitations.workspace_id = ?", workspaceID)
+	if status != "" {
+		db = db.Where("workspace_invitations.status = ?", status)
+	}
+
+	var invitations []Invitation
+	err := db.Order("workspace_invitations.created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *repository) FindPendingByEmail(email string) ([]Invitation, error) {
+	var invitations []Invitation
+	err := withWorkspaceName().
+		Where("LOWER(workspace_invitations.email) = LOWER(?) AND workspace_invitations.status = ? AND workspace_invitations.expires_at > ?", email, StatusPending, time.Now()).
+		Order("workspace_invitations.created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *repository) Update(invitation *Invitation) error {
+	return database.DB.Model(&Invitation{ID: invitation.ID}).Select("status", "token_hash", "accepted_by", "expires_at", "responded_at", "last_sent_at").Updates(invitation).Error
+}
+
+// ExpireOverdue moves pending invitations past their expiry to expired
+func (r *repository) ExpireOverdue() (int64, error) {
+	result := database.DB.Model(&Invitation{}).
+		Where("status = ? AND expires_at <= ?", StatusPending, time.Now()).
+		Update("status", StatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+func (r *repository) IsMember(workspaceID uint, email string) (bool, error) {
+	var count int64
+	err := database.DB.Table("workspaces_users").
+		Joins("JOIN users ON users.id = workspaces_users.user_id").
+		Where("workspaces_users.workspace_id = ? AND LOWER(users.email) = LOWER(?) AND users.deleted_at IS NULL", workspaceID, email).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Accept adds the user to the workspace and marks the invitation accepted in one transaction.
+// A user who is already a member keeps their current role.
+func (r *repository) Accept(invitation *Invitation, userID uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&workspacesUsers.WorkspacesUsers{}).
+			Where("workspace_id = ? AND user_id = ?", invitation.WorkspaceID, userID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing == 0 {
+			member := &workspacesUsers.WorkspacesUsers{
+				WorkspaceID: invitation.WorkspaceID,
+				UserID:      userID,
+				Role:        invitation.Role,
+			}
+			if err := tx.Create(member).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		result := tx.Model(&Invitation{}).
+			Where("id = ? AND status = ?", invitation.ID, StatusPending).
+			Updates(map[string]interface{}{
+				"status":       StatusAccepted,
+				"accepted_by":  userID,
+				"responded_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		// Someone answered or revoked it concurrently
+		if result.RowsAffected == 0 {
+			return ErrNotPending
+		}
+
+		invitation.Status = StatusAccepted
+		invitation.AcceptedBy = &userID
+		invitation.RespondedAt = &now
+		return nil
+	})
+}
+
+func withWorkspaceName() *gorm.DB {
+	return database.DB.Model(&Invitation{}).
+		Select("workspace_invitations.*, workspaces.name AS workspace_name").
+		Joins("JOIN workspaces ON workspaces.id = workspace_invitations.workspace_id")
+}
+
+func first(db *gorm.DB) (*Invitation, error) {
+	var invitation Invitation
+	err := db.First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
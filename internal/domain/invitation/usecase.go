@@ -0,0 +1,401 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/pkg/mailer"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/pkg/utils"
+)
+
+var (
+	ErrNotFound          = errors.New("invitation not found")
+	ErrNotPending        = errors.New("invitation is no longer pending")
+	ErrExpired           = errors.New("invitation has expired")
+	ErrEmailMismatch     = errors.New("invitation was sent to a different email address")
+	ErrEmailNotVerified  = errors.New("verify your email address before accepting invitations")
+	ErrAlreadyMember     = errors.New("user is already a member of this workspace")
+	ErrAlreadyInvited    = errors.New("an invitation to this email is already pending, resend it instead")
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+)
+
+// UserRepository is the subset of user.Repository needed to match invitees
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+	FindByEmail(email string) (*user.User, error)
+}
+
+// WorkspaceRepository resolves the invited workspace (implemented by workspaces.NewRepositoryAdapter)
+type WorkspaceRepository interface {
+	FindByID(id uint) (*workspacesUsers.WorkspaceInfo, error)
+}
+
+// LanguageResolver returns the language a user reads emails in (implemented by settings.UseCase)
+type LanguageResolver interface {
+	Language(userID uint) string
+}
+
+type UseCase interface {
+	Invite(ctx context.Context, workspaceID, inviterID uint, req CreateRequest) (*Invitation, error)
+	List(workspaceID, userID uint, status string) ([]Invitation, error)
+	Resend(ctx context.Context, id, userID uint) (*Invitation, error)
+	Revoke(id, userID uint) (*Invitation, error)
+	Mine(userID uint) ([]Invitation, error)
+	Accept(id, userID uint) (*Invitation, error)
+	AcceptToken(token string, userID uint) (*Invitation, error)
+	Decline(id, userID uint) (*Invitation, error)
+	DeclineToken(token string) (*Invitation, error)
+	AcceptPending(userID uint) error
+}
+
+type usecase struct {
+	repo          Repository
+	userRepo      UserRepository
+	workspaceRepo WorkspaceRepository
+	authz         policy.Authorizer
+	mailer        mailer.Mailer
+	langs         LanguageResolver
+	cfg           *config.Config
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository, workspaceRepo WorkspaceRepository, authz policy.Authorizer, m mailer.Mailer, langs LanguageResolver, cfg *config.Config) UseCase {
+	return &usecase{
+		repo:          repo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		authz:         authz,
+		mailer:        m,
+		langs:         langs,
+		cfg:           cfg,
+	}
+}
+
+// Invite emails a join link to an address. Admins and owners may invite, and
+// only with a role below their own, like workspacesUsers.Create.
+func (u *usecase) Invite(ctx context.Context, workspaceID, inviterID uint, req CreateRequest) (*Invitation, error) {
+	workspace, err := u.workspaceRepo.FindByID(workspaceID)
+	if err != nil || workspace == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+
+	if req.Role == "" {
+		req.Role = policy.RoleMember
+	}
+	actorRole, err := u.authz.WorkspaceRole(workspaceID, inviterID)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanAssignRole(actorRole, "", req.Role) {
+		return nil, policy.ErrForbidden
+	}
+	if err := u.authz.CheckWritable(workspaceID); err != nil {
+		return nil, err
+	}
+
+	email := normalizeEmail(req.Email)
+	member, err := u.repo.IsMember(workspaceID, email)
+	if err != nil {
+		return nil, err
+	}
+	if member {
+		return nil, ErrAlreadyMember
+	}
+
+	if _, err := u.repo.ExpireOverdue(); err != nil {
+		return nil, err
+	}
+	pending, err := u.repo.FindPending(workspaceID, email)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, ErrAlreadyInvited
+	}
+
+	invitation := &Invitation{
+		WorkspaceID:   workspaceID,
+		WorkspaceName: workspace.Name,
+		Email:         email,
+		Role:          req.Role,
+		Status:        StatusPending,
+		InvitedBy:     &inviterID,
+	}
+	token, err := u.issueToken(invitation)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Create(invitation); err != nil {
+		return nil, err
+	}
+
+	if err := u.send(ctx, invitation, inviterID, token); err != nil {
+		log.Printf("[Invitation] Failed to email invitation %d: %v", invitation.ID, err)
+	}
+	return invitation, nil
+}
+
+// List returns a workspace's invitations, by default the outstanding ones
+func (u *usecase) List(workspaceID, userID uint, status string) ([]Invitation, error) {
+	if err := u.authz.AuthorizeWorkspace(workspaceID, userID, policy.ActionInvite); err != nil {
+		return nil, err
+	}
+	if _, err := u.repo.ExpireOverdue(); err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		status = StatusPending
+	}
+	if status == "all" {
+		status = ""
+	}
+	return u.repo.FindByWorkspaceID(workspaceID, status)
+}
+
+// Resend emails a fresh link. Expired invitations are reopened with a new expiry,
+// and the previous link stops working.
+func (u *usecase) Resend(ctx context.Context, id, userID uint) (*Invitation, error) {
+	invitation, err := u.manageable(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != StatusPending && invitation.Status != StatusExpired {
+		return nil, ErrNotPending
+	}
+
+	workspace, err := u.workspaceRepo.FindByID(invitation.WorkspaceID)
+	if err != nil || workspace == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	invitation.WorkspaceName = workspace.Name
+
+	invitation.Status = StatusPending
+	token, err := u.issueToken(invitation)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Update(invitation); err != nil {
+		return nil, err
+	}
+
+	if err := u.send(ctx, invitation, userID, token); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (u *usecase) Revoke(id, userID uint) (*Invitation, error) {
+	invitation, err := u.manageable(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != StatusPending {
+		return nil, ErrNotPending
+	}
+
+	now := time.Now()
+	invitation.Status = StatusRevoked
+	invitation.RespondedAt = &now
+	if err := u.repo.Update(invitation); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// Mine lists the pending invitations addressed to the caller's email
+func (u *usecase) Mine(userID uint) ([]Invitation, error) {
+	invitee, err := u.userRepo.FindByID(userID)
+	if err != nil || invitee == nil {
+		return nil, errors.New("user not found")
+	}
+	return u.repo.FindPendingByEmail(invitee.Email)
+}
+
+func (u *usecase) Accept(id, userID uint) (*Invitation, error) {
+	invitation, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.accept(invitation, userID)
+}
+
+func (u *usecase) AcceptToken(token string, userID uint) (*Invitation, error) {
+	invitation, err := u.repo.FindByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	return u.accept(invitation, userID)
+}
+
+func (u *usecase) Decline(id, userID uint) (*Invitation, error) {
+	invitation, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if invitation != nil {
+		invitee, err := u.userRepo.FindByID(userID)
+		if err != nil || invitee == nil || !strings.EqualFold(invitee.Email, invitation.Email) {
+			return nil, ErrEmailMismatch
+		}
+	}
+	return u.decline(invitation)
+}
+
+// DeclineToken needs no login: holding the emailed link is enough to say no
+func (u *usecase) DeclineToken(token string) (*Invitation, error) {
+	invitation, err := u.repo.FindByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	return u.decline(invitation)
+}
+
+// AcceptPending joins a user whose email was just verified to every workspace
+// it was invited to. Failures are logged per invitation so one bad row does
+// not block the others.
+func (u *usecase) AcceptPending(userID uint) error {
+	invitee, err := u.userRepo.FindByID(userID)
+	if err != nil || invitee == nil {
+		return errors.New("user not found")
+	}
+	if invitee.EmailVerifiedAt == nil {
+		return ErrEmailNotVerified
+	}
+
+	invitations, err := u.repo.FindPendingByEmail(invitee.Email)
+	if err != nil {
+		return err
+	}
+	for i := range invitations {
+		if err := u.authz.CheckWritable(invitations[i].WorkspaceID); err != nil {
+			log.Printf("[Invitation] Skipped invitation %d for UserID=%d: %v", invitations[i].ID, userID, err)
+			continue
+		}
+		if err := u.repo.Accept(&invitations[i], userID); err != nil {
+			log.Printf("[Invitation] Failed to auto-accept invitation %d for UserID=%d: %v", invitations[i].ID, userID, err)
+		}
+	}
+	return nil
+}
+
+func (u *usecase) accept(invitation *Invitation, userID uint) (*Invitation, error) {
+	if err := checkPending(invitation); err != nil {
+		return nil, err
+	}
+
+	// Links can be forwarded, so only the addressee may use one
+	invitee, err := u.userRepo.FindByID(userID)
+	if err != nil || invitee == nil || !strings.EqualFold(invitee.Email, invitation.Email) {
+		return nil, ErrEmailMismatch
+	}
+	// Anyone can register with an address, so it must be proven first
+	if invitee.EmailVerifiedAt == nil {
+		return nil, ErrEmailNotVerified
+	}
+	if err := u.authz.CheckWritable(invitation.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Accept(invitation, userID); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (u *usecase) decline(invitation *Invitation) (*Invitation, error) {
+	if err := checkPending(invitation); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation.Status = StatusDeclined
+	invitation.RespondedAt = &now
+	if err := u.repo.Update(invitation); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// manageable loads an invitation the caller may resend or revoke
+func (u *usecase) manageable(id, userID uint) (*Invitation, error) {
+	invitation, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, ErrNotFound
+	}
+	if err := u.authz.AuthorizeWorkspace(invitation.WorkspaceID, userID, policy.ActionInvite); err != nil {
+		return nil, err
+	}
+	if invitation.Expired(time.Now()) {
+		invitation.Status = StatusExpired
+	}
+	return invitation, nil
+}
+
+// issueToken sets a new token hash and expiry and returns the plain token for the email
+func (u *usecase) issueToken(invitation *Invitation) (string, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	ttl := u.cfg.Auth.InvitationTTLDays
+	if ttl == 0 {
+		ttl = 7
+	}
+
+	now := time.Now()
+	invitation.TokenHash = utils.HashToken(token)
+	invitation.ExpiresAt = now.Add(time.Duration(ttl) * 24 * time.Hour)
+	invitation.LastSentAt = now
+	return token, nil
+}
+
+// send emails the link in the invitee's language, or the inviter's when the
+// address has no account yet
+func (u *usecase) send(ctx context.Context, invitation *Invitation, inviterID uint, token string) error {
+	lang := u.langs.Language(inviterID)
+	if invitee, err := u.userRepo.FindByEmail(invitation.Email); err == nil && invitee != nil && invitee.ID != 0 {
+		lang = u.langs.Language(invitee.ID)
+	}
+
+	inviterName := "A teammate"
+	if inviter, err := u.userRepo.FindByID(inviterID); err == nil && inviter != nil {
+		inviterName = inviter.Username
+	}
+
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", u.cfg.App.FrontendURL, token)
+	return u.mailer.Send(ctx, mailer.Message{
+		To:      invitation.Email,
+		Subject: i18n.T(lang, "email.invitation.subject", invitation.WorkspaceName),
+		Body:    i18n.T(lang, "email.invitation.body", inviterName, invitation.WorkspaceName, invitation.Role, link, invitation.ExpiresAt.Format(time.RFC1123)),
+	})
+}
+
+func checkPending(invitation *Invitation) error {
+	if invitation == nil {
+		return ErrNotFound
+	}
+	if invitation.Expired(time.Now()) {
+		return ErrExpired
+	}
+	if invitation.Status != StatusPending {
+		return ErrNotPending
+	}
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
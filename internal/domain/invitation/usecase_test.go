@@ -0,0 +1,333 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/mailer"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/pkg/utils"
+)
+
+// mockRepository keeps invitations in memory, keyed by ID
+type mockRepository struct {
+	invitations map[uint]*Invitation
+	members     map[string]bool
+	accepted    []uint
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{invitations: map[uint]*Invitation{}, members: map[string]bool{}}
+}
+
+func (m *mockRepository) Create(invitation *Invitation) error {
+	invitation.ID = uint(len(m.invitations) + 1)
+	m.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (m *mockRepository) FindByID(id uint) (*Invitation, error) {
+	return m.invitations[id], nil
+}
+
+func (m *mockRepository) FindByTokenHash(tokenHash string) (*Invitation, error) {
+	for _, inv := range m.invitations {
+		if inv.TokenHash == tokenHash {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) FindPending(workspaceID uint, email string) (*Invitation, error) {
+	for _, inv := range m.invitations {
+		if inv.WorkspaceID == workspaceID && inv.Email == email && inv.Status == StatusPending {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) FindByWorkspaceID(workspaceID uint, status string) ([]Invitation, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) FindPendingByEmail(email string) ([]Invitation, error) {
+	var out []Invitation
+	for _, inv := range m.invitations {
+		if inv.Email == email && inv.Status == StatusPending {
+			out = append(out, *inv)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepository) Update(invitation *Invitation) error {
+	m.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (m *mockRepository) ExpireOverdue() (int64, error) {
+	return 0, nil
+}
+
+func (m *mockRepository) IsMember(workspaceID uint, email string) (bool, error) {
+	return m.members[email], nil
+}
+
+func (m *mockRepository) Accept(invitation *Invitation, userID uint) error {
+	invitation.Status = StatusAccepted
+	invitation.AcceptedBy = &userID
+	m.accepted = append(m.accepted, invitation.ID)
+	return nil
+}
+
+type mockUserRepository struct {
+	users map[uint]*user.User
+}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	return m.users[id], nil
+}
+
+func (m *mockUserRepository) FindByEmail(email string) (*user.User, error) {
+	for _, u := range m.users {
+		if strings.EqualFold(u.Email, email) {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+type mockWorkspaceRepository struct{}
+
+func (m *mockWorkspaceRepository) FindByID(id uint) (*workspacesUsers.WorkspaceInfo, error) {
+	return &workspacesUsers.WorkspaceInfo{ID: id, Name: "Acme"}, nil
+}
+
+type mockLanguageResolver struct{}
+
+func (m *mockLanguageResolver) Language(userID uint) string {
+	return "en"
+}
+
+// mockPolicyRepository resolves workspace roles from an in-memory map keyed by user ID
+type mockPolicyRepository struct {
+	workspaceRoles map[uint]policy.Role
+}
+
+func (m *mockPolicyRepository) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	return m.workspaceRoles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	return "", nil
+}
+
+func (m *mockPolicyRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 0, nil
+}
+
+func (m *mockPolicyRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return false, nil
+}
+
+const (
+	ownerID      uint = 1
+	adminID      uint = 2
+	inviteeID    uint = 3
+	newcomerID   uint = 4
+	unverifiedID uint = 5
+)
+
+func newTestUseCase(repo *mockRepository, m mailer.Mailer) UseCase {
+	verified := time.Now()
+	users := &mockUserRepository{users: map[uint]*user.User{
+		ownerID:      {ID: ownerID, Username: "owner", Email: "owner@example.com", EmailVerifiedAt: &verified},
+		adminID:      {ID: adminID, Username: "admin", Email: "admin@example.com", EmailVerifiedAt: &verified},
+		inviteeID:    {ID: inviteeID, Username: "invitee", Email: "invitee@example.com", EmailVerifiedAt: &verified},
+		newcomerID:   {ID: newcomerID, Username: "newcomer", Email: "newcomer@example.com", EmailVerifiedAt: &verified},
+		unverifiedID: {ID: unverifiedID, Username: "squatter", Email: "pending@example.com"},
+	}}
+	authz := policy.New(&mockPolicyRepository{workspaceRoles: map[uint]policy.Role{
+		ownerID: policy.RoleOwner,
+		adminID: policy.RoleAdmin,
+	}})
+	cfg := &config.Config{}
+	cfg.App.FrontendURL = "http://app.test"
+	return NewUseCase(repo, users, &mockWorkspaceRepository{}, authz, m, &mockLanguageResolver{}, cfg)
+}
+
+func TestUseCase_Invite(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID uint
+		email   string
+		role    policy.Role
+		member  bool
+		wantErr error
+	}{
+		{name: "owner invites admin", actorID: ownerID, email: "new@example.com", role: policy.RoleAdmin},
+		{name: "role defaults to member", actorID: adminID, email: "new@example.com"},
+		{name: "admin cannot invite admin", actorID: adminID, email: "new@example.com", role: policy.RoleAdmin, wantErr: policy.ErrForbidden},
+		{name: "outsider cannot invite", actorID: inviteeID, email: "new@example.com", wantErr: policy.ErrForbidden},
+		{name: "existing member", actorID: ownerID, email: "new@example.com", member: true, wantErr: ErrAlreadyMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			repo.members["new@example.com"] = tt.member
+			m := mailer.NewMemoryMailer()
+			uc := newTestUseCase(repo, m)
+
+			inv, err := uc.Invite(context.Background(), 10, tt.actorID, CreateRequest{Email: " New@Example.com ", Role: tt.role})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Invite() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(m.Sent()) != 0 {
+					t.Error("Invite() sent an email on failure")
+				}
+				return
+			}
+
+			if inv.Email != "new@example.com" || inv.Status != StatusPending {
+				t.Errorf("Invite() = %q/%q, want normalized pending invitation", inv.Email, inv.Status)
+			}
+			if tt.role == "" && inv.Role != policy.RoleMember {
+				t.Errorf("Invite() role = %q, want %q", inv.Role, policy.RoleMember)
+			}
+			if !inv.ExpiresAt.After(time.Now().Add(6 * 24 * time.Hour)) {
+				t.Errorf("Invite() expires at %v, want the default 7 day TTL", inv.ExpiresAt)
+			}
+			sent := m.Sent()
+			if len(sent) != 1 || sent[0].To != "new@example.com" || !strings.Contains(sent[0].Body, "http://app.test/invitations/accept?token=") {
+				t.Errorf("Invite() sent %+v, want one email with an accept link", sent)
+			}
+		})
+	}
+}
+
+func TestUseCase_Invite_RejectsDuplicatePending(t *testing.T) {
+	repo := newMockRepository()
+	uc := newTestUseCase(repo, mailer.NewMemoryMailer())
+
+	if _, err := uc.Invite(context.Background(), 10, ownerID, CreateRequest{Email: "new@example.com"}); err != nil {
+		t.Fatalf("first Invite() error = %v", err)
+	}
+	if _, err := uc.Invite(context.Background(), 10, ownerID, CreateRequest{Email: "NEW@example.com"}); !errors.Is(err, ErrAlreadyInvited) {
+		t.Errorf("second Invite() error = %v, want %v", err, ErrAlreadyInvited)
+	}
+}
+
+func TestUseCase_Accept(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		status     string
+		expiresIn  time.Duration
+		userID     uint
+		wantErr    error
+		wantStatus string
+	}{
+		{name: "addressee accepts", email: "invitee@example.com", status: StatusPending, expiresIn: time.Hour, userID: inviteeID, wantStatus: StatusAccepted},
+		{name: "different user", email: "invitee@example.com", status: StatusPending, expiresIn: time.Hour, userID: adminID, wantErr: ErrEmailMismatch},
+		{name: "unverified addressee", email: "pending@example.com", status: StatusPending, expiresIn: time.Hour, userID: unverifiedID, wantErr: ErrEmailNotVerified},
+		{name: "expired", email: "invitee@example.com", status: StatusPending, expiresIn: -time.Hour, userID: inviteeID, wantErr: ErrExpired},
+		{name: "revoked", email: "invitee@example.com", status: StatusRevoked, expiresIn: time.Hour, userID: inviteeID, wantErr: ErrNotPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			repo.Create(&Invitation{
+				WorkspaceID: 10,
+				Email:       tt.email,
+				Role:        policy.RoleMember,
+				Status:      tt.status,
+				TokenHash:   utils.HashToken("secret"),
+				ExpiresAt:   time.Now().Add(tt.expiresIn),
+			})
+			uc := newTestUseCase(repo, mailer.NewMemoryMailer())
+
+			inv, err := uc.AcceptToken("secret", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AcceptToken() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && inv.Status != tt.wantStatus {
+				t.Errorf("AcceptToken() status = %q, want %q", inv.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestUseCase_AcceptToken_UnknownToken(t *testing.T) {
+	uc := newTestUseCase(newMockRepository(), mailer.NewMemoryMailer())
+	if _, err := uc.AcceptToken("missing", inviteeID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AcceptToken() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestUseCase_Revoke_ThenDeclineFails(t *testing.T) {
+	repo := newMockRepository()
+	uc := newTestUseCase(repo, mailer.NewMemoryMailer())
+
+	inv, err := uc.Invite(context.Background(), 10, ownerID, CreateRequest{Email: "invitee@example.com"})
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	if _, err := uc.Revoke(inv.ID, inviteeID); !policy.IsForbidden(err) {
+		t.Errorf("Revoke() by invitee error = %v, want forbidden", err)
+	}
+	if _, err := uc.Revoke(inv.ID, adminID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if _, err := uc.Decline(inv.ID, inviteeID); !errors.Is(err, ErrNotPending) {
+		t.Errorf("Decline() after revoke error = %v, want %v", err, ErrNotPending)
+	}
+}
+
+func TestUseCase_AcceptPending(t *testing.T) {
+	repo := newMockRepository()
+	uc := newTestUseCase(repo, mailer.NewMemoryMailer())
+
+	for _, workspaceID := range []uint{10, 11} {
+		if _, err := uc.Invite(context.Background(), workspaceID, ownerID, CreateRequest{Email: "newcomer@example.com"}); err != nil {
+			t.Fatalf("Invite() error = %v", err)
+		}
+	}
+	if _, err := uc.Invite(context.Background(), 12, ownerID, CreateRequest{Email: "someone@example.com"}); err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+
+	if err := uc.AcceptPending(newcomerID); err != nil {
+		t.Fatalf("AcceptPending() error = %v", err)
+	}
+	if len(repo.accepted) != 2 {
+		t.Errorf("AcceptPending() accepted %d invitations, want 2", len(repo.accepted))
+	}
+}
+
+func TestUseCase_AcceptPending_UnverifiedEmail(t *testing.T) {
+	repo := newMockRepository()
+	uc := newTestUseCase(repo, mailer.NewMemoryMailer())
+
+	if _, err := uc.Invite(context.Background(), 10, ownerID, CreateRequest{Email: "pending@example.com"}); err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+
+	// Someone registered with the invited address but never proved they own it
+	if err := uc.AcceptPending(unverifiedID); !errors.Is(err, ErrEmailNotVerified) {
+		t.Errorf("AcceptPending() error = %v, want %v", err, ErrEmailNotVerified)
+	}
+	if len(repo.accepted) != 0 {
+		t.Errorf("AcceptPending() accepted %d invitations for an unverified email, want 0", len(repo.accepted))
+	}
+}
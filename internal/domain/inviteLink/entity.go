@@ -0,0 +1,83 @@
+package inviteLink
+
+import (
+	"time"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+// What a link grants membership of
+const (
+	TypeWorkspace = "workspace"
+	TypeBoard     = "board"
+)
+
+// Link states, derived from the stored columns
+const (
+	StatusActive    = "active"
+	StatusRevoked   = "revoked"
+	StatusExpired   = "expired"
+	StatusExhausted = "exhausted"
+)
+
+// InviteLink is a shareable, revocable link that joins whoever opens it to a
+// workspace or board. Only the token's hash is stored.
+type InviteLink struct {
+	ID              uint        `json:"id" gorm:"primaryKey"`
+	EntityType      string      `json:"entity_type"`
+	WorkspaceID     uint        `json:"workspace_id"`
+	BoardID         *uint       `json:"board_id,omitempty"`
+	Role            policy.Role `json:"role"`
+	TokenHash       string      `json:"-"`
+	MaxUses         *int        `json:"max_uses"`
+	UseCount        int         `json:"use_count"`
+	EmailDomain     *string     `json:"email_domain"`
+	ExpiresAt       time.Time   `json:"expires_at"`
+	CreatedBy       *uint       `json:"created_by,omitempty"`
+	CreatorUsername string      `json:"creator_username,omitempty" gorm:"->"`
+	RevokedBy       *uint       `json:"revoked_by,omitempty"`
+	RevokedAt       *time.Time  `json:"revoked_at,omitempty"`
+	Status          string      `json:"status" gorm:"-"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+func (InviteLink) TableName() string {
+	return "invite_links"
+}
+
+// State reports whether the link can still be used and, if not, why
+func (l *InviteLink) State(now time.Time) string {
+	switch {
+	case l.RevokedAt != nil:
+		return StatusRevoked
+	case now.After(l.ExpiresAt):
+		return StatusExpired
+	case l.MaxUses != nil && l.UseCount >= *l.MaxUses:
+		return StatusExhausted
+	default:
+		return StatusActive
+	}
+}
+
+// EntityID is the workspace or board the link joins
+func (l *InviteLink) EntityID() uint {
+	if l.BoardID != nil {
+		return *l.BoardID
+	}
+	return l.WorkspaceID
+}
+
+type CreateRequest struct {
+	Role           policy.Role `json:"role"`
+	MaxUses        *int        `json:"max_uses" binding:"omitempty,min=1"`
+	ExpiresInHours int         `json:"expires_in_hours" binding:"omitempty,min=1"`
+	EmailDomain    string      `json:"email_domain"`
+}
+
+// Created is returned once, at creation: the token cannot be recovered later
+type Created struct {
+	*InviteLink
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,149 @@
+package inviteLink
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Auditor records invite link changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
+type Handler struct {
+	usecase UseCase
+	audit   Auditor
+}
+
+func NewHandler(u UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: u, audit: auditor}
+}
+
+func (h *Handler) CreateForWorkspace(c *gin.Context) {
+	h.create(c, h.usecase.CreateForWorkspace)
+}
+
+func (h *Handler) CreateForBoard(c *gin.Context) {
+	h.create(c, h.usecase.CreateForBoard)
+}
+
+func (h *Handler) GetByWorkspaceID(c *gin.Context) {
+	h.list(c, h.usecase.ListForWorkspace)
+}
+
+func (h *Handler) GetByBoardID(c *gin.Context) {
+	h.list(c, h.usecase.ListForBoard)
+}
+
+func (h *Handler) Revoke(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	link, err := h.usecase.Revoke(id, userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, "revoke_invite_link", link)
+	response.Success(c, link)
+}
+
+// create issues a link for the workspace or board in the :id param
+func (h *Handler) create(c *gin.Context, run func(id, userID uint, req CreateRequest) (*Created, error)) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	created, err := run(id, userID.(uint), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, "create_invite_link", created.InviteLink)
+	response.Success(c, created)
+}
+
+// list returns the links of the workspace or board in the :id param
+func (h *Handler) list(c *gin.Context, run func(id, userID uint) ([]InviteLink, error)) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	links, err := run(id, userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, links)
+}
+
+func (h *Handler) record(c *gin.Context, action string, link *InviteLink) {
+	entry := audit.FromRequest(c, action, audit.EntityInviteLink, link.ID).WithWorkspace(link.WorkspaceID)
+	if link.BoardID != nil {
+		entry = entry.WithBoard(*link.BoardID)
+	}
+	h.audit.Record(entry.WithChanges(nil, link))
+}
+
+func idParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case policy.IsForbidden(err):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrWorkspaceNotFound), errors.Is(err, ErrBoardNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvalidDomain):
+		response.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
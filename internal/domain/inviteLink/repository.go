@@ -0,0 +1,103 @@
+package inviteLink
+
+import (
+	"errors"
+	"time"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(link *InviteLink) error
+	FindByID(id uint) (*InviteLink, error)
+	FindByTokenHash(tokenHash string) (*InviteLink, error)
+	FindByWorkspaceID(workspaceID uint) ([]InviteLink, error)
+	FindByBoardID(boardID uint) ([]InviteLink, error)
+	Revoke(link *InviteLink, userID uint) error
+	Consume(id uint) (bool, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(link *InviteLink) error {
+	return database.DB.Create(link).Error
+}
+
+func (r *repository) FindByID(id uint) (*InviteLink, error) {
+	return first(database.DB.Where("invite_links.id = ?", id))
+}
+
+func (r *repository) FindByTokenHash(tokenHash string) (*InviteLink, error) {
+	return first(database.DB.Where("invite_links.token_hash = ?", tokenHash))
+}
+
+// FindByWorkspaceID lists the links to the workspace itself, not to its boards
+func (r *repository) FindByWorkspaceID(workspaceID uint) ([]InviteLink, error) {
+	var links []InviteLink
+	err := withCreator().
+		Where("invite_links.workspace_id = ? AND invite_links.entity_type = ?", workspaceID, TypeWorkspace).
+		Order("invite_links.created_at DESC").
+		Find(&links).Error
+	return links, err
+}
+
+func (r *repository) FindByBoardID(boardID uint) ([]InviteLink, error) {
+	var links []InviteLink
+	err := withCreator().
+		Where("invite_links.board_id = ?", boardID).
+		Order("invite_links.created_at DESC").
+		Find(&links).Error
+	return links, err
+}
+
+func (r *repository) Revoke(link *InviteLink, userID uint) error {
+	now := time.Now()
+	if err := database.DB.Model(&InviteLink{ID: link.ID}).Updates(map[string]interface{}{
+		"revoked_at": now,
+		"revoked_by": userID,
+		"updated_at": now,
+	}).Error; err != nil {
+		return err
+	}
+
+	link.RevokedAt = &now
+	link.RevokedBy = &userID
+	return nil
+}
+
+// Consume counts one use of the link. The check and the increment are a single
+// statement, so concurrent joins cannot push a link past its max uses. It
+// reports false when the link was revoked, expired or used up in the meantime.
+func (r *repository) Consume(id uint) (bool, error) {
+	result := database.DB.Model(&InviteLink{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ? AND (max_uses IS NULL OR use_count < max_uses)", id, time.Now()).
+		Updates(map[string]interface{}{
+			"use_count":  gorm.Expr("use_count + 1"),
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func withCreator() *gorm.DB {
+	return database.DB.Model(&InviteLink{}).
+		Select("invite_links.*, users.username AS creator_username").
+		Joins("LEFT JOIN users ON users.id = invite_links.created_by")
+}
+
+func first(db *gorm.DB) (*InviteLink, error) {
+	var link InviteLink
+	err := db.First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
@@ -0,0 +1,292 @@
+package inviteLink
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/boardsUsers"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/pkg/utils"
+)
+
+var (
+	ErrNotFound          = errors.New("invite link not found")
+	ErrWrongType         = errors.New("invite link is for a different kind of resource")
+	ErrRevoked           = errors.New("invite link has been revoked")
+	ErrExpired           = errors.New("invite link has expired")
+	ErrExhausted         = errors.New("invite link has reached its maximum number of uses")
+	ErrEmailDomain       = errors.New("invite link is restricted to another email domain")
+	ErrEmailNotVerified  = errors.New("verify your email address before using a domain-restricted invite link")
+	ErrInvalidDomain     = errors.New("email_domain must be a domain such as example.com")
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	ErrBoardNotFound     = errors.New("board not found")
+)
+
+// UserRepository is the subset of user.Repository needed to check email domains
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+}
+
+// WorkspaceRepository resolves linked workspaces (implemented by workspaces.NewRepositoryAdapter)
+type WorkspaceRepository interface {
+	FindByID(id uint) (*workspacesUsers.WorkspaceInfo, error)
+}
+
+// BoardRepository resolves linked boards and their workspace (implemented by boards.NewRepositoryAdapter)
+type BoardRepository interface {
+	FindByID(id uint) (*boardsUsers.BoardInfo, error)
+}
+
+type UseCase interface {
+	CreateForWorkspace(workspaceID, userID uint, req CreateRequest) (*Created, error)
+	CreateForBoard(boardID, userID uint, req CreateRequest) (*Created, error)
+	ListForWorkspace(workspaceID, userID uint) ([]InviteLink, error)
+	ListForBoard(boardID, userID uint) ([]InviteLink, error)
+	Revoke(id, userID uint) (*InviteLink, error)
+	Resolve(token, entityType string, userID uint) (*InviteLink, error)
+	Consume(id uint) error
+}
+
+type usecase struct {
+	repo          Repository
+	userRepo      UserRepository
+	workspaceRepo WorkspaceRepository
+	boardRepo     BoardRepository
+	authz         policy.Authorizer
+	cfg           *config.Config
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository, workspaceRepo WorkspaceRepository, boardRepo BoardRepository, authz policy.Authorizer, cfg *config.Config) UseCase {
+	return &usecase{
+		repo:          repo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		boardRepo:     boardRepo,
+		authz:         authz,
+		cfg:           cfg,
+	}
+}
+
+// CreateForWorkspace issues a workspace link. Admins and owners may create
+// links, and only for a role below their own, like workspacesUsers.Create.
+func (u *usecase) CreateForWorkspace(workspaceID, userID uint, req CreateRequest) (*Created, error) {
+	workspace, err := u.workspaceRepo.FindByID(workspaceID)
+	if err != nil || workspace == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+
+	actorRole, err := u.authz.WorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.create(&InviteLink{EntityType: TypeWorkspace, WorkspaceID: workspaceID}, actorRole, userID, req)
+}
+
+// CreateForBoard issues a board link, checked against the caller's effective board role
+func (u *usecase) CreateForBoard(boardID, userID uint, req CreateRequest) (*Created, error) {
+	board, err := u.boardRepo.FindByID(boardID)
+	if err != nil || board == nil {
+		return nil, ErrBoardNotFound
+	}
+
+	actorRole, err := u.authz.BoardRole(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.create(&InviteLink{EntityType: TypeBoard, WorkspaceID: board.WorkspaceID, BoardID: &boardID}, actorRole, userID, req)
+}
+
+func (u *usecase) ListForWorkspace(workspaceID, userID uint) ([]InviteLink, error) {
+	if err := u.authz.AuthorizeWorkspace(workspaceID, userID, policy.ActionInvite); err != nil {
+		return nil, err
+	}
+
+	links, err := u.repo.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return withStatus(links), nil
+}
+
+func (u *usecase) ListForBoard(boardID, userID uint) ([]InviteLink, error) {
+	if err := u.authz.AuthorizeBoard(boardID, userID, policy.ActionInvite); err != nil {
+		return nil, err
+	}
+
+	links, err := u.repo.FindByBoardID(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return withStatus(links), nil
+}
+
+// Revoke stops a link from working. Revoking twice is a no-op.
+func (u *usecase) Revoke(id, userID uint) (*InviteLink, error) {
+	link, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, ErrNotFound
+	}
+
+	if link.BoardID != nil {
+		err = u.authz.AuthorizeBoard(*link.BoardID, userID, policy.ActionInvite)
+	} else {
+		err = u.authz.AuthorizeWorkspace(link.WorkspaceID, userID, policy.ActionInvite)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if link.RevokedAt == nil {
+		if err := u.repo.Revoke(link, userID); err != nil {
+			return nil, err
+		}
+	}
+	link.Status = link.State(time.Now())
+	return link, nil
+}
+
+// Resolve finds the link behind a token and checks that userID may use it to
+// join. It does not count a use: call Consume once the caller is known not to
+// be a member already.
+func (u *usecase) Resolve(token, entityType string, userID uint) (*InviteLink, error) {
+	link, err := u.repo.FindByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, ErrNotFound
+	}
+	if link.EntityType != entityType {
+		return nil, ErrWrongType
+	}
+	if err := stateError(link.State(time.Now())); err != nil {
+		return nil, err
+	}
+	if err := u.authz.CheckWritable(link.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	if link.EmailDomain != nil {
+		joiner, err := u.userRepo.FindByID(userID)
+		if err != nil || joiner == nil {
+			return nil, errors.New("user not found")
+		}
+		if !strings.HasSuffix(strings.ToLower(joiner.Email), "@"+*link.EmailDomain) {
+			return nil, ErrEmailDomain
+		}
+		// Anyone can register an address on the domain, so it must be proven first
+		if joiner.EmailVerifiedAt == nil {
+			return nil, ErrEmailNotVerified
+		}
+	}
+
+	link.Status = StatusActive
+	return link, nil
+}
+
+func (u *usecase) Consume(id uint) error {
+	ok, err := u.repo.Consume(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Lost a race with another join, a revoke or the expiry
+		return ErrExhausted
+	}
+	return nil
+}
+
+func (u *usecase) create(link *InviteLink, actorRole policy.Role, userID uint, req CreateRequest) (*Created, error) {
+	// Board links grant board roles, see policy.CanAssignBoardRole
+	defaultRole, canAssign := policy.RoleMember, policy.CanAssignRole
+	if link.EntityType == TypeBoard {
+		defaultRole, canAssign = policy.RoleEditor, policy.CanAssignBoardRole
+	}
+	if req.Role == "" {
+		req.Role = defaultRole
+	}
+	if !canAssign(actorRole, "", req.Role) {
+		return nil, policy.ErrForbidden
+	}
+	if err := u.authz.CheckWritable(link.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	if req.EmailDomain != "" {
+		domain, err := normalizeDomain(req.EmailDomain)
+		if err != nil {
+			return nil, err
+		}
+		link.EmailDomain = &domain
+	}
+
+	hours := req.ExpiresInHours
+	if hours == 0 {
+		days := u.cfg.Auth.InviteLinkTTLDays
+		if days == 0 {
+			days = 7
+		}
+		hours = days * 24
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	link.Role = req.Role
+	link.MaxUses = req.MaxUses
+	link.TokenHash = utils.HashToken(token)
+	link.ExpiresAt = time.Now().Add(time.Duration(hours) * time.Hour)
+	link.CreatedBy = &userID
+	if err := u.repo.Create(link); err != nil {
+		return nil, err
+	}
+
+	link.Status = StatusActive
+	return &Created{
+		InviteLink: link,
+		Token:      token,
+		URL:        fmt.Sprintf("%s/join/%s?token=%s", u.cfg.App.FrontendURL, link.EntityType, token),
+	}, nil
+}
+
+func stateError(status string) error {
+	switch status {
+	case StatusRevoked:
+		return ErrRevoked
+	case StatusExpired:
+		return ErrExpired
+	case StatusExhausted:
+		return ErrExhausted
+	}
+	return nil
+}
+
+func withStatus(links []InviteLink) []InviteLink {
+	now := time.Now()
+	for i := range links {
+		links[i].Status = links[i].State(now)
+	}
+	return links
+}
+
+// normalizeDomain accepts "example.com" or "@example.com"
+func normalizeDomain(domain string) (string, error) {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@")
+	if domain == "" || strings.ContainsAny(domain, "@ /") || !strings.Contains(domain, ".") ||
+		strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", ErrInvalidDomain
+	}
+	return domain, nil
+}
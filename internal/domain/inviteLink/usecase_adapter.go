@@ -0,0 +1,90 @@
+package inviteLink
+
+import (
+	"errors"
+
+	"hrm-app/internal/domain/boardsUsers"
+	"hrm-app/internal/domain/workspacesUsers"
+)
+
+// workspaceAdapter adapts the UseCase to the workspacesUsers.InviteLinks interface
+type workspaceAdapter struct {
+	usecase UseCase
+}
+
+// NewWorkspaceAdapter creates an adapter that implements workspacesUsers.InviteLinks
+func NewWorkspaceAdapter(usecase UseCase) workspacesUsers.InviteLinks {
+	return &workspaceAdapter{usecase: usecase}
+}
+
+func (a *workspaceAdapter) Resolve(token string, userID uint) (*workspacesUsers.InviteLinkInfo, error) {
+	link, err := a.usecase.Resolve(token, TypeWorkspace, userID)
+	if err != nil {
+		if isLinkError(err) {
+			return nil, &workspacesUsers.InviteLinkError{Err: err}
+		}
+		return nil, err
+	}
+
+	return &workspacesUsers.InviteLinkInfo{
+		ID:          link.ID,
+		WorkspaceID: link.WorkspaceID,
+		Role:        link.Role,
+	}, nil
+}
+
+func (a *workspaceAdapter) Consume(id uint) error {
+	if err := a.usecase.Consume(id); err != nil {
+		if isLinkError(err) {
+			return &workspacesUsers.InviteLinkError{Err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// boardAdapter adapts the UseCase to the boardsUsers.InviteLinks interface
+type boardAdapter struct {
+	usecase UseCase
+}
+
+// NewBoardAdapter creates an adapter that implements boardsUsers.InviteLinks
+func NewBoardAdapter(usecase UseCase) boardsUsers.InviteLinks {
+	return &boardAdapter{usecase: usecase}
+}
+
+func (a *boardAdapter) Resolve(token string, userID uint) (*boardsUsers.InviteLinkInfo, error) {
+	link, err := a.usecase.Resolve(token, TypeBoard, userID)
+	if err != nil {
+		if isLinkError(err) {
+			return nil, &boardsUsers.InviteLinkError{Err: err}
+		}
+		return nil, err
+	}
+
+	return &boardsUsers.InviteLinkInfo{
+		ID:      link.ID,
+		BoardID: link.EntityID(),
+		Role:    link.Role,
+	}, nil
+}
+
+func (a *boardAdapter) Consume(id uint) error {
+	if err := a.usecase.Consume(id); err != nil {
+		if isLinkError(err) {
+			return &boardsUsers.InviteLinkError{Err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// isLinkError tells the caller's mistakes apart from server failures
+func isLinkError(err error) bool {
+	for _, target := range []error{ErrNotFound, ErrWrongType, ErrRevoked, ErrExpired, ErrExhausted, ErrEmailDomain, ErrEmailNotVerified} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
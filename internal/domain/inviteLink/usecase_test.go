@@ -0,0 +1,349 @@
+package inviteLink
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/boardsUsers"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/pkg/utils"
+)
+
+// mockRepository keeps links in memory, keyed by ID
+type mockRepository struct {
+	links map[uint]*InviteLink
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{links: map[uint]*InviteLink{}}
+}
+
+func (m *mockRepository) Create(link *InviteLink) error {
+	link.ID = uint(len(m.links) + 1)
+	m.links[link.ID] = link
+	return nil
+}
+
+func (m *mockRepository) FindByID(id uint) (*InviteLink, error) {
+	return m.links[id], nil
+}
+
+func (m *mockRepository) FindByTokenHash(tokenHash string) (*InviteLink, error) {
+	for _, link := range m.links {
+		if link.TokenHash == tokenHash {
+			return link, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) FindByWorkspaceID(workspaceID uint) ([]InviteLink, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) FindByBoardID(boardID uint) ([]InviteLink, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) Revoke(link *InviteLink, userID uint) error {
+	now := time.Now()
+	link.RevokedAt = &now
+	link.RevokedBy = &userID
+	return nil
+}
+
+func (m *mockRepository) Consume(id uint) (bool, error) {
+	link := m.links[id]
+	if link == nil || link.State(time.Now()) != StatusActive {
+		return false, nil
+	}
+	link.UseCount++
+	return true, nil
+}
+
+type mockUserRepository struct {
+	users map[uint]*user.User
+}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	return m.users[id], nil
+}
+
+type mockWorkspaceRepository struct{}
+
+func (m *mockWorkspaceRepository) FindByID(id uint) (*workspacesUsers.WorkspaceInfo, error) {
+	return &workspacesUsers.WorkspaceInfo{ID: id, Name: "Acme"}, nil
+}
+
+type mockBoardRepository struct{}
+
+func (m *mockBoardRepository) FindByID(id uint) (*boardsUsers.BoardInfo, error) {
+	return &boardsUsers.BoardInfo{ID: id, Name: "Roadmap", WorkspaceID: 10}, nil
+}
+
+// mockPolicyRepository resolves roles from in-memory maps keyed by user ID
+type mockPolicyRepository struct {
+	workspaceRoles map[uint]policy.Role
+	boardRoles     map[uint]policy.Role
+	readOnly       bool
+}
+
+func (m *mockPolicyRepository) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	return m.workspaceRoles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	return m.boardRoles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 10, nil
+}
+
+func (m *mockPolicyRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return m.readOnly, nil
+}
+
+const (
+	ownerID      uint = 1
+	adminID      uint = 2
+	boardAdminID uint = 3
+	joinerID     uint = 4
+	unverifiedID uint = 5
+)
+
+func newTestUseCase(repo *mockRepository) UseCase {
+	return newTestUseCaseInWorkspace(repo, false)
+}
+
+// newTestUseCaseInWorkspace builds the usecase with workspace 10 archived or not
+func newTestUseCaseInWorkspace(repo *mockRepository, readOnly bool) UseCase {
+	verified := time.Now()
+	users := &mockUserRepository{users: map[uint]*user.User{
+		joinerID:     {ID: joinerID, Username: "joiner", Email: "Joiner@Example.com", EmailVerifiedAt: &verified},
+		unverifiedID: {ID: unverifiedID, Username: "squatter", Email: "someone@example.com"},
+	}}
+	authz := policy.New(&mockPolicyRepository{
+		workspaceRoles: map[uint]policy.Role{ownerID: policy.RoleOwner, adminID: policy.RoleAdmin},
+		boardRoles:     map[uint]policy.Role{boardAdminID: policy.RoleAdmin},
+		readOnly:       readOnly,
+	})
+	cfg := &config.Config{}
+	cfg.App.FrontendURL = "http://app.test"
+	return NewUseCase(repo, users, &mockWorkspaceRepository{}, &mockBoardRepository{}, authz, cfg)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func strPtr(v string) *string {
+	return &v
+}
+
+func TestUseCase_CreateForWorkspace(t *testing.T) {
+	tests := []struct {
+		name       string
+		actorID    uint
+		req        CreateRequest
+		wantErr    error
+		wantRole   policy.Role
+		wantDomain string
+	}{
+		{name: "owner grants admin", actorID: ownerID, req: CreateRequest{Role: policy.RoleAdmin}, wantRole: policy.RoleAdmin},
+		{name: "role defaults to member", actorID: adminID, req: CreateRequest{}, wantRole: policy.RoleMember},
+		{name: "admin cannot grant admin", actorID: adminID, req: CreateRequest{Role: policy.RoleAdmin}, wantErr: policy.ErrForbidden},
+		{name: "nobody grants owner", actorID: ownerID, req: CreateRequest{Role: policy.RoleOwner}, wantErr: policy.ErrForbidden},
+		{name: "outsider", actorID: joinerID, req: CreateRequest{}, wantErr: policy.ErrForbidden},
+		{name: "domain is normalized", actorID: ownerID, req: CreateRequest{EmailDomain: " @Example.COM "}, wantRole: policy.RoleMember, wantDomain: "example.com"},
+		{name: "invalid domain", actorID: ownerID, req: CreateRequest{EmailDomain: "user@example.com"}, wantErr: ErrInvalidDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			created, err := newTestUseCase(repo).CreateForWorkspace(10, tt.actorID, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateForWorkspace() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if created.Role != tt.wantRole || created.EntityType != TypeWorkspace {
+				t.Errorf("CreateForWorkspace() = %s/%s, want %s/%s", created.EntityType, created.Role, TypeWorkspace, tt.wantRole)
+			}
+			if tt.wantDomain != "" && (created.EmailDomain == nil || *created.EmailDomain != tt.wantDomain) {
+				t.Errorf("CreateForWorkspace() email domain = %v, want %q", created.EmailDomain, tt.wantDomain)
+			}
+			if created.TokenHash != utils.HashToken(created.Token) {
+				t.Error("CreateForWorkspace() stored a hash that does not match the returned token")
+			}
+			if !strings.HasPrefix(created.URL, "http://app.test/join/workspace?token=") {
+				t.Errorf("CreateForWorkspace() url = %q", created.URL)
+			}
+			if !created.ExpiresAt.After(time.Now().Add(6 * 24 * time.Hour)) {
+				t.Errorf("CreateForWorkspace() expires at %v, want the default 7 day TTL", created.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestUseCase_CreateForBoard_UsesBoardRole(t *testing.T) {
+	repo := newMockRepository()
+	created, err := newTestUseCase(repo).CreateForBoard(7, boardAdminID, CreateRequest{ExpiresInHours: 2})
+	if err != nil {
+		t.Fatalf("CreateForBoard() error = %v", err)
+	}
+	if created.WorkspaceID != 10 || created.EntityID() != 7 {
+		t.Errorf("CreateForBoard() workspace/board = %d/%d, want 10/7", created.WorkspaceID, created.EntityID())
+	}
+	if created.ExpiresAt.After(time.Now().Add(3 * time.Hour)) {
+		t.Errorf("CreateForBoard() expires at %v, want about 2 hours from now", created.ExpiresAt)
+	}
+	if created.Role != policy.RoleEditor {
+		t.Errorf("CreateForBoard() role = %q, want the board default %q", created.Role, policy.RoleEditor)
+	}
+
+	// Board links grant board roles only
+	if _, err := newTestUseCase(repo).CreateForBoard(7, boardAdminID, CreateRequest{Role: policy.RoleMember}); !errors.Is(err, policy.ErrForbidden) {
+		t.Errorf("CreateForBoard() with a workspace role error = %v, want %v", err, policy.ErrForbidden)
+	}
+}
+
+func TestUseCase_Resolve(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name       string
+		link       InviteLink
+		entityType string
+		userID     uint
+		wantErr    error
+	}{
+		{name: "active", link: InviteLink{EntityType: TypeWorkspace}, entityType: TypeWorkspace},
+		{name: "wrong type", link: InviteLink{EntityType: TypeWorkspace}, entityType: TypeBoard, wantErr: ErrWrongType},
+		{name: "revoked", link: InviteLink{EntityType: TypeWorkspace, RevokedAt: &past}, entityType: TypeWorkspace, wantErr: ErrRevoked},
+		{name: "expired", link: InviteLink{EntityType: TypeWorkspace, ExpiresAt: past}, entityType: TypeWorkspace, wantErr: ErrExpired},
+		{name: "used up", link: InviteLink{EntityType: TypeWorkspace, MaxUses: intPtr(2), UseCount: 2}, entityType: TypeWorkspace, wantErr: ErrExhausted},
+		{name: "matching domain", link: InviteLink{EntityType: TypeWorkspace, EmailDomain: strPtr("example.com")}, entityType: TypeWorkspace},
+		{name: "other domain", link: InviteLink{EntityType: TypeWorkspace, EmailDomain: strPtr("acme.io")}, entityType: TypeWorkspace, wantErr: ErrEmailDomain},
+		{name: "matching domain but unverified", link: InviteLink{EntityType: TypeWorkspace, EmailDomain: strPtr("example.com")}, entityType: TypeWorkspace, userID: unverifiedID, wantErr: ErrEmailNotVerified},
+		{name: "unrestricted link needs no verification", link: InviteLink{EntityType: TypeWorkspace}, entityType: TypeWorkspace, userID: unverifiedID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			link := tt.link
+			link.TokenHash = utils.HashToken("secret")
+			if link.ExpiresAt.IsZero() {
+				link.ExpiresAt = time.Now().Add(time.Hour)
+			}
+			repo.Create(&link)
+
+			userID := tt.userID
+			if userID == 0 {
+				userID = joinerID
+			}
+			_, err := newTestUseCase(repo).Resolve("secret", tt.entityType, userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Resolve() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCase_Consume_StopsAtMaxUses(t *testing.T) {
+	repo := newMockRepository()
+	uc := newTestUseCase(repo)
+	created, err := uc.CreateForWorkspace(10, ownerID, CreateRequest{MaxUses: intPtr(1)})
+	if err != nil {
+		t.Fatalf("CreateForWorkspace() error = %v", err)
+	}
+
+	if err := uc.Consume(created.ID); err != nil {
+		t.Fatalf("first Consume() error = %v", err)
+	}
+	if err := uc.Consume(created.ID); !errors.Is(err, ErrExhausted) {
+		t.Errorf("second Consume() error = %v, want %v", err, ErrExhausted)
+	}
+	if _, err := uc.Resolve(created.Token, TypeWorkspace, joinerID); !errors.Is(err, ErrExhausted) {
+		t.Errorf("Resolve() after last use error = %v, want %v", err, ErrExhausted)
+	}
+}
+
+func TestUseCase_Revoke(t *testing.T) {
+	repo := newMockRepository()
+	uc := newTestUseCase(repo)
+	created, err := uc.CreateForBoard(7, boardAdminID, CreateRequest{})
+	if err != nil {
+		t.Fatalf("CreateForBoard() error = %v", err)
+	}
+
+	if _, err := uc.Revoke(created.ID, joinerID); !policy.IsForbidden(err) {
+		t.Errorf("Revoke() by outsider error = %v, want forbidden", err)
+	}
+	link, err := uc.Revoke(created.ID, ownerID)
+	if err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if link.Status != StatusRevoked {
+		t.Errorf("Revoke() status = %q, want %q", link.Status, StatusRevoked)
+	}
+	if _, err := uc.Resolve(created.Token, TypeBoard, joinerID); !errors.Is(err, ErrRevoked) {
+		t.Errorf("Resolve() after revoke error = %v, want %v", err, ErrRevoked)
+	}
+}
+
+func TestAdapters_WrapLinkErrors(t *testing.T) {
+	repo := newMockRepository()
+	uc := newTestUseCase(repo)
+
+	_, err := NewWorkspaceAdapter(uc).Resolve("unknown", joinerID)
+	var workspaceErr *workspacesUsers.InviteLinkError
+	if !errors.As(err, &workspaceErr) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("workspace adapter Resolve() error = %v, want an InviteLinkError wrapping %v", err, ErrNotFound)
+	}
+
+	created, err := uc.CreateForBoard(7, boardAdminID, CreateRequest{Role: policy.RoleCommenter})
+	if err != nil {
+		t.Fatalf("CreateForBoard() error = %v", err)
+	}
+	info, err := NewBoardAdapter(uc).Resolve(created.Token, joinerID)
+	if err != nil {
+		t.Fatalf("board adapter Resolve() error = %v", err)
+	}
+	if info.BoardID != 7 || info.Role != policy.RoleCommenter {
+		t.Errorf("board adapter Resolve() = %+v, want board 7 as commenter", info)
+	}
+
+	_, err = NewWorkspaceAdapter(uc).Resolve(created.Token, joinerID)
+	if !errors.As(err, &workspaceErr) || !errors.Is(err, ErrWrongType) {
+		t.Errorf("workspace adapter Resolve() of a board link error = %v, want %v", err, ErrWrongType)
+	}
+}
+
+func TestUseCase_ReadOnlyWorkspace(t *testing.T) {
+	repo := newMockRepository()
+	created, err := newTestUseCase(repo).CreateForWorkspace(10, ownerID, CreateRequest{})
+	if err != nil {
+		t.Fatalf("CreateForWorkspace() error = %v", err)
+	}
+
+	// Workspace 10 is archived after the link was issued
+	archived := newTestUseCaseInWorkspace(repo, true)
+	if _, err := archived.CreateForWorkspace(10, ownerID, CreateRequest{}); !errors.Is(err, policy.ErrReadOnly) {
+		t.Errorf("CreateForWorkspace() error = %v, want %v", err, policy.ErrReadOnly)
+	}
+	if _, err := archived.CreateForBoard(20, boardAdminID, CreateRequest{}); !errors.Is(err, policy.ErrReadOnly) {
+		t.Errorf("CreateForBoard() error = %v, want %v", err, policy.ErrReadOnly)
+	}
+	if _, err := archived.Resolve(created.Token, TypeWorkspace, joinerID); !errors.Is(err, policy.ErrReadOnly) {
+		t.Errorf("Resolve() error = %v, want %v", err, policy.ErrReadOnly)
+	}
+}
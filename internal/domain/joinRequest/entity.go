@@ -0,0 +1,43 @@
+package joinRequest
+
+import (
+	"time"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+// Request states
+const (
+	StatusPending   = "pending"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+	StatusCancelled = "cancelled"
+)
+
+// JoinRequest asks the admins of a team workspace to let a user in
+type JoinRequest struct {
+	ID            uint        `json:"id" gorm:"primaryKey"`
+	WorkspaceID   uint        `json:"workspace_id"`
+	WorkspaceName string      `json:"workspace_name,omitempty" gorm:"->"`
+	UserID        uint        `json:"user_id"`
+	Username      string      `json:"username,omitempty" gorm:"->"`
+	Message       string      `json:"message,omitempty"`
+	Status        string      `json:"status"`
+	Role          policy.Role `json:"role,omitempty"` // granted on approval
+	ReviewedBy    *uint       `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time  `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+func (JoinRequest) TableName() string {
+	return "workspace_join_requests"
+}
+
+type CreateRequest struct {
+	Message string `json:"message" binding:"max=500"`
+}
+
+type ApproveRequest struct {
+	Role policy.Role `json:"role"`
+}
@@ -0,0 +1,175 @@
+package joinRequest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Auditor records join request changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
+type Handler struct {
+	usecase UseCase
+	audit   Auditor
+}
+
+func NewHandler(u UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: u, audit: auditor}
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	workspaceID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req CreateRequest
+	// The message is optional, so an empty body is fine
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	request, err := h.usecase.Request(workspaceID, userID.(uint), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, "request_join_workspace", request)
+	response.Success(c, request)
+}
+
+func (h *Handler) GetByWorkspaceID(c *gin.Context) {
+	workspaceID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requests, err := h.usecase.List(workspaceID, userID.(uint), c.Query("status"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, requests)
+}
+
+func (h *Handler) GetMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requests, err := h.usecase.Mine(userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, requests)
+}
+
+func (h *Handler) Approve(c *gin.Context) {
+	var req ApproveRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	h.manage(c, "approve_join_request", func(id, userID uint) (*JoinRequest, error) {
+		return h.usecase.Approve(id, userID, req)
+	})
+}
+
+func (h *Handler) Reject(c *gin.Context) {
+	h.manage(c, "reject_join_request", h.usecase.Reject)
+}
+
+func (h *Handler) Cancel(c *gin.Context) {
+	h.manage(c, "cancel_join_request", h.usecase.Cancel)
+}
+
+// manage runs an action on the join request in the :id param on behalf of the caller
+func (h *Handler) manage(c *gin.Context, action string, run func(id, userID uint) (*JoinRequest, error)) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	request, err := run(id, userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.record(c, action, request)
+	response.Success(c, request)
+}
+
+// record audits the join request in the state the action left it in
+func (h *Handler) record(c *gin.Context, action string, request *JoinRequest) {
+	h.audit.Record(audit.FromRequest(c, action, audit.EntityJoinRequest, request.ID).
+		WithWorkspace(request.WorkspaceID).
+		WithChanges(nil, request))
+}
+
+func idParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case policy.IsForbidden(err), errors.Is(err, ErrInviteOnly):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrWorkspaceNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrNotPending), errors.Is(err, ErrAlreadyRequested), errors.Is(err, workspacesUsers.ErrAlreadyMember):
+		response.Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrPublicWorkspace):
+		response.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
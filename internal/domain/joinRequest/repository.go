@@ -0,0 +1,146 @@
+package joinRequest
+
+import (
+	"errors"
+	"time"
+
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(request *JoinRequest) error
+	FindByID(id uint) (*JoinRequest, error)
+	FindPending(workspaceID, userID uint) (*JoinRequest, error)
+	FindByWorkspaceID(workspaceID uint, status string) ([]JoinRequest, error)
+	FindByUserID(userID uint) ([]JoinRequest, error)
+	Close(request *JoinRequest, status string, reviewerID *uint) error
+	Approve(request *JoinRequest, reviewerID uint) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(request *JoinRequest) error {
+	return database.DB.Create(request).Error
+}
+
+func (r *repository) FindByID(id uint) (*JoinRequest, error) {
+	return first(withNames().Where("workspace_join_requests.id = ?", id))
+}
+
+func (r *repository) FindPending(workspaceID, userID uint) (*JoinRequest, error) {
+	return first(withNames().Where("workspace_join_requests.workspace_id = ? AND workspace_join_requests.user_id = ? AND workspace_join_requests.status = ?", workspaceID, userID, StatusPending))
+}
+
+func (r *repository) FindByWorkspaceID(workspaceID uint, status string) ([]JoinRequest, error) {
+	db := withNames().Where("workspace_join_requests.workspace_id = ?", workspaceID)
+	if status != "" {
+		db = db.Where("workspace_join_requests.status = ?", status)
+	}
+
+	var requests []JoinRequest
+	err := db.Order("workspace_join_requests.created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *repository) FindByUserID(userID uint) ([]JoinRequest, error) {
+	var requests []JoinRequest
+	err := withNames().
+		Where("workspace_join_requests.user_id = ?", userID).
+		Order("workspace_join_requests.created_at DESC").
+		Find(&requests).Error
+	return requests, err
+}
+
+// Close answers a pending request without adding anyone
+func (r *repository) Close(request *JoinRequest, status string, reviewerID *uint) error {
+	now := time.Now()
+	result := database.DB.Model(&JoinRequest{}).
+		Where("id = ? AND status = ?", request.ID, StatusPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewerID,
+			"reviewed_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	// Answered or cancelled concurrently
+	if result.RowsAffected == 0 {
+		return ErrNotPending
+	}
+
+	request.Status = status
+	request.ReviewedBy = reviewerID
+	request.ReviewedAt = &now
+	return nil
+}
+
+// Approve adds the requester with request.Role and marks the request approved
+// in one transaction. A requester who joined meanwhile keeps their role.
+func (r *repository) Approve(request *JoinRequest, reviewerID uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&JoinRequest{}).
+			Where("id = ? AND status = ?", request.ID, StatusPending).
+			Updates(map[string]interface{}{
+				"status":      StatusApproved,
+				"role":        request.Role,
+				"reviewed_by": reviewerID,
+				"reviewed_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotPending
+		}
+
+		var existing int64
+		if err := tx.Model(&workspacesUsers.WorkspacesUsers{}).
+			Where("workspace_id = ? AND user_id = ?", request.WorkspaceID, request.UserID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing == 0 {
+			member := &workspacesUsers.WorkspacesUsers{
+				WorkspaceID: request.WorkspaceID,
+				UserID:      request.UserID,
+				Role:        request.Role,
+			}
+			if err := tx.Create(member).Error; err != nil {
+				return err
+			}
+		}
+
+		request.Status = StatusApproved
+		request.ReviewedBy = &reviewerID
+		request.ReviewedAt = &now
+		return nil
+	})
+}
+
+func withNames() *gorm.DB {
+	return database.DB.Model(&JoinRequest{}).
+		Select("workspace_join_requests.*, workspaces.name AS workspace_name, users.username").
+		Joins("JOIN workspaces ON workspaces.id = workspace_join_requests.workspace_id").
+		Joins("JOIN users ON users.id = workspace_join_requests.user_id")
+}
+
+func first(db *gorm.DB) (*JoinRequest, error) {
+	var request JoinRequest
+	err := db.First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
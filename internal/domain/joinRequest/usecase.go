@@ -0,0 +1,257 @@
+package joinRequest
+
+import (
+	"errors"
+
+	"hrm-app/internal/domain/notification"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
+)
+
+// Workspace privacy values, see workspaces.Privacy*
+const (
+	privacyPublic = "public"
+	privacyTeam   = "team"
+)
+
+var (
+	ErrNotFound          = errors.New("join request not found")
+	ErrNotPending        = errors.New("join request is no longer pending")
+	ErrAlreadyRequested  = errors.New("a join request for this workspace is already pending")
+	ErrPublicWorkspace   = errors.New("this workspace is public, join it directly instead")
+	ErrInviteOnly        = errors.New("this workspace is private and can only be joined by invitation")
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+)
+
+// UserRepository is the subset of user.Repository needed to name requesters
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+}
+
+// WorkspaceRepository resolves the requested workspace (implemented by workspaces.NewRepositoryAdapter)
+type WorkspaceRepository interface {
+	FindByID(id uint) (*workspacesUsers.WorkspaceInfo, error)
+}
+
+// MemberRepository lists the members to notify of new requests (implemented by workspacesUsers.Repository)
+type MemberRepository interface {
+	GetByWorkspaceID(workspaceID uint) ([]workspacesUsers.WorkspacesUsers, error)
+}
+
+// Notifier tells requesters and reviewers about requests (implemented by notification.UseCase)
+type Notifier interface {
+	Notify(userID uint, n notification.Notification)
+}
+
+type UseCase interface {
+	Request(workspaceID, userID uint, req CreateRequest) (*JoinRequest, error)
+	List(workspaceID, userID uint, status string) ([]JoinRequest, error)
+	Mine(userID uint) ([]JoinRequest, error)
+	Approve(id, userID uint, req ApproveRequest) (*JoinRequest, error)
+	Reject(id, userID uint) (*JoinRequest, error)
+	Cancel(id, userID uint) (*JoinRequest, error)
+}
+
+type usecase struct {
+	repo          Repository
+	userRepo      UserRepository
+	workspaceRepo WorkspaceRepository
+	memberRepo    MemberRepository
+	authz         policy.Authorizer
+	notifier      Notifier
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository, workspaceRepo WorkspaceRepository, memberRepo MemberRepository, authz policy.Authorizer, notifier Notifier) UseCase {
+	return &usecase{
+		repo:          repo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		memberRepo:    memberRepo,
+		authz:         authz,
+		notifier:      notifier,
+	}
+}
+
+// Request asks to join a team workspace. Public workspaces are joined
+// directly and private ones only by invitation, so both are refused here.
+func (u *usecase) Request(workspaceID, userID uint, req CreateRequest) (*JoinRequest, error) {
+	workspace, err := u.workspaceRepo.FindByID(workspaceID)
+	if err != nil || workspace == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	switch workspace.Privacy {
+	case privacyTeam:
+		// The only privacy that takes requests
+	case privacyPublic:
+		return nil, ErrPublicWorkspace
+	default:
+		return nil, ErrInviteOnly
+	}
+
+	role, err := u.authz.WorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != "" {
+		return nil, workspacesUsers.ErrAlreadyMember
+	}
+	if err := u.authz.CheckWritable(workspaceID); err != nil {
+		return nil, err
+	}
+
+	pending, err := u.repo.FindPending(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, ErrAlreadyRequested
+	}
+
+	request := &JoinRequest{
+		WorkspaceID:   workspaceID,
+		WorkspaceName: workspace.Name,
+		UserID:        userID,
+		Message:       req.Message,
+		Status:        StatusPending,
+	}
+	if err := u.repo.Create(request); err != nil {
+		return nil, err
+	}
+
+	u.notifyReviewers(request)
+	return request, nil
+}
+
+// List shows a workspace's requests to those who manage its members. status
+// defaults to pending; "all" lists every request.
+func (u *usecase) List(workspaceID, userID uint, status string) ([]JoinRequest, error) {
+	if err := u.authz.AuthorizeWorkspace(workspaceID, userID, policy.ActionManageMembers); err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case "":
+		status = StatusPending
+	case "all":
+		status = ""
+	}
+	return u.repo.FindByWorkspaceID(workspaceID, status)
+}
+
+func (u *usecase) Mine(userID uint) ([]JoinRequest, error) {
+	return u.repo.FindByUserID(userID)
+}
+
+// Approve lets the requester in, as a member unless the reviewer picks another
+// role below their own, like workspacesUsers.Create.
+func (u *usecase) Approve(id, userID uint, req ApproveRequest) (*JoinRequest, error) {
+	request, err := u.pending(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Role == "" {
+		req.Role = policy.RoleMember
+	}
+	actorRole, err := u.authz.WorkspaceRole(request.WorkspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actorRole == "" {
+		return nil, policy.ErrNotMember
+	}
+	if !policy.CanAssignRole(actorRole, "", req.Role) {
+		return nil, policy.ErrForbidden
+	}
+	if err := u.authz.CheckWritable(request.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	request.Role = req.Role
+	if err := u.repo.Approve(request, userID); err != nil {
+		return nil, err
+	}
+
+	u.notifyRequester(request, notification.TypeWorkspaceJoinApproved, request.WorkspaceName, request.Role)
+	return request, nil
+}
+
+func (u *usecase) Reject(id, userID uint) (*JoinRequest, error) {
+	request, err := u.pending(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authz.AuthorizeWorkspace(request.WorkspaceID, userID, policy.ActionManageMembers); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Close(request, StatusRejected, &userID); err != nil {
+		return nil, err
+	}
+
+	u.notifyRequester(request, notification.TypeWorkspaceJoinRejected, request.WorkspaceName)
+	return request, nil
+}
+
+// Cancel withdraws a request. Only the requester may cancel.
+func (u *usecase) Cancel(id, userID uint) (*JoinRequest, error) {
+	request, err := u.pending(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.UserID != userID {
+		return nil, policy.ErrForbidden
+	}
+
+	if err := u.repo.Close(request, StatusCancelled, nil); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (u *usecase) pending(id uint) (*JoinRequest, error) {
+	request, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrNotFound
+	}
+	if request.Status != StatusPending {
+		return nil, ErrNotPending
+	}
+	return request, nil
+}
+
+// notifyReviewers tells the owners and admins of the workspace about a new request
+func (u *usecase) notifyReviewers(request *JoinRequest) {
+	members, err := u.memberRepo.GetByWorkspaceID(request.WorkspaceID)
+	if err != nil {
+		return
+	}
+
+	requesterName := ""
+	if requester, err := u.userRepo.FindByID(request.UserID); err == nil && requester != nil {
+		requesterName = requester.Username
+	}
+
+	for _, member := range members {
+		if !member.Role.AtLeast(policy.RoleAdmin) {
+			continue
+		}
+		u.notifier.Notify(member.UserID, notification.Notification{
+			Type: notification.TypeWorkspaceJoinRequested,
+			Args: []interface{}{requesterName, request.WorkspaceName},
+			Data: map[string]interface{}{"workspace_id": request.WorkspaceID, "join_request_id": request.ID},
+		})
+	}
+}
+
+func (u *usecase) notifyRequester(request *JoinRequest, notificationType string, args ...interface{}) {
+	u.notifier.Notify(request.UserID, notification.Notification{
+		Type: notificationType,
+		Args: args,
+		Data: map[string]interface{}{"workspace_id": request.WorkspaceID, "join_request_id": request.ID},
+	})
+}
@@ -0,0 +1,321 @@
+package joinRequest
+
+import (
+	"errors"
+	"testing"
+
+	"hrm-app/internal/domain/notification"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
+)
+
+// mockRepository keeps join requests in memory and adds approved requesters
+// to the shared roles map, so policy sees them as members afterwards
+type mockRepository struct {
+	requests map[uint]*JoinRequest
+	roles    map[uint]policy.Role
+	nextID   uint
+}
+
+func (m *mockRepository) Create(request *JoinRequest) error {
+	m.nextID++
+	request.ID = m.nextID
+	m.requests[request.ID] = request
+	return nil
+}
+
+func (m *mockRepository) FindByID(id uint) (*JoinRequest, error) {
+	if request, ok := m.requests[id]; ok {
+		found := *request
+		return &found, nil
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) FindPending(workspaceID, userID uint) (*JoinRequest, error) {
+	for _, request := range m.requests {
+		if request.WorkspaceID == workspaceID && request.UserID == userID && request.Status == StatusPending {
+			return request, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) FindByWorkspaceID(workspaceID uint, status string) ([]JoinRequest, error) {
+	var requests []JoinRequest
+	for _, request := range m.requests {
+		if request.WorkspaceID == workspaceID && (status == "" || request.Status == status) {
+			requests = append(requests, *request)
+		}
+	}
+	return requests, nil
+}
+
+func (m *mockRepository) FindByUserID(userID uint) ([]JoinRequest, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) Close(request *JoinRequest, status string, reviewerID *uint) error {
+	request.Status = status
+	request.ReviewedBy = reviewerID
+	m.requests[request.ID].Status = status
+	return nil
+}
+
+func (m *mockRepository) Approve(request *JoinRequest, reviewerID uint) error {
+	request.Status = StatusApproved
+	request.ReviewedBy = &reviewerID
+	m.requests[request.ID].Status = StatusApproved
+	if _, ok := m.roles[request.UserID]; !ok {
+		m.roles[request.UserID] = request.Role
+	}
+	return nil
+}
+
+type mockUserRepository struct{}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	return &user.User{ID: id, Username: "requester"}, nil
+}
+
+// mockWorkspaceRepository serves workspace 1 as a team workspace, 2 as public and 3 as private
+type mockWorkspaceRepository struct{}
+
+func (m *mockWorkspaceRepository) FindByID(id uint) (*workspacesUsers.WorkspaceInfo, error) {
+	privacy := map[uint]string{1: "team", 2: "public", 3: "private"}[id]
+	if privacy == "" {
+		return nil, nil
+	}
+	return &workspacesUsers.WorkspaceInfo{ID: id, Name: "Acme", Privacy: privacy}, nil
+}
+
+type mockMemberRepository struct {
+	roles map[uint]policy.Role
+}
+
+func (m *mockMemberRepository) GetByWorkspaceID(workspaceID uint) ([]workspacesUsers.WorkspacesUsers, error) {
+	var members []workspacesUsers.WorkspacesUsers
+	for userID, role := range m.roles {
+		members = append(members, workspacesUsers.WorkspacesUsers{WorkspaceID: workspaceID, UserID: userID, Role: role})
+	}
+	return members, nil
+}
+
+// mockPolicyRepository resolves workspace roles from the shared roles map
+type mockPolicyRepository struct {
+	roles    map[uint]policy.Role
+	readOnly bool
+}
+
+func (m *mockPolicyRepository) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	return m.roles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	return "", nil
+}
+
+func (m *mockPolicyRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 0, nil
+}
+
+func (m *mockPolicyRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return m.readOnly, nil
+}
+
+type mockNotifier struct {
+	sent []notification.Notification
+	to   []uint
+}
+
+func (m *mockNotifier) Notify(userID uint, n notification.Notification) {
+	m.to = append(m.to, userID)
+	m.sent = append(m.sent, n)
+}
+
+const (
+	ownerID     uint = 1
+	adminID     uint = 2
+	memberID    uint = 3
+	requesterID uint = 9
+)
+
+func newTestUseCase() (UseCase, *mockRepository, *mockNotifier) {
+	roles := map[uint]policy.Role{ownerID: policy.RoleOwner, adminID: policy.RoleAdmin, memberID: policy.RoleMember}
+	repo := &mockRepository{requests: map[uint]*JoinRequest{}, roles: roles}
+	notifier := &mockNotifier{}
+	uc := NewUseCase(repo, &mockUserRepository{}, &mockWorkspaceRepository{}, &mockMemberRepository{roles: roles},
+		policy.New(&mockPolicyRepository{roles: roles}), notifier)
+	return uc, repo, notifier
+}
+
+func TestUseCase_Request(t *testing.T) {
+	tests := []struct {
+		name        string
+		workspaceID uint
+		userID      uint
+		pending     bool
+		wantErr     error
+	}{
+		{name: "team workspace takes requests", workspaceID: 1, userID: requesterID},
+		{name: "public workspace is joined directly", workspaceID: 2, userID: requesterID, wantErr: ErrPublicWorkspace},
+		{name: "private workspace is invite only", workspaceID: 3, userID: requesterID, wantErr: ErrInviteOnly},
+		{name: "unknown workspace", workspaceID: 4, userID: requesterID, wantErr: ErrWorkspaceNotFound},
+		{name: "members cannot request", workspaceID: 1, userID: memberID, wantErr: workspacesUsers.ErrAlreadyMember},
+		{name: "one pending request at a time", workspaceID: 1, userID: requesterID, pending: true, wantErr: ErrAlreadyRequested},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, notifier := newTestUseCase()
+			if tt.pending {
+				repo.Create(&JoinRequest{WorkspaceID: tt.workspaceID, UserID: tt.userID, Status: StatusPending})
+			}
+
+			request, err := uc.Request(tt.workspaceID, tt.userID, CreateRequest{Message: "Let me in"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Request() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if request.Status != StatusPending || request.Message != "Let me in" {
+				t.Errorf("Request() = %+v, want a pending request with the message", request)
+			}
+			// Owner and admin are told, the plain member is not
+			if len(notifier.to) != 2 {
+				t.Errorf("Request() notified %v, want the owner and the admin", notifier.to)
+			}
+			for i, to := range notifier.to {
+				if to == memberID || notifier.sent[i].Type != notification.TypeWorkspaceJoinRequested {
+					t.Errorf("Request() sent %q to %d", notifier.sent[i].Type, to)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCase_Approve(t *testing.T) {
+	tests := []struct {
+		name     string
+		actorID  uint
+		role     policy.Role
+		wantRole policy.Role
+		wantErr  error
+	}{
+		{name: "admin approves as member by default", actorID: adminID, wantRole: policy.RoleMember},
+		{name: "owner approves as admin", actorID: ownerID, role: policy.RoleAdmin, wantRole: policy.RoleAdmin},
+		{name: "admin cannot grant admin", actorID: adminID, role: policy.RoleAdmin, wantErr: policy.ErrForbidden},
+		{name: "member cannot approve", actorID: memberID, wantErr: policy.ErrForbidden},
+		{name: "requester cannot approve themselves", actorID: requesterID, wantErr: policy.ErrNotMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, notifier := newTestUseCase()
+			pending, err := uc.Request(1, requesterID, CreateRequest{})
+			if err != nil {
+				t.Fatalf("Request() error = %v", err)
+			}
+			notifier.to, notifier.sent = nil, nil
+
+			request, err := uc.Approve(pending.ID, tt.actorID, ApproveRequest{Role: tt.role})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Approve() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if _, ok := repo.roles[requesterID]; ok {
+					t.Error("Approve() added the requester on failure")
+				}
+				return
+			}
+			if request.Status != StatusApproved || repo.roles[requesterID] != tt.wantRole {
+				t.Errorf("Approve() role = %q, want %q", repo.roles[requesterID], tt.wantRole)
+			}
+			if len(notifier.to) != 1 || notifier.to[0] != requesterID || notifier.sent[0].Type != notification.TypeWorkspaceJoinApproved {
+				t.Errorf("Approve() notified %v, want the requester %d", notifier.to, requesterID)
+			}
+			if _, err := uc.Approve(pending.ID, tt.actorID, ApproveRequest{}); !errors.Is(err, ErrNotPending) {
+				t.Errorf("Approve() twice error = %v, want %v", err, ErrNotPending)
+			}
+		})
+	}
+}
+
+func TestUseCase_ReadOnlyWorkspace(t *testing.T) {
+	roles := map[uint]policy.Role{ownerID: policy.RoleOwner}
+	authz := &mockPolicyRepository{roles: roles}
+	repo := &mockRepository{requests: map[uint]*JoinRequest{}, roles: roles}
+	uc := NewUseCase(repo, &mockUserRepository{}, &mockWorkspaceRepository{}, &mockMemberRepository{roles: roles},
+		policy.New(authz), &mockNotifier{})
+
+	pending, err := uc.Request(1, requesterID, CreateRequest{})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+
+	// The workspace is archived while the request waits
+	authz.readOnly = true
+	if _, err := uc.Approve(pending.ID, ownerID, ApproveRequest{}); !errors.Is(err, policy.ErrReadOnly) {
+		t.Errorf("Approve() error = %v, want %v", err, policy.ErrReadOnly)
+	}
+	if _, ok := repo.roles[requesterID]; ok {
+		t.Error("Approve() added the requester to an archived workspace")
+	}
+	if _, err := uc.Request(1, 10, CreateRequest{}); !errors.Is(err, policy.ErrReadOnly) {
+		t.Errorf("Request() error = %v, want %v", err, policy.ErrReadOnly)
+	}
+}
+
+func TestUseCase_RejectAndCancel(t *testing.T) {
+	uc, repo, notifier := newTestUseCase()
+
+	first, err := uc.Request(1, requesterID, CreateRequest{})
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := uc.Reject(first.ID, memberID); !policy.IsForbidden(err) {
+		t.Errorf("Reject() by a member error = %v, want forbidden", err)
+	}
+	rejected, err := uc.Reject(first.ID, adminID)
+	if err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if rejected.Status != StatusRejected || *rejected.ReviewedBy != adminID {
+		t.Errorf("Reject() = %+v, want rejected by %d", rejected, adminID)
+	}
+	if last := notifier.sent[len(notifier.sent)-1]; last.Type != notification.TypeWorkspaceJoinRejected {
+		t.Errorf("Reject() sent %q, want %q", last.Type, notification.TypeWorkspaceJoinRejected)
+	}
+	if _, ok := repo.roles[requesterID]; ok {
+		t.Error("Reject() added the requester")
+	}
+
+	// A rejected requester may ask again, and withdraw the new request
+	second, err := uc.Request(1, requesterID, CreateRequest{})
+	if err != nil {
+		t.Fatalf("Request() after reject error = %v", err)
+	}
+	if _, err := uc.Cancel(second.ID, adminID); !policy.IsForbidden(err) {
+		t.Errorf("Cancel() by an admin error = %v, want forbidden", err)
+	}
+	if _, err := uc.Cancel(second.ID, requesterID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	pending, err := uc.List(1, ownerID, "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("List() = %d pending requests, want 0", len(pending))
+	}
+	all, _ := uc.List(1, ownerID, "all")
+	if len(all) != 2 {
+		t.Errorf("List(all) = %d requests, want 2", len(all))
+	}
+	if _, err := uc.List(1, memberID, ""); !policy.IsForbidden(err) {
+		t.Errorf("List() by a member error = %v, want forbidden", err)
+	}
+}
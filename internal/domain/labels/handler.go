@@ -32,16 +32,6 @@ func (h *Handler) Create(c *gin.Context) {
 	response.Success(c, label)
 }
 
-func (h *Handler) GetAll(c *gin.Context) {
-	labels, err := h.usecase.FindAll()
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	response.Success(c, labels)
-}
-
 func (h *Handler) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
@@ -0,0 +1,89 @@
+package mfa
+
+import (
+	"errors"
+	"time"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindByUserID(userID uint) (*UserMFA, error)
+	Save(m *UserMFA) error
+	DeleteByUserID(userID uint) error
+	UpdateLastUsedStep(id uint, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) FindByUserID(userID uint) (*UserMFA, error) {
+	var m UserMFA
+	err := database.DB.Where("user_id = ?", userID).First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *repository) Save(m *UserMFA) error {
+	return database.DB.Save(m).Error
+}
+
+func (r *repository) DeleteByUserID(userID uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&UserMFA{}).Error
+	})
+}
+
+// UpdateLastUsedStep records the step of an accepted code. It reports false when
+// the step was already used, which rejects replayed codes.
+func (r *repository) UpdateLastUsedStep(id uint, step int64) (bool, error) {
+	result := database.DB.Model(&UserMFA{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *repository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *repository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := database.DB.Model(&RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *repository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := database.DB.Model(&RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,209 @@
+package mfa
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/totp"
+	"hrm-app/internal/pkg/utils"
+)
+
+const (
+	issuer            = "Traspac"
+	recoveryCodeCount = 10
+)
+
+var (
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrInvalidCode    = errors.New("invalid authentication code")
+)
+
+// UserRepository is the subset of user.Repository needed for enrollment
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+}
+
+type UseCase interface {
+	Status(userID uint) (*StatusResponse, error)
+	Enroll(userID uint) (*EnrollResponse, error)
+	Confirm(userID uint, code string) ([]string, error)
+	Disable(userID uint, code string) error
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	IsEnabled(userID uint) (bool, error)
+	Verify(userID uint, code string) error
+}
+
+type usecase struct {
+	repo     Repository
+	userRepo UserRepository
+	now      func() time.Time
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository) UseCase {
+	return &usecase{
+		repo:     repo,
+		userRepo: userRepo,
+		now:      time.Now,
+	}
+}
+
+func (u *usecase) Status(userID uint) (*StatusResponse, error) {
+	m, err := u.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil || m.EnabledAt == nil {
+		return &StatusResponse{}, nil
+	}
+
+	remaining, err := u.repo.CountUnusedRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &StatusResponse{Enabled: true, RecoveryCodesRemaining: remaining}, nil
+}
+
+// Enroll creates a new pending secret, replacing an unconfirmed one
+func (u *usecase) Enroll(userID uint) (*EnrollResponse, error) {
+	existing, err := u.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.EnabledAt != nil {
+		return nil, ErrAlreadyEnabled
+	}
+
+	account, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &UserMFA{UserID: userID, Secret: secret}
+	if existing != nil {
+		m.ID = existing.ID
+		m.CreatedAt = existing.CreatedAt
+	}
+	if err := u.repo.Save(m); err != nil {
+		return nil, err
+	}
+
+	return &EnrollResponse{
+		Secret:     secret,
+		OtpauthURI: totp.URI(issuer, account.Email, secret),
+	}, nil
+}
+
+// Confirm enables the factor with a first valid code and returns the recovery codes.
+// The plain recovery codes are only ever returned here.
+func (u *usecase) Confirm(userID uint, code string) ([]string, error) {
+	m, err := u.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, ErrNotEnrolled
+	}
+	if m.EnabledAt != nil {
+		return nil, ErrAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(m.Secret, code, u.now())
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	now := u.now()
+	m.EnabledAt = &now
+	m.LastUsedStep = step
+	if err := u.repo.Save(m); err != nil {
+		return nil, err
+	}
+
+	return u.issueRecoveryCodes(userID)
+}
+
+func (u *usecase) Disable(userID uint, code string) error {
+	if err := u.Verify(userID, code); err != nil {
+		return err
+	}
+	return u.repo.DeleteByUserID(userID)
+}
+
+func (u *usecase) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	if err := u.Verify(userID, code); err != nil {
+		return nil, err
+	}
+	return u.issueRecoveryCodes(userID)
+}
+
+func (u *usecase) IsEnabled(userID uint) (bool, error) {
+	m, err := u.repo.FindByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	return m != nil && m.EnabledAt != nil, nil
+}
+
+// Verify accepts a TOTP code or an unused recovery code
+func (u *usecase) Verify(userID uint, code string) error {
+	m, err := u.repo.FindByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if m == nil || m.EnabledAt == nil {
+		return ErrNotEnrolled
+	}
+
+	if step, ok := totp.Validate(m.Secret, code, u.now()); ok {
+		fresh, err := u.repo.UpdateLastUsedStep(m.ID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrInvalidCode
+		}
+		return nil
+	}
+
+	used, err := u.repo.UseRecoveryCode(userID, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+func (u *usecase) issueRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateSecureToken(5)
+		if err != nil {
+			return nil, err
+		}
+		code := raw[:5] + "-" + raw[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := u.repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode ignores case, spaces and dashes so codes can be typed loosely
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
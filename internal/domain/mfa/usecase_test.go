@@ -0,0 +1,137 @@
+package mfa
+
+import (
+	"testing"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/totp"
+)
+
+type mockRepository struct {
+	mfa   *UserMFA
+	codes map[string]bool // hash -> used
+}
+
+func (m *mockRepository) FindByUserID(userID uint) (*UserMFA, error) {
+	if m.mfa == nil || m.mfa.UserID != userID {
+		return nil, nil
+	}
+	copied := *m.mfa
+	return &copied, nil
+}
+
+func (m *mockRepository) Save(mfa *UserMFA) error {
+	mfa.ID = 1
+	copied := *mfa
+	m.mfa = &copied
+	return nil
+}
+
+func (m *mockRepository) DeleteByUserID(userID uint) error {
+	m.mfa = nil
+	m.codes = map[string]bool{}
+	return nil
+}
+
+func (m *mockRepository) UpdateLastUsedStep(id uint, step int64) (bool, error) {
+	if m.mfa.LastUsedStep >= step {
+		return false, nil
+	}
+	m.mfa.LastUsedStep = step
+	return true, nil
+}
+
+func (m *mockRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	m.codes = map[string]bool{}
+	for _, hash := range codeHashes {
+		m.codes[hash] = false
+	}
+	return nil
+}
+
+func (m *mockRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	used, ok := m.codes[codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	m.codes[codeHash] = true
+	return true, nil
+}
+
+func (m *mockRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	for _, used := range m.codes {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type mockUserRepository struct{}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	return &user.User{ID: id, Email: "jane@example.com"}, nil
+}
+
+func TestEnrollAndVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	uc := NewUseCase(&mockRepository{}, &mockUserRepository{}).(*usecase)
+	uc.now = func() time.Time { return now }
+
+	enrollment, err := uc.Enroll(5)
+	if err != nil {
+		t.Fatalf("Enroll() error = %v", err)
+	}
+
+	if enabled, _ := uc.IsEnabled(5); enabled {
+		t.Fatalf("expected factor to stay disabled before confirmation")
+	}
+
+	if _, err := uc.Confirm(5, "000000"); err != ErrInvalidCode {
+		t.Errorf("expected ErrInvalidCode, got %v", err)
+	}
+
+	code, _ := totp.Code(enrollment.Secret, totp.Step(now))
+	recoveryCodes, err := uc.Confirm(5, code)
+	if err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if len(recoveryCodes) != recoveryCodeCount {
+		t.Errorf("expected %d recovery codes, got %d", recoveryCodeCount, len(recoveryCodes))
+	}
+
+	t.Run("confirmation code cannot be replayed", func(t *testing.T) {
+		if err := uc.Verify(5, code); err != ErrInvalidCode {
+			t.Errorf("expected ErrInvalidCode, got %v", err)
+		}
+	})
+
+	t.Run("next code is accepted", func(t *testing.T) {
+		now = now.Add(totp.Period)
+		next, _ := totp.Code(enrollment.Secret, totp.Step(now))
+		if err := uc.Verify(5, next); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("recovery code is single use", func(t *testing.T) {
+		if err := uc.Verify(5, recoveryCodes[0]); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := uc.Verify(5, recoveryCodes[0]); err != ErrInvalidCode {
+			t.Errorf("expected ErrInvalidCode on reuse, got %v", err)
+		}
+		status, _ := uc.Status(5)
+		if status.RecoveryCodesRemaining != recoveryCodeCount-1 {
+			t.Errorf("expected %d remaining, got %d", recoveryCodeCount-1, status.RecoveryCodesRemaining)
+		}
+	})
+
+	t.Run("cannot enroll twice", func(t *testing.T) {
+		if _, err := uc.Enroll(5); err != ErrAlreadyEnabled {
+			t.Errorf("expected ErrAlreadyEnabled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package notification
+
+import "time"
+
+// Notification types, each with localized "notification.<type>.title/body" texts
+const (
+	TypeWorkspaceMemberAdded       = "workspace_member_added"
+	TypeBoardMemberAdded           = "board_member_added"
+	TypeTaskCardAssigned           = "task_card_assigned"
+	TypeWorkspaceOwnershipOffered  = "workspace_ownership_offered"
+	TypeWorkspaceOwnershipAccepted = "workspace_ownership_accepted"
+	TypeWorkspaceOwnershipDeclined = "workspace_ownership_declined"
+	TypeWorkspaceJoinRequested     = "workspace_join_requested"
+	TypeWorkspaceJoinApproved      = "workspace_join_approved"
+	TypeWorkspaceJoinRejected      = "workspace_join_rejected"
+)
+
+// Notification tells a single user about something that happened to them
+type Notification struct {
+	Type string
+	Args []interface{}          // formatted into the localized body
+	Data map[string]interface{} // ids the client needs to link to the target
+}
+
+// Message is the WebSocket payload of a delivered notification
+type Message struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Body      string                 `json:"body"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"hrm-app/internal/domain/settings"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/pkg/mailer"
+)
+
+// Publisher pushes a message to a user's own WebSocket queue (implemented by websocket.Hub)
+type Publisher interface {
+	PublishToUser(userID uint, message []byte) error
+}
+
+// Preferences reads the recipient's settings (implemented by settings.UseCase)
+type Preferences interface {
+	Get(userID uint) (*settings.Settings, error)
+}
+
+// UserRepository is the subset of user.Repository needed to email recipients
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+}
+
+type UseCase interface {
+	Notify(userID uint, n Notification)
+}
+
+type usecase struct {
+	publisher Publisher
+	prefs     Preferences
+	userRepo  UserRepository
+	mailer    mailer.Mailer
+}
+
+func NewUseCase(publisher Publisher, prefs Preferences, userRepo UserRepository, m mailer.Mailer) UseCase {
+	return &usecase{
+		publisher: publisher,
+		prefs:     prefs,
+		userRepo:  userRepo,
+		mailer:    m,
+	}
+}
+
+// Notify delivers n in the background so callers never wait on SMTP or RabbitMQ
+func (u *usecase) Notify(userID uint, n Notification) {
+	go u.deliver(userID, n)
+}
+
+// deliver sends n over WebSocket and email in the recipient's language, unless
+// the recipient turned notifications off. Failures are logged, never returned.
+func (u *usecase) deliver(userID uint, n Notification) {
+	prefs, err := u.prefs.Get(userID)
+	if err != nil {
+		log.Printf("[Notification] Failed to read settings for UserID=%d: %v", userID, err)
+		return
+	}
+	if !prefs.NotificationsAllowed() {
+		return
+	}
+
+	msg := Message{
+		Type:      n.Type,
+		Title:     i18n.T(prefs.Language, "notification."+n.Type+".title"),
+		Body:      i18n.T(prefs.Language, "notification."+n.Type+".body", n.Args...),
+		Data:      n.Data,
+		CreatedAt: time.Now(),
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"action": "notification",
+		"status": "success",
+		"data":   msg,
+	})
+	if err := u.publisher.PublishToUser(userID, payload); err != nil {
+		log.Printf("[Notification] Failed to push %s to UserID=%d: %v", n.Type, userID, err)
+	}
+
+	recipient, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		log.Printf("[Notification] Failed to find UserID=%d: %v", userID, err)
+		return
+	}
+	if err := u.mailer.Send(context.Background(), mailer.Message{
+		To:      recipient.Email,
+		Subject: msg.Title,
+		Body:    i18n.T(prefs.Language, "email.notification.body", recipient.Username, msg.Body),
+	}); err != nil {
+		log.Printf("[Notification] Failed to email %s to UserID=%d: %v", n.Type, userID, err)
+	}
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"hrm-app/internal/domain/settings"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/pkg/mailer"
+)
+
+type mockPublisher struct {
+	messages map[uint][][]byte
+}
+
+func (m *mockPublisher) PublishToUser(userID uint, message []byte) error {
+	m.messages[userID] = append(m.messages[userID], message)
+	return nil
+}
+
+type mockPreferences map[uint]*settings.Settings
+
+func (m mockPreferences) Get(userID uint) (*settings.Settings, error) {
+	return m[userID], nil
+}
+
+type mockUserRepository struct{}
+
+func (m *mockUserRepository) FindByID(id uint) (*user.User, error) {
+	return &user.User{ID: id, Username: "budi", Email: "budi@example.com"}, nil
+}
+
+func TestDeliver(t *testing.T) {
+	publisher := &mockPublisher{messages: map[uint][][]byte{}}
+	prefs := mockPreferences{
+		1: {UserID: 1, Language: i18n.Indonesian, Notification: settings.NotificationAllowed},
+		2: {UserID: 2, Language: i18n.English, Notification: settings.NotificationNotAllowed},
+	}
+	mail := mailer.NewMemoryMailer()
+	uc := NewUseCase(publisher, prefs, &mockUserRepository{}, mail).(*usecase)
+
+	n := Notification{Type: TypeBoardMemberAdded, Args: []interface{}{"Sprint", "member"}, Data: map[string]interface{}{"board_id": 3}}
+	uc.deliver(1, n)
+	uc.deliver(2, n)
+
+	if len(publisher.messages[2]) != 0 || len(mail.Sent()) != 1 {
+		t.Fatalf("notifications delivered to a user who turned them off")
+	}
+
+	if len(publisher.messages[1]) != 1 {
+		t.Fatalf("ws messages = %d, want 1", len(publisher.messages[1]))
+	}
+	var ws struct {
+		Action string  `json:"action"`
+		Data   Message `json:"data"`
+	}
+	if err := json.Unmarshal(publisher.messages[1][0], &ws); err != nil {
+		t.Fatal(err)
+	}
+	if ws.Action != "notification" || ws.Data.Body != "Anda ditambahkan ke board \"Sprint\" sebagai member." {
+		t.Errorf("ws message = %+v", ws)
+	}
+
+	sent := mail.Sent()[0]
+	if sent.To != "budi@example.com" || sent.Subject != "Anda ditambahkan ke board" || !strings.Contains(sent.Body, "Halo budi") {
+		t.Errorf("email = %+v", sent)
+	}
+}
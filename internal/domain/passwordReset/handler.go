@@ -0,0 +1,54 @@
+package passwordReset
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// A failure is only logged: answering differently would tell callers
+	// which emails are registered
+	if err := h.usecase.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		log.Printf("[Forgot Password] Failed to send reset link: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": response.Message(c, "If the email is registered, a reset link has been sent")})
+}
+
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.usecase.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": response.Message(c, "Password reset successfully")})
+}
@@ -0,0 +1,52 @@
+package passwordReset
+
+import (
+	"errors"
+	"time"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(token *PasswordResetToken) error
+	FindByTokenHash(tokenHash string) (*PasswordResetToken, error)
+	MarkUsed(id uint) (bool, error)
+	DeleteUnusedByUserID(userID uint) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(token *PasswordResetToken) error {
+	return database.DB.Create(token).Error
+}
+
+func (r *repository) FindByTokenHash(tokenHash string) (*PasswordResetToken, error) {
+	var token PasswordResetToken
+	err := database.DB.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes the token. It reports false when the token was already used,
+// so two concurrent resets with the same token cannot both succeed.
+func (r *repository) MarkUsed(id uint) (bool, error) {
+	result := database.DB.Model(&PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *repository) DeleteUnusedByUserID(userID uint) error {
+	return database.DB.Where("user_id = ? AND used_at IS NULL", userID).Delete(&PasswordResetToken{}).Error
+}
@@ -0,0 +1,132 @@
+package passwordReset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/pkg/mailer"
+	"hrm-app/internal/pkg/utils"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired reset token")
+
+// UserRepository is the subset of user.Repository needed to reset passwords
+type UserRepository interface {
+	FindByEmail(email string) (*user.User, error)
+	Update(user *user.User) error
+}
+
+// LanguageResolver returns the language a user reads emails in (implemented by settings.UseCase)
+type LanguageResolver interface {
+	Language(userID uint) string
+}
+
+type UseCase interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+type usecase struct {
+	repo           Repository
+	userRepo       UserRepository
+	mailer         mailer.Mailer
+	langs          LanguageResolver
+	cfg            *config.Config
+	revokeSessions func(userID uint) error
+}
+
+func NewUseCase(repo Repository, userRepo UserRepository, m mailer.Mailer, langs LanguageResolver, cfg *config.Config) UseCase {
+	return &usecase{
+		repo:           repo,
+		userRepo:       userRepo,
+		mailer:         m,
+		langs:          langs,
+		cfg:            cfg,
+		revokeSessions: utils.RevokeAllSessions,
+	}
+}
+
+// ForgotPassword emails a reset link. Unknown emails are ignored silently so the
+// endpoint cannot be used to discover registered accounts.
+func (u *usecase) ForgotPassword(ctx context.Context, email string) error {
+	existing, err := u.userRepo.FindByEmail(email)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.ID == 0 {
+		return nil
+	}
+
+	// Only the latest link stays valid
+	if err := u.repo.DeleteUnusedByUserID(existing.ID); err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	ttl := u.cfg.Auth.ResetTokenTTLMinutes
+	if ttl == 0 {
+		ttl = 30
+	}
+
+	resetToken := &PasswordResetToken{
+		UserID:    existing.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(ttl) * time.Minute),
+	}
+	if err := u.repo.Create(resetToken); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", u.cfg.App.FrontendURL, token)
+	lang := u.langs.Language(existing.ID)
+	return u.mailer.Send(ctx, mailer.Message{
+		To:      existing.Email,
+		Subject: i18n.T(lang, "email.password_reset.subject"),
+		Body:    i18n.T(lang, "email.password_reset.body", existing.Username, ttl, link),
+	})
+}
+
+func (u *usecase) ResetPassword(ctx context.Context, token, password string) error {
+	resetToken, err := u.repo.FindByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if resetToken == nil || resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return ErrInvalidToken
+	}
+
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	consumed, err := u.repo.MarkUsed(resetToken.ID)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrInvalidToken
+	}
+
+	if err := u.userRepo.Update(&user.User{ID: resetToken.UserID, Password: hashed}); err != nil {
+		return err
+	}
+
+	// Log out every device that used the old password
+	if err := u.revokeSessions(resetToken.UserID); err != nil {
+		log.Printf("[Password Reset] Failed to revoke sessions for UserID=%d: %v", resetToken.UserID, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package passwordReset
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/pkg/mailer"
+	"hrm-app/internal/pkg/utils"
+)
+
+type mockRepository struct {
+	tokens map[string]*PasswordResetToken
+}
+
+func (m *mockRepository) Create(token *PasswordResetToken) error {
+	token.ID = uint(len(m.tokens) + 1)
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockRepository) FindByTokenHash(tokenHash string) (*PasswordResetToken, error) {
+	return m.tokens[tokenHash], nil
+}
+
+func (m *mockRepository) MarkUsed(id uint) (bool, error) {
+	for _, t := range m.tokens {
+		if t.ID == id && t.UsedAt == nil {
+			now := time.Now()
+			t.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockRepository) DeleteUnusedByUserID(userID uint) error {
+	for hash, t := range m.tokens {
+		if t.UserID == userID && t.UsedAt == nil {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
+
+type englishOnly struct{}
+
+func (englishOnly) Language(userID uint) string {
+	return i18n.English
+}
+
+type mockUserRepository struct {
+	users map[string]*user.User
+}
+
+func (m *mockUserRepository) FindByEmail(email string) (*user.User, error) {
+	return m.users[email], nil
+}
+
+func (m *mockUserRepository) Update(u *user.User) error {
+	for _, existing := range m.users {
+		if existing.ID == u.ID && u.Password != "" {
+			existing.Password = u.Password
+		}
+	}
+	return nil
+}
+
+func newTestUseCase() (*usecase, *mockUserRepository, *mailer.MemoryMailer, *[]uint) {
+	cfg := &config.Config{}
+	cfg.App.FrontendURL = "http://app.test"
+
+	userRepo := &mockUserRepository{users: map[string]*user.User{
+		"jane@example.com": {ID: 7, Email: "jane@example.com", Username: "jane"},
+	}}
+	mail := mailer.NewMemoryMailer()
+	revoked := &[]uint{}
+
+	uc := NewUseCase(&mockRepository{tokens: map[string]*PasswordResetToken{}}, userRepo, mail, englishOnly{}, cfg).(*usecase)
+	uc.revokeSessions = func(userID uint) error {
+		*revoked = append(*revoked, userID)
+		return nil
+	}
+	return uc, userRepo, mail, revoked
+}
+
+// tokenFromMail extracts the reset token from the link in the email body
+func tokenFromMail(t *testing.T, msg mailer.Message) string {
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if strings.HasPrefix(line, "http://app.test/reset-password?") {
+			u, err := url.Parse(line)
+			if err != nil {
+				t.Fatalf("invalid reset link: %v", err)
+			}
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("reset link not found in email body: %q", msg.Body)
+	return ""
+}
+
+func TestForgotPassword(t *testing.T) {
+	t.Run("unknown email sends nothing", func(t *testing.T) {
+		uc, _, mail, _ := newTestUseCase()
+		if err := uc.ForgotPassword(context.Background(), "nobody@example.com"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(mail.Sent()) != 0 {
+			t.Errorf("expected no email, got %d", len(mail.Sent()))
+		}
+	})
+
+	t.Run("stores only the token hash", func(t *testing.T) {
+		uc, _, mail, _ := newTestUseCase()
+		if err := uc.ForgotPassword(context.Background(), "jane@example.com"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		sent := mail.Sent()
+		if len(sent) != 1 || sent[0].To != "jane@example.com" {
+			t.Fatalf("expected one email to jane@example.com, got %+v", sent)
+		}
+		token := tokenFromMail(t, sent[0])
+		if _, ok := uc.repo.(*mockRepository).tokens[utils.HashToken(token)]; !ok {
+			t.Errorf("expected token hash to be stored")
+		}
+		if _, ok := uc.repo.(*mockRepository).tokens[token]; ok {
+			t.Errorf("expected plain token not to be stored")
+		}
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	uc, userRepo, mail, revoked := newTestUseCase()
+	ctx := context.Background()
+
+	if err := uc.ForgotPassword(ctx, "jane@example.com"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	token := tokenFromMail(t, mail.Sent()[0])
+
+	if err := uc.ResetPassword(ctx, token, "new-password"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !utils.CheckPasswordHash("new-password", userRepo.users["jane@example.com"].Password) {
+		t.Errorf("expected password to be updated")
+	}
+	if len(*revoked) != 1 || (*revoked)[0] != 7 {
+		t.Errorf("expected sessions of user 7 to be revoked, got %v", *revoked)
+	}
+
+	if err := uc.ResetPassword(ctx, token, "another-password"); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken on reuse, got %v", err)
+	}
+
+	if err := uc.ResetPassword(ctx, "bogus", "another-password"); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for unknown token, got %v", err)
+	}
+}
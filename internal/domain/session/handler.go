@@ -0,0 +1,75 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) GetAll(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	data, err := h.usecase.List(userID.(uint), c.GetString("session_id"))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to list sessions")
+		return
+	}
+
+	response.Success(c, data)
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.usecase.Revoke(userID.(uint), c.Param("id")); err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	response.DeleteSuccess(c, "Session revoked successfully")
+}
+
+func (h *Handler) DeleteAll(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.usecase.RevokeAll(userID.(uint)); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to revoke sessions")
+		return
+	}
+
+	// The current session is gone as well
+	c.SetCookie("access_token", "", -1, "/", "", false, true)
+	c.SetCookie("refresh_token", "", -1, "/", "", false, true)
+
+	response.DeleteSuccess(c, "Logged out from all sessions")
+}
@@ -0,0 +1,63 @@
+package session
+
+import (
+	"errors"
+	"sort"
+
+	"hrm-app/internal/pkg/utils"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type UseCase interface {
+	List(userID uint, currentSessionID string) ([]Session, error)
+	Revoke(userID uint, sessionID string) error
+	RevokeAll(userID uint) error
+}
+
+type usecase struct{}
+
+func NewUseCase() UseCase {
+	return &usecase{}
+}
+
+func (u *usecase) List(userID uint, currentSessionID string) ([]Session, error) {
+	metas, err := utils.ListSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(metas))
+	for _, meta := range metas {
+		sessions = append(sessions, Session{
+			ID:         meta.ID,
+			UserAgent:  meta.UserAgent,
+			IP:         meta.IP,
+			CreatedAt:  meta.CreatedAt,
+			LastSeenAt: meta.LastSeenAt,
+			Current:    meta.ID == currentSessionID,
+		})
+	}
+
+	// Most recently active first
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+func (u *usecase) Revoke(userID uint, sessionID string) error {
+	found, err := utils.RevokeSession(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAll logs the user out everywhere, including the current session
+func (u *usecase) RevokeAll(userID uint) error {
+	return utils.RevokeAllSessions(userID)
+}
@@ -0,0 +1,44 @@
+package settings
+
+import (
+	"time"
+
+	"hrm-app/internal/pkg/i18n"
+)
+
+const (
+	NotificationAllowed    = "allowed"
+	NotificationNotAllowed = "not_allowed"
+)
+
+type Settings struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	UserID       uint      `json:"user_id"`
+	Language     string    `json:"language"`
+	Notification string    `json:"notification"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (Settings) TableName() string {
+	return "settings"
+}
+
+// NotificationsAllowed reports whether WS and email notifications may be delivered
+func (s *Settings) NotificationsAllowed() bool {
+	return s.Notification != NotificationNotAllowed
+}
+
+// Defaults are the settings of a new user
+func Defaults(userID uint) *Settings {
+	return &Settings{
+		UserID:       userID,
+		Language:     i18n.Default,
+		Notification: NotificationAllowed,
+	}
+}
+
+type UpdateRequest struct {
+	Language     string `json:"language" binding:"omitempty,oneof=en_us indo"`
+	Notification string `json:"notification" binding:"omitempty,oneof=allowed not_allowed"`
+}
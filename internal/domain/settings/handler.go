@@ -0,0 +1,57 @@
+package settings
+
+import (
+	"net/http"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	usecase UseCase
+}
+
+func NewHandler(u UseCase) *Handler {
+	return &Handler{usecase: u}
+}
+
+func (h *Handler) Get(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	data, err := h.usecase.Get(userID.(uint))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to get settings")
+		return
+	}
+
+	response.Success(c, data)
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := h.usecase.Update(userID.(uint), &req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to update settings")
+		return
+	}
+
+	response.Success(c, data)
+}
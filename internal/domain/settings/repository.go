@@ -0,0 +1,52 @@
+package settings
+
+import (
+	"errors"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(settings *Settings) error
+	FindByUserID(userID uint) (*Settings, error)
+	Update(settings *Settings) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+// Create inserts the settings unless the user already has them
+func (r *repository) Create(settings *Settings) error {
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoNothing: true,
+	}).Create(settings).Error
+}
+
+func (r *repository) FindByUserID(userID uint) (*Settings, error) {
+	var settings Settings
+	err := database.DB.Where("user_id = ?", userID).First(&settings).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *repository) Update(settings *Settings) error {
+	return database.DB.Model(&Settings{}).
+		Where("user_id = ?", settings.UserID).
+		Updates(map[string]interface{}{
+			"language":     settings.Language,
+			"notification": settings.Notification,
+			"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+}
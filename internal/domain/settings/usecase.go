@@ -0,0 +1,76 @@
+package settings
+
+import (
+	"log"
+
+	"hrm-app/internal/pkg/i18n"
+)
+
+type UseCase interface {
+	CreateDefaults(userID uint) error
+	Get(userID uint) (*Settings, error)
+	Update(userID uint, req *UpdateRequest) (*Settings, error)
+	Language(userID uint) string
+}
+
+type usecase struct {
+	repo Repository
+}
+
+func NewUseCase(repo Repository) UseCase {
+	return &usecase{repo: repo}
+}
+
+// CreateDefaults stores the default settings of a newly registered user
+func (u *usecase) CreateDefaults(userID uint) error {
+	return u.repo.Create(Defaults(userID))
+}
+
+// Get returns the user's settings, creating the defaults for users registered
+// before settings existed.
+func (u *usecase) Get(userID uint) (*Settings, error) {
+	settings, err := u.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings != nil {
+		return settings, nil
+	}
+
+	if err := u.CreateDefaults(userID); err != nil {
+		return nil, err
+	}
+	return u.repo.FindByUserID(userID)
+}
+
+func (u *usecase) Update(userID uint, req *UpdateRequest) (*Settings, error) {
+	settings, err := u.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Language != "" {
+		settings.Language = req.Language
+	}
+	if req.Notification != "" {
+		settings.Notification = req.Notification
+	}
+
+	if err := u.repo.Update(settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Language returns the user's language, or the default when it cannot be read
+func (u *usecase) Language(userID uint) string {
+	settings, err := u.Get(userID)
+	if err != nil {
+		log.Printf("[Settings] Failed to read settings for UserID=%d: %v", userID, err)
+		return i18n.Default
+	}
+	if !i18n.Supported(settings.Language) {
+		return i18n.Default
+	}
+	return settings.Language
+}
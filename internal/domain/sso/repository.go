@@ -0,0 +1,72 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"hrm-app/internal/pkg/database"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindIdentity(provider, subject string) (*UserIdentity, error)
+	CreateIdentity(identity *UserIdentity) error
+	SaveState(ctx context.Context, state string, data loginState, exp time.Duration) error
+	TakeState(ctx context.Context, state string) (*loginState, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) FindIdentity(provider, subject string) (*UserIdentity, error) {
+	var identity UserIdentity
+	err := database.DB.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *repository) CreateIdentity(identity *UserIdentity) error {
+	return database.DB.Create(identity).Error
+}
+
+func (r *repository) SaveState(ctx context.Context, state string, data loginState, exp time.Duration) error {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return database.RDB.Set(ctx, stateKey(state), value, exp).Err()
+}
+
+// TakeState returns and deletes the login state, so each state is used once
+func (r *repository) TakeState(ctx context.Context, state string) (*loginState, error) {
+	value, err := database.RDB.GetDel(ctx, stateKey(state)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var data loginState
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func stateKey(state string) string {
+	return fmt.Sprintf("oidc:state:%s", state)
+}
@@ -0,0 +1,147 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/oidc"
+	"hrm-app/internal/pkg/utils"
+)
+
+// StateTTL is how long the user has to finish logging in at the provider
+const StateTTL = 10 * time.Minute
+
+var (
+	ErrDisabled          = errors.New("single sign-on is not enabled")
+	ErrInvalidState      = errors.New("invalid or expired login state")
+	ErrEmailNotVerified  = errors.New("identity provider did not verify this email address")
+	ErrNoAccount         = errors.New("no account is registered with this email address")
+	ErrAccountUnverified = errors.New("verify the email address of your account before signing in with single sign-on")
+)
+
+// Provider is the identity provider client, implemented by *oidc.Provider
+type Provider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Claims, error)
+}
+
+// UserRepository is the subset of user.Repository needed to link accounts
+type UserRepository interface {
+	FindByID(id uint) (*user.User, error)
+	FindByEmail(email string) (*user.User, error)
+}
+
+type UseCase interface {
+	// Begin returns the provider login URL and the state it carries, which the
+	// caller binds to the browser
+	Begin(ctx context.Context) (string, string, error)
+	Complete(ctx context.Context, state, code string) (*user.User, error)
+}
+
+type usecase struct {
+	repo     Repository
+	userRepo UserRepository
+	provider Provider
+	issuer   string
+}
+
+// NewUseCase builds the SSO flow. A nil provider disables it.
+func NewUseCase(repo Repository, userRepo UserRepository, provider Provider, issuer string) UseCase {
+	return &usecase{
+		repo:     repo,
+		userRepo: userRepo,
+		provider: provider,
+		issuer:   issuer,
+	}
+}
+
+// Begin stores a fresh state, nonce and PKCE verifier and returns the provider login URL and the state
+func (u *usecase) Begin(ctx context.Context) (string, string, error) {
+	if u.provider == nil {
+		return "", "", ErrDisabled
+	}
+
+	state, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := u.repo.SaveState(ctx, state, loginState{CodeVerifier: verifier, Nonce: nonce}, StateTTL); err != nil {
+		return "", "", err
+	}
+
+	authURL, err := u.provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// Complete exchanges the code and resolves the local user: first by a linked
+// identity, then by email, which links the identity for next time. Only local
+// accounts whose own address was verified are linked, so nobody can register
+// someone else's address first and share their SSO login.
+func (u *usecase) Complete(ctx context.Context, state, code string) (*user.User, error) {
+	if u.provider == nil {
+		return nil, ErrDisabled
+	}
+
+	saved, err := u.repo.TakeState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return nil, ErrInvalidState
+	}
+
+	claims, err := u.provider.Exchange(ctx, code, saved.CodeVerifier, saved.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := u.repo.FindIdentity(u.issuer, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		return u.userRepo.FindByID(identity.UserID)
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	existing, err := u.userRepo.FindByEmail(claims.Email)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.ID == 0 {
+		return nil, ErrNoAccount
+	}
+	if existing.EmailVerifiedAt == nil {
+		return nil, ErrAccountUnverified
+	}
+
+	if err := u.repo.CreateIdentity(&UserIdentity{
+		UserID:   existing.ID,
+		Provider: u.issuer,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}); err != nil {
+		return nil, err
+	}
+	log.Printf("[SSO] Linked identity %s to UserID=%d", claims.Subject, existing.ID)
+
+	return existing, nil
+}
@@ -14,6 +14,7 @@ type TaskCard struct {
 	Content   string                            `json:"content"`
 	Date      string                            `json:"date"`
 	Status    bool                              `json:"status"`
+	Rank      string                            `json:"rank"` // order within the tab
 	Labels    []labels.TaskCardLabel            `json:"labels" gorm:"foreignKey:TaskCardID"`
 	Comments  []taskCardComment.TaskCardComment `json:"comments" gorm:"foreignKey:TaskCardID"`
 	Members   []taskCardUsers.TaskCardUsers     `json:"members" gorm:"foreignKey:TaskCardID"`
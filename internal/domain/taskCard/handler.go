@@ -33,17 +33,6 @@ func (h *Handler) Create(c *gin.Context) {
 	response.Success(c, taskCard)
 }
 
-func (h *Handler) GetAll(c *gin.Context) {
-	ctx := c.Request.Context()
-	taskCards, err := h.usecase.FindAll(ctx)
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	response.Success(c, taskCards)
-}
-
 func (h *Handler) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
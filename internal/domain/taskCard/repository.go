@@ -3,6 +3,7 @@ package taskCard
 import (
 	"context"
 	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/rank"
 
 	"gorm.io/gorm"
 )
@@ -14,7 +15,9 @@ type Repository interface {
 	FindByTaskTabID(ctx context.Context, taskTabID uint) ([]TaskCard, error)
 	FindSummaryByTaskTabIDs(ctx context.Context, taskTabIDs []uint) ([]TaskCard, error)
 	FindByTaskTabIDPaginated(ctx context.Context, taskTabID uint, limit, offset int) ([]TaskCard, error)
+	FindRanks(ctx context.Context, taskTabID uint) ([]rank.Item, error)
 	Update(ctx context.Context, taskCard *TaskCard) error
+	Move(ctx context.Context, id, taskTabID uint, key string) error
 	Delete(ctx context.Context, id uint) error
 }
 
@@ -67,6 +70,7 @@ func (r *repository) FindByTaskTabID(ctx context.Context, taskTabID uint) ([]Tas
 			return db.Select("id", "username")
 		}).
 		Where("task_tab_id = ?", taskTabID).
+		Order("rank, id").
 		Find(&taskCards).Error
 	return taskCards, err
 }
@@ -78,8 +82,9 @@ func (r *repository) FindSummaryByTaskTabIDs(ctx context.Context, taskTabIDs []u
 		Preload("Members.User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "username")
 		}).
-		Select("id, task_tab_id, name, date, status").
+		Select("id, task_tab_id, name, date, status, rank").
 		Where("task_tab_id IN ?", taskTabIDs).
+		Order("task_tab_id, rank, id").
 		Find(&taskCards).Error
 	return taskCards, err
 }
@@ -95,16 +100,34 @@ func (r *repository) FindByTaskTabIDPaginated(ctx context.Context, taskTabID uin
 			return db.Select("id", "username")
 		}).
 		Where("task_tab_id = ?", taskTabID).
+		Order("rank, id").
 		Limit(limit).
 		Offset(offset).
 		Find(&taskCards).Error
 	return taskCards, err
 }
 
+// FindRanks lists the cards of a tab in order
+func (r *repository) FindRanks(ctx context.Context, taskTabID uint) ([]rank.Item, error) {
+	var items []rank.Item
+	err := database.DB.WithContext(ctx).
+		Model(&TaskCard{}).
+		Select(`id, rank AS "key"`).
+		Where("task_tab_id = ?", taskTabID).
+		Order("rank, id").
+		Scan(&items).Error
+	return items, err
+}
+
 func (r *repository) Update(ctx context.Context, taskCard *TaskCard) error {
 	return database.DB.WithContext(ctx).Model(&TaskCard{ID: taskCard.ID}).Updates(taskCard).Error
 }
 
+// Move writes only the tab and rank of a card
+func (r *repository) Move(ctx context.Context, id, taskTabID uint, key string) error {
+	return database.DB.WithContext(ctx).Model(&TaskCard{ID: id}).Updates(map[string]interface{}{"task_tab_id": taskTabID, "rank": key}).Error
+}
+
 func (r *repository) Delete(ctx context.Context, id uint) error {
 	return database.DB.WithContext(ctx).Delete(&TaskCard{}, id).Error
 }
@@ -3,6 +3,8 @@ package taskCard
 import (
 	"context"
 	"errors"
+
+	"hrm-app/internal/pkg/rank"
 )
 
 type UseCase interface {
@@ -12,6 +14,7 @@ type UseCase interface {
 	FindByTaskTabID(ctx context.Context, taskTabID uint) ([]TaskCard, error)
 	FindByTaskTabIDs(ctx context.Context, taskTabIDs []uint) ([]TaskCard, error)
 	Update(ctx context.Context, taskCard *TaskCard) error
+	Move(ctx context.Context, id, taskTabID, beforeID, afterID uint) (*TaskCard, error)
 	Delete(ctx context.Context, id uint) error
 }
 
@@ -29,6 +32,13 @@ func (u *usecase) Create(ctx context.Context, taskCard *TaskCard) error {
 	if taskCard.Name == "" {
 		return errors.New("task card name is required")
 	}
+
+	// New cards go to the bottom of their tab
+	key, err := u.place(ctx, taskCard.ID, taskCard.TaskTabID, 0, 0)
+	if err != nil {
+		return err
+	}
+	taskCard.Rank = key
 	return u.repo.Create(ctx, taskCard)
 }
 
@@ -48,14 +58,60 @@ func (u *usecase) FindByTaskTabIDs(ctx context.Context, taskTabIDs []uint) ([]Ta
 	return u.repo.FindSummaryByTaskTabIDs(ctx, taskTabIDs)
 }
 
+// Update saves a card. A card moved to another tab goes to its bottom; the
+// rank itself is never taken from the caller.
 func (u *usecase) Update(ctx context.Context, taskCard *TaskCard) error {
 	// Check if taskCard exists
-	if _, err := u.repo.FindByID(ctx, taskCard.ID); err != nil {
+	existing, err := u.repo.FindByID(ctx, taskCard.ID)
+	if err != nil {
 		return err
 	}
+
+	taskCard.Rank = existing.Rank
+	if taskCard.TaskTabID != 0 && taskCard.TaskTabID != existing.TaskTabID {
+		if taskCard.Rank, err = u.place(ctx, taskCard.ID, taskCard.TaskTabID, 0, 0); err != nil {
+			return err
+		}
+	}
 	return u.repo.Update(ctx, taskCard)
 }
 
+// Move places a card in the tab taskTabID (its current tab when zero) between
+// the cards beforeID and afterID, either of which may be zero at the ends
+func (u *usecase) Move(ctx context.Context, id, taskTabID, beforeID, afterID uint) (*TaskCard, error) {
+	taskCard, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if taskTabID == 0 {
+		taskTabID = taskCard.TaskTabID
+	}
+
+	key, err := u.place(ctx, id, taskTabID, beforeID, afterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Move(ctx, id, taskTabID, key); err != nil {
+		return nil, err
+	}
+	return u.repo.FindByID(ctx, id)
+}
+
+// place finds the rank for card id between beforeID and afterID in a tab
+func (u *usecase) place(ctx context.Context, id, taskTabID, beforeID, afterID uint) (string, error) {
+	items, err := u.repo.FindRanks(ctx, taskTabID)
+	if err != nil {
+		return "", err
+	}
+	siblings := items[:0]
+	for _, item := range items {
+		if item.ID != id {
+			siblings = append(siblings, item)
+		}
+	}
+	return rank.Place(siblings, beforeID, afterID)
+}
+
 func (u *usecase) Delete(ctx context.Context, id uint) error {
 	return u.repo.Delete(ctx, id)
 }
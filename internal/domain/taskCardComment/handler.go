@@ -1,11 +1,14 @@
 package taskCardComment
 
 import (
+	"errors"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/response"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
@@ -40,16 +43,6 @@ func (h *Handler) CreateTaskCardComment(c *gin.Context) {
 	response.Success(c, taskCardComment)
 }
 
-func (h *Handler) GetAllTaskCardComment(c *gin.Context) {
-	taskCardComments, err := h.usecase.FindAll()
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	response.Success(c, taskCardComments)
-}
-
 func (h *Handler) GetTaskCardCommentByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
@@ -100,8 +93,15 @@ func (h *Handler) UpdateTaskCardComment(c *gin.Context) {
 
 	taskCardComment.ID = id
 
-	if err := h.usecase.Update(&taskCardComment); err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.usecase.Update(&taskCardComment, userID.(uint)); err != nil {
+		commentError(c, err)
 		return
 	}
 
@@ -116,10 +116,29 @@ func (h *Handler) DeleteTaskCardComment(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.Delete(uint(id)); err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.usecase.Delete(uint(id), userID.(uint)); err != nil {
+		commentError(c, err)
 		return
 	}
 
 	response.Success(c, nil)
 }
+
+// commentError maps a failed update or delete to its status code
+func commentError(c *gin.Context, err error) {
+	switch {
+	case policy.IsForbidden(err):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		response.Error(c, http.StatusNotFound, "Comment not found")
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
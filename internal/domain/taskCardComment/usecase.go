@@ -2,24 +2,47 @@ package taskCardComment
 
 import (
 	"errors"
+	"fmt"
+
+	"hrm-app/internal/pkg/policy"
 )
 
+// ErrNotAuthor is returned when someone other than the author edits a comment
+var ErrNotAuthor = fmt.Errorf("%w: only the author can edit a comment", policy.ErrForbidden)
+
+// Authorizer checks board roles (implemented by policy.Authorizer)
+type Authorizer interface {
+	AuthorizeBoard(boardID, userID uint, action policy.Action) error
+}
+
+// BoardLocator finds the board a comment is on (implemented by policy.BoardLocator)
+type BoardLocator interface {
+	BoardIDByComment(commentID uint) (uint, error)
+}
+
 type UseCase interface {
 	Create(taskCardComment *TaskCardComment) error
 	FindAll() ([]TaskCardComment, error)
 	FindByID(id uint) (*TaskCardComment, error)
 	FindByTaskCardID(taskCardID uint) ([]TaskCardComment, error)
-	Update(taskCardComment *TaskCardComment) error
-	Delete(id uint) error
+	// Update changes the text of a comment written by userID
+	Update(taskCardComment *TaskCardComment, userID uint) error
+	// Delete removes a comment written by userID, or any comment when userID
+	// can edit the board
+	Delete(id, userID uint) error
 }
 
 type usecase struct {
-	repo Repository
+	repo    Repository
+	authz   Authorizer
+	locator BoardLocator
 }
 
-func NewUseCase(repo Repository) UseCase {
+func NewUseCase(repo Repository, authz Authorizer, locator BoardLocator) UseCase {
 	return &usecase{
-		repo: repo,
+		repo:    repo,
+		authz:   authz,
+		locator: locator,
 	}
 }
 
@@ -38,18 +61,46 @@ func (u *usecase) FindByID(id uint) (*TaskCardComment, error) {
 	return u.repo.FindByID(id)
 }
 
-func (u *usecase) Update(taskCardComment *TaskCardComment) error {
+func (u *usecase) Update(taskCardComment *TaskCardComment, userID uint) error {
 	// Check if taskCardComment exists
 	if taskCardComment.ID < 0 {
 		return errors.New("invalid comment ID")
 	}
-	if _, err := u.repo.FindByID(uint(taskCardComment.ID)); err != nil {
+	if taskCardComment.Comment == "" {
+		return errors.New("comment is required")
+	}
+	existing, err := u.repo.FindByID(uint(taskCardComment.ID))
+	if err != nil {
+		return err
+	}
+	if existing.UserID != userID {
+		return ErrNotAuthor
+	}
+
+	// Only the text changes; the card and author stay as they were
+	existing.Comment = taskCardComment.Comment
+	if err := u.repo.Update(existing); err != nil {
 		return err
 	}
-	return u.repo.Update(taskCardComment)
+	*taskCardComment = *existing
+	return nil
 }
 
-func (u *usecase) Delete(id uint) error {
+func (u *usecase) Delete(id, userID uint) error {
+	existing, err := u.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if existing.UserID != userID {
+		// Moderating other people's comments takes edit access to the board
+		boardID, err := u.locator.BoardIDByComment(id)
+		if err != nil {
+			return err
+		}
+		if err := u.authz.AuthorizeBoard(boardID, userID, policy.ActionEdit); err != nil {
+			return err
+		}
+	}
 	return u.repo.Delete(id)
 }
 
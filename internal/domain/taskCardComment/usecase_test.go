@@ -0,0 +1,96 @@
+package taskCardComment
+
+import (
+	"errors"
+	"testing"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+type mockRepository struct {
+	comments map[uint]*TaskCardComment
+	deleted  []uint
+}
+
+func (m *mockRepository) Create(taskCardComment *TaskCardComment) error { return nil }
+func (m *mockRepository) FindAll() ([]TaskCardComment, error)           { return nil, nil }
+func (m *mockRepository) FindByTaskCardID(taskCardID uint) ([]TaskCardComment, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) FindByID(id uint) (*TaskCardComment, error) {
+	comment, ok := m.comments[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *comment
+	return &copied, nil
+}
+
+func (m *mockRepository) Update(taskCardComment *TaskCardComment) error {
+	copied := *taskCardComment
+	m.comments[uint(taskCardComment.ID)] = &copied
+	return nil
+}
+
+func (m *mockRepository) Delete(id uint) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+// mockAuthorizer lets only editor 3 edit the board
+type mockAuthorizer struct{}
+
+func (m *mockAuthorizer) AuthorizeBoard(boardID, userID uint, action policy.Action) error {
+	if userID == 3 {
+		return nil
+	}
+	return policy.ErrForbidden
+}
+
+type mockLocator struct{}
+
+func (m *mockLocator) BoardIDByComment(commentID uint) (uint, error) { return 1, nil }
+
+func newTestUseCase() (UseCase, *mockRepository) {
+	repo := &mockRepository{comments: map[uint]*TaskCardComment{
+		10: {ID: 10, TaskCardID: 5, UserID: 1, Comment: "first"},
+	}}
+	return NewUseCase(repo, &mockAuthorizer{}, &mockLocator{}), repo
+}
+
+func TestUpdate_OnlyAuthor(t *testing.T) {
+	uc, repo := newTestUseCase()
+
+	for _, userID := range []uint{2, 3} {
+		if err := uc.Update(&TaskCardComment{ID: 10, Comment: "forged"}, userID); !errors.Is(err, ErrNotAuthor) {
+			t.Errorf("user %d: expected ErrNotAuthor, got %v", userID, err)
+		}
+	}
+
+	comment := &TaskCardComment{ID: 10, TaskCardID: 99, UserID: 2, Comment: "edited"}
+	if err := uc.Update(comment, 1); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	saved := repo.comments[10]
+	if saved.Comment != "edited" || saved.UserID != 1 || saved.TaskCardID != 5 {
+		t.Errorf("expected only the text to change, got %+v", saved)
+	}
+}
+
+func TestDelete_AuthorOrEditor(t *testing.T) {
+	uc, repo := newTestUseCase()
+
+	if err := uc.Delete(10, 2); !policy.IsForbidden(err) {
+		t.Errorf("expected forbidden for another commenter, got %v", err)
+	}
+	if err := uc.Delete(10, 1); err != nil {
+		t.Errorf("expected the author to delete, got %v", err)
+	}
+	if err := uc.Delete(10, 3); err != nil {
+		t.Errorf("expected an editor to delete, got %v", err)
+	}
+	if len(repo.deleted) != 2 {
+		t.Errorf("deleted = %v", repo.deleted)
+	}
+}
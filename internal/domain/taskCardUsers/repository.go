@@ -12,6 +12,7 @@ type Repository interface {
 	GetByTaskCardIDAndUserID(taskCardID, userID uint) (*TaskCardUsers, error)
 	Update(taskCardUsers *TaskCardUsers) error
 	Delete(id uint) error
+	FindTaskCardName(taskCardID uint) (string, error)
 }
 
 type repository struct{}
@@ -55,3 +56,12 @@ func (r *repository) Update(taskCardUsers *TaskCardUsers) error {
 func (r *repository) Delete(id uint) error {
 	return database.DB.Delete(&TaskCardUsers{}, id).Error
 }
+
+func (r *repository) FindTaskCardName(taskCardID uint) (string, error) {
+	var names []string
+	err := database.DB.Table("task_cards").Where("id = ?", taskCardID).Limit(1).Pluck("name", &names).Error
+	if err != nil || len(names) == 0 {
+		return "", err
+	}
+	return names[0], nil
+}
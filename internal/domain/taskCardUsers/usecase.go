@@ -2,8 +2,15 @@ package taskCardUsers
 
 import (
 	"errors"
+	"hrm-app/internal/domain/notification"
+	"log"
 )
 
+// Notifier tells users they were assigned (implemented by notification.UseCase)
+type Notifier interface {
+	Notify(userID uint, n notification.Notification)
+}
+
 type UseCase interface {
 	Create(taskCardUsers *TaskCardUsers) error
 	GetByTaskCardID(taskCardID uint) ([]TaskCardUsers, error)
@@ -13,12 +20,14 @@ type UseCase interface {
 }
 
 type usecase struct {
-	repo Repository
+	repo     Repository
+	notifier Notifier
 }
 
-func NewUseCase(repo Repository) UseCase {
+func NewUseCase(repo Repository, notifier Notifier) UseCase {
 	return &usecase{
-		repo: repo,
+		repo:     repo,
+		notifier: notifier,
 	}
 }
 
@@ -37,8 +46,22 @@ func (u *usecase) Create(taskCardUsers *TaskCardUsers) error {
 		return errors.New("user already assigned to this task card")
 	}
 
-	return u.repo.Create(taskCardUsers)
+	if err := u.repo.Create(taskCardUsers); err != nil {
+		return err
+	}
 
+	// The assignment succeeded, a missing card name only skips the notification
+	cardName, err := u.repo.FindTaskCardName(taskCardUsers.TaskCardID)
+	if err != nil {
+		log.Printf("[TaskCardUsers] Failed to find TaskCardID=%d for notification: %v", taskCardUsers.TaskCardID, err)
+		return nil
+	}
+	u.notifier.Notify(taskCardUsers.UserID, notification.Notification{
+		Type: notification.TypeTaskCardAssigned,
+		Args: []interface{}{cardName},
+		Data: map[string]interface{}{"task_card_id": taskCardUsers.TaskCardID},
+	})
+	return nil
 }
 
 func (u *usecase) GetByTaskCardID(taskCardID uint) ([]TaskCardUsers, error) {
@@ -8,7 +8,8 @@ import (
 type TaskTab struct {
 	ID        uint                `json:"id" gorm:"primaryKey"`
 	BoardID   uint                `json:"board_id"`
-	Position  int                 `json:"position"`
+	Position  int                 `json:"position"` // 1-based index on the board, derived from Rank
+	Rank      string              `json:"rank"`
 	Name      string              `json:"name"`
 	TaskCards []taskCard.TaskCard `json:"task_cards" gorm:"foreignKey:TaskTabID"`
 	CreatedAt time.Time           `json:"created_at"`
@@ -32,16 +32,6 @@ func (h *Handler) Create(c *gin.Context) {
 	response.Success(c, taskTab)
 }
 
-func (h *Handler) GetAll(c *gin.Context) {
-	taskTabs, err := h.usecase.FindAll()
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	response.Success(c, taskTabs)
-}
-
 func (h *Handler) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
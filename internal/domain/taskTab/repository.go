@@ -2,6 +2,7 @@ package taskTab
 
 import (
 	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/rank"
 )
 
 type Repository interface {
@@ -9,8 +10,11 @@ type Repository interface {
 	FindAll() ([]TaskTab, error)
 	FindByID(id uint) (*TaskTab, error)
 	FindByBoardID(boardID uint) ([]TaskTab, error)
+	FindRanks(boardID uint) ([]rank.Item, error)
 	CreateBatch(taskTabs []TaskTab) error
 	Update(taskTab *TaskTab) error
+	UpdateRank(id uint, key string) error
+	Reorder(boardID uint) error
 	Delete(id uint) error
 }
 
@@ -38,13 +42,20 @@ func (r *repository) FindByID(id uint) (*TaskTab, error) {
 
 func (r *repository) FindByBoardID(boardID uint) ([]TaskTab, error) {
 	var taskTabs []TaskTab
-	err := database.DB.Where("board_id = ?", boardID).Find(&taskTabs).Error
+	err := database.DB.Where("board_id = ?", boardID).Order("rank, id").Find(&taskTabs).Error
 	return taskTabs, err
 }
 
+// FindRanks lists the tabs of a board in order
+func (r *repository) FindRanks(boardID uint) ([]rank.Item, error) {
+	var items []rank.Item
+	err := database.DB.Model(&TaskTab{}).Select(`id, rank AS "key"`).Where("board_id = ?", boardID).Order("rank, id").Scan(&items).Error
+	return items, err
+}
+
 func (r *repository) FindSummaryByBoardID(boardID uint) ([]TaskTab, error) {
 	var taskTabs []TaskTab
-	err := database.DB.Select("id, board_id, position, name").Where("board_id = ?", boardID).Find(&taskTabs).Error
+	err := database.DB.Select("id, board_id, position, name").Where("board_id = ?", boardID).Order("rank, id").Find(&taskTabs).Error
 	return taskTabs, err
 }
 
@@ -56,6 +67,18 @@ func (r *repository) Update(taskTab *TaskTab) error {
 	return database.DB.Model(&TaskTab{ID: taskTab.ID}).Updates(taskTab).Error
 }
 
+func (r *repository) UpdateRank(id uint, key string) error {
+	return database.DB.Model(&TaskTab{ID: id}).Update("rank", key).Error
+}
+
+// Reorder renumbers the positions of a board's tabs to follow their ranks
+func (r *repository) Reorder(boardID uint) error {
+	return database.DB.Exec(`
+		UPDATE task_tabs t SET position = o.n
+		FROM (SELECT id, row_number() OVER (ORDER BY rank, id) AS n FROM task_tabs WHERE board_id = ?) o
+		WHERE t.id = o.id AND t.position <> o.n`, boardID).Error
+}
+
 func (r *repository) Delete(id uint) error {
 	return database.DB.Delete(&TaskTab{}, id).Error
 }
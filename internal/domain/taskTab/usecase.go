@@ -2,6 +2,8 @@ package taskTab
 
 import (
 	"errors"
+
+	"hrm-app/internal/pkg/rank"
 )
 
 type UseCase interface {
@@ -9,6 +11,7 @@ type UseCase interface {
 	FindAll() ([]TaskTab, error)
 	FindByID(id uint) (*TaskTab, error)
 	Update(taskTab *TaskTab) error
+	Move(id, beforeID, afterID uint) (*TaskTab, error)
 	Delete(id uint) error
 }
 
@@ -24,6 +27,17 @@ func (u *usecase) Create(taskTab *TaskTab) error {
 	if taskTab.Name == "" {
 		return errors.New("name is required")
 	}
+
+	// New tabs go to the end of the board
+	siblings, err := u.repo.FindRanks(taskTab.BoardID)
+	if err != nil {
+		return err
+	}
+	key, err := rank.Place(siblings, 0, 0)
+	if err != nil {
+		return err
+	}
+	taskTab.Rank, taskTab.Position = key, len(siblings)+1
 	return u.repo.Create(taskTab)
 }
 
@@ -35,14 +49,112 @@ func (u *usecase) FindByID(id uint) (*TaskTab, error) {
 	return u.repo.FindByID(id)
 }
 
+// Update saves a tab. A changed position or board moves the tab to that index
+// (the end of a new board by default) and renumbers the others; the rank itself
+// is never taken from the caller.
 func (u *usecase) Update(taskTab *TaskTab) error {
 	// Check if taskTab exists
-	if _, err := u.repo.FindByID(taskTab.ID); err != nil {
+	existing, err := u.repo.FindByID(taskTab.ID)
+	if err != nil {
 		return err
 	}
-	return u.repo.Update(taskTab)
+
+	if taskTab.BoardID == 0 {
+		taskTab.BoardID = existing.BoardID
+	}
+	taskTab.Rank = existing.Rank
+	changedBoard := taskTab.BoardID != existing.BoardID
+	moved := changedBoard || (taskTab.Position != 0 && taskTab.Position != existing.Position)
+	if moved {
+		siblings, err := u.siblings(taskTab)
+		if err != nil {
+			return err
+		}
+		position := taskTab.Position
+		if position == 0 {
+			position = len(siblings) + 1
+		}
+		if taskTab.Rank, err = rank.PlaceAt(siblings, position); err != nil {
+			return err
+		}
+	}
+
+	if err := u.repo.Update(taskTab); err != nil {
+		return err
+	}
+	if !moved {
+		return nil
+	}
+	if changedBoard {
+		if err := u.repo.Reorder(existing.BoardID); err != nil {
+			return err
+		}
+	}
+	return u.reorder(taskTab)
+}
+
+// Move places a tab between its new neighbours beforeID and afterID, either
+// of which may be zero at the ends of the board
+func (u *usecase) Move(id, beforeID, afterID uint) (*TaskTab, error) {
+	taskTab, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	siblings, err := u.siblings(taskTab)
+	if err != nil {
+		return nil, err
+	}
+	key, err := rank.Place(siblings, beforeID, afterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdateRank(id, key); err != nil {
+		return nil, err
+	}
+
+	taskTab.Rank = key
+	if err := u.reorder(taskTab); err != nil {
+		return nil, err
+	}
+	return taskTab, nil
 }
 
 func (u *usecase) Delete(id uint) error {
-	return u.repo.Delete(id)
+	taskTab, err := u.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.Delete(id); err != nil {
+		return err
+	}
+	return u.repo.Reorder(taskTab.BoardID)
+}
+
+// siblings lists the other tabs on the board of taskTab
+func (u *usecase) siblings(taskTab *TaskTab) ([]rank.Item, error) {
+	items, err := u.repo.FindRanks(taskTab.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	siblings := items[:0]
+	for _, item := range items {
+		if item.ID != taskTab.ID {
+			siblings = append(siblings, item)
+		}
+	}
+	return siblings, nil
+}
+
+// reorder renumbers the board's positions and refreshes taskTab's own
+func (u *usecase) reorder(taskTab *TaskTab) error {
+	if err := u.repo.Reorder(taskTab.BoardID); err != nil {
+		return err
+	}
+	fresh, err := u.repo.FindByID(taskTab.ID)
+	if err != nil {
+		return err
+	}
+	taskTab.Position = fresh.Position
+	return nil
 }
@@ -0,0 +1,77 @@
+package template
+
+import "hrm-app/internal/domain/boards"
+
+// builtIns are offered in every workspace and cannot be deleted
+var builtIns = []Template{
+	{
+		Key:         "scrum",
+		Name:        "Scrum",
+		Description: "Plan sprints from a backlog and track work through review",
+		Tabs: []boards.TabBlueprint{
+			{Name: "Backlog", Cards: []boards.CardBlueprint{
+				{Name: "Write user stories", Content: "Capture the next features as user stories with acceptance criteria.", Labels: []boards.LabelBlueprint{{Title: "Story", Color: "#3b82f6"}}},
+			}},
+			{Name: "Sprint Backlog", Cards: []boards.CardBlueprint{
+				{Name: "Sprint planning", Content: "Pick the stories for this sprint and agree on the sprint goal.", Labels: []boards.LabelBlueprint{{Title: "Ceremony", Color: "#8b5cf6"}}},
+			}},
+			{Name: "In Progress"},
+			{Name: "Review"},
+			{Name: "Done"},
+		},
+	},
+	{
+		Key:         "hiring",
+		Name:        "Hiring pipeline",
+		Description: "Move candidates from application to offer",
+		Tabs: []boards.TabBlueprint{
+			{Name: "Applied", Cards: []boards.CardBlueprint{
+				{Name: "Example candidate", Content: "One card per candidate. Attach the CV and note the role applied for.", Labels: []boards.LabelBlueprint{{Title: "Example", Color: "#9ca3af"}}},
+			}},
+			{Name: "Screening"},
+			{Name: "Interview"},
+			{Name: "Offer"},
+			{Name: "Hired"},
+			{Name: "Rejected"},
+		},
+	},
+	{
+		Key:         "onboarding",
+		Name:        "Onboarding",
+		Description: "Walk a new hire through their first weeks",
+		Tabs: []boards.TabBlueprint{
+			{Name: "Before Day One", Cards: []boards.CardBlueprint{
+				{Name: "Prepare equipment", Content: "Order the laptop and set up accounts.", Labels: []boards.LabelBlueprint{{Title: "IT", Color: "#f59e0b"}}},
+				{Name: "Send welcome email", Content: "Share the first day schedule and who to ask for."},
+			}},
+			{Name: "First Day", Cards: []boards.CardBlueprint{
+				{Name: "Office tour and introductions"},
+				{Name: "Sign contract and policies", Labels: []boards.LabelBlueprint{{Title: "HR", Color: "#10b981"}}},
+			}},
+			{Name: "First Week", Cards: []boards.CardBlueprint{
+				{Name: "Meet the team lead", Content: "Agree on goals for the first month."},
+			}},
+			{Name: "First Month"},
+			{Name: "Done"},
+		},
+	},
+}
+
+// BuiltIns returns copies of the built-in templates
+func BuiltIns() []Template {
+	templates := make([]Template, len(builtIns))
+	copy(templates, builtIns)
+	for i := range templates {
+		templates[i].BuiltIn = true
+	}
+	return templates
+}
+
+func findBuiltIn(key string) *Template {
+	for _, template := range BuiltIns() {
+		if template.Key == key {
+			return &template
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package template
+
+import (
+	"time"
+
+	"hrm-app/internal/domain/boards"
+)
+
+// Template is a reusable board layout: built-in ones are keyed by Key, saved
+// ones belong to a workspace
+type Template struct {
+	ID          uint                  `json:"id,omitempty" gorm:"primaryKey"`
+	Key         string                `json:"key,omitempty" gorm:"-"`
+	BuiltIn     bool                  `json:"built_in" gorm:"-"`
+	WorkspaceID uint                  `json:"workspace_id,omitempty"`
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Tabs        []boards.TabBlueprint `json:"tabs" gorm:"column:content;serializer:json"`
+	CreatedBy   *uint                 `json:"created_by,omitempty"`
+	CreatedAt   time.Time             `json:"created_at,omitempty"`
+	UpdatedAt   time.Time             `json:"updated_at,omitempty"`
+}
+
+func (Template) TableName() string {
+	return "board_templates"
+}
+
+// SaveRequest saves a board as a template. Without IncludeCards only the tabs are kept.
+type SaveRequest struct {
+	Name         string `json:"name" binding:"required,max=100"`
+	Description  string `json:"description" binding:"max=500"`
+	IncludeCards bool   `json:"include_cards"`
+}
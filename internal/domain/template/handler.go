@@ -0,0 +1,126 @@
+package template
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/policy"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Auditor records template changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
+type Handler struct {
+	usecase UseCase
+	audit   Auditor
+}
+
+func NewHandler(u UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: u, audit: auditor}
+}
+
+func (h *Handler) GetByWorkspaceID(c *gin.Context) {
+	workspaceID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	templates, err := h.usecase.List(workspaceID, userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response.Success(c, templates)
+}
+
+func (h *Handler) SaveFromBoard(c *gin.Context) {
+	boardID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req SaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	template, err := h.usecase.SaveFromBoard(c.Request.Context(), boardID, userID.(uint), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.audit.Record(audit.FromRequest(c, "save_board_template", audit.EntityBoardTemplate, template.ID).
+		WithWorkspace(template.WorkspaceID).
+		WithBoard(boardID).
+		WithChanges(nil, template))
+	response.Success(c, template)
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	template, err := h.usecase.Delete(id, userID.(uint))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.audit.Record(audit.FromRequest(c, "delete_board_template", audit.EntityBoardTemplate, template.ID).
+		WithWorkspace(template.WorkspaceID).
+		WithChanges(template, nil))
+	response.DeleteSuccess(c, "Template deleted successfully")
+}
+
+func idParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case policy.IsForbidden(err):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrBoardNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,48 @@
+package template
+
+import (
+	"errors"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(template *Template) error
+	FindByID(id uint) (*Template, error)
+	FindByWorkspaceID(workspaceID uint) ([]Template, error)
+	Delete(id uint) error
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) Create(template *Template) error {
+	return database.DB.Create(template).Error
+}
+
+func (r *repository) FindByID(id uint) (*Template, error) {
+	var template Template
+	err := database.DB.Where("id = ?", id).First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *repository) FindByWorkspaceID(workspaceID uint) ([]Template, error) {
+	var templates []Template
+	err := database.DB.Where("workspace_id = ?", workspaceID).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+func (r *repository) Delete(id uint) error {
+	return database.DB.Delete(&Template{}, id).Error
+}
@@ -0,0 +1,125 @@
+package template
+
+import (
+	"context"
+	"errors"
+
+	"hrm-app/internal/domain/boards"
+	"hrm-app/internal/pkg/policy"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound is shared with boards so board creation reports unknown templates the same way
+	ErrNotFound      = boards.ErrTemplateNotFound
+	ErrBoardNotFound = errors.New("board not found")
+)
+
+// BoardRepository loads the board a template is saved from (implemented by boards.Repository)
+type BoardRepository interface {
+	FindByID(ctx context.Context, id uint) (*boards.Boards, error)
+}
+
+type UseCase interface {
+	List(workspaceID, userID uint) ([]Template, error)
+	SaveFromBoard(ctx context.Context, boardID, userID uint, req SaveRequest) (*Template, error)
+	Delete(id, userID uint) (*Template, error)
+	Blueprint(key string, id, workspaceID, userID uint) ([]boards.TabBlueprint, error)
+}
+
+type usecase struct {
+	repo      Repository
+	boardRepo BoardRepository
+	authz     policy.Authorizer
+}
+
+func NewUseCase(repo Repository, boardRepo BoardRepository, authz policy.Authorizer) UseCase {
+	return &usecase{repo: repo, boardRepo: boardRepo, authz: authz}
+}
+
+// List returns the built-in templates followed by those saved in the workspace
+func (u *usecase) List(workspaceID, userID uint) ([]Template, error) {
+	if err := u.authz.AuthorizeWorkspace(workspaceID, userID, policy.ActionView); err != nil {
+		return nil, err
+	}
+
+	saved, err := u.repo.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return append(BuiltIns(), saved...), nil
+}
+
+// SaveFromBoard stores the layout of a board as a template of its workspace.
+// Anyone who may edit the board may save it.
+func (u *usecase) SaveFromBoard(ctx context.Context, boardID, userID uint, req SaveRequest) (*Template, error) {
+	if err := u.authz.AuthorizeBoard(boardID, userID, policy.ActionEdit); err != nil {
+		return nil, err
+	}
+	board, err := u.boardRepo.FindByID(ctx, boardID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBoardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	template := &Template{
+		WorkspaceID: board.WorkspaceID,
+		Name:        req.Name,
+		Description: req.Description,
+		Tabs:        boards.BlueprintOf(board, req.IncludeCards),
+		CreatedBy:   &userID,
+	}
+	if err := u.repo.Create(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Delete removes a saved template. Its creator may delete it while they can
+// still edit the workspace; workspace admins may delete any.
+func (u *usecase) Delete(id, userID uint) (*Template, error) {
+	template, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrNotFound
+	}
+
+	action := policy.ActionUpdate
+	if template.CreatedBy != nil && *template.CreatedBy == userID {
+		action = policy.ActionEdit
+	}
+	if err := u.authz.AuthorizeWorkspace(template.WorkspaceID, userID, action); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Delete(id); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Blueprint resolves a built-in template by key, or a template saved in
+// workspaceID by id. The caller has already been allowed to create the board.
+func (u *usecase) Blueprint(key string, id, workspaceID, userID uint) ([]boards.TabBlueprint, error) {
+	if key != "" {
+		if template := findBuiltIn(key); template != nil {
+			return template.Tabs, nil
+		}
+		return nil, ErrNotFound
+	}
+
+	template, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	// Templates are not shared across workspaces
+	if template == nil || template.WorkspaceID != workspaceID {
+		return nil, ErrNotFound
+	}
+	return template.Tabs, nil
+}
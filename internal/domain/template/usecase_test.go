@@ -0,0 +1,210 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hrm-app/internal/domain/boards"
+	"hrm-app/internal/domain/labels"
+	"hrm-app/internal/domain/taskCard"
+	"hrm-app/internal/domain/taskTab"
+	"hrm-app/internal/pkg/policy"
+)
+
+type mockRepository struct {
+	templates map[uint]*Template
+	nextID    uint
+}
+
+func (m *mockRepository) Create(template *Template) error {
+	m.nextID++
+	template.ID = m.nextID
+	m.templates[template.ID] = template
+	return nil
+}
+
+func (m *mockRepository) FindByID(id uint) (*Template, error) {
+	if template, ok := m.templates[id]; ok {
+		found := *template
+		return &found, nil
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) FindByWorkspaceID(workspaceID uint) ([]Template, error) {
+	var templates []Template
+	for _, template := range m.templates {
+		if template.WorkspaceID == workspaceID {
+			templates = append(templates, *template)
+		}
+	}
+	return templates, nil
+}
+
+func (m *mockRepository) Delete(id uint) error {
+	delete(m.templates, id)
+	return nil
+}
+
+// mockBoardRepository serves board 5 of workspace 1 with two tabs
+type mockBoardRepository struct{}
+
+func (m *mockBoardRepository) FindByID(ctx context.Context, id uint) (*boards.Boards, error) {
+	return &boards.Boards{
+		ID:          id,
+		WorkspaceID: 1,
+		Name:        "Sprint 12",
+		TaskTabs: []taskTab.TaskTab{
+			{Name: "Todo", TaskCards: []taskCard.TaskCard{
+				{Name: "Fix login", Content: "Steps to reproduce", Labels: []labels.TaskCardLabel{{Title: "Bug", Color: "#ef4444"}}},
+			}},
+			{Name: "Done"},
+		},
+	}, nil
+}
+
+// mockPolicyRepository gives every user the same role in workspace 1 and on its boards
+type mockPolicyRepository struct {
+	roles map[uint]policy.Role
+}
+
+func (m *mockPolicyRepository) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	if workspaceID != 1 {
+		return "", nil
+	}
+	return m.roles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	return m.roles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 1, nil
+}
+
+func (m *mockPolicyRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return false, nil
+}
+
+const (
+	adminID  uint = 1
+	memberID uint = 2
+	viewerID uint = 3
+	otherID  uint = 4
+)
+
+func newTestUseCase() (UseCase, *mockRepository) {
+	roles := map[uint]policy.Role{adminID: policy.RoleAdmin, memberID: policy.RoleMember, viewerID: policy.RoleViewer, otherID: policy.RoleMember}
+	repo := &mockRepository{templates: map[uint]*Template{}}
+	return NewUseCase(repo, &mockBoardRepository{}, policy.New(&mockPolicyRepository{roles: roles})), repo
+}
+
+func TestUseCase_SaveFromBoard(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       uint
+		includeCards bool
+		wantCards    int
+		wantErr      error
+	}{
+		{name: "tabs only", userID: memberID},
+		{name: "with cards", userID: memberID, includeCards: true, wantCards: 1},
+		{name: "viewers cannot save", userID: viewerID, wantErr: policy.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newTestUseCase()
+
+			template, err := uc.SaveFromBoard(context.Background(), 5, tt.userID, SaveRequest{Name: "Sprint", IncludeCards: tt.includeCards})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SaveFromBoard() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if template.WorkspaceID != 1 || len(template.Tabs) != 2 || template.Tabs[0].Name != "Todo" {
+				t.Fatalf("SaveFromBoard() = %+v, want the two tabs of board 5 in workspace 1", template)
+			}
+			if got := len(template.Tabs[0].Cards); got != tt.wantCards {
+				t.Errorf("SaveFromBoard() kept %d cards, want %d", got, tt.wantCards)
+			}
+			if tt.wantCards > 0 && template.Tabs[0].Cards[0].Labels[0].Title != "Bug" {
+				t.Errorf("SaveFromBoard() card = %+v, want its label kept", template.Tabs[0].Cards[0])
+			}
+		})
+	}
+}
+
+func TestUseCase_ListAndBlueprint(t *testing.T) {
+	uc, _ := newTestUseCase()
+	saved, err := uc.SaveFromBoard(context.Background(), 5, memberID, SaveRequest{Name: "Sprint"})
+	if err != nil {
+		t.Fatalf("SaveFromBoard() error = %v", err)
+	}
+
+	templates, err := uc.List(1, viewerID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(templates) != len(builtIns)+1 || !templates[0].BuiltIn || templates[len(templates)-1].ID != saved.ID {
+		t.Errorf("List() = %+v, want the built-ins followed by the saved template", templates)
+	}
+	if _, err := uc.List(2, viewerID); !errors.Is(err, policy.ErrNotMember) {
+		t.Errorf("List() of another workspace error = %v, want %v", err, policy.ErrNotMember)
+	}
+
+	for _, key := range []string{"scrum", "hiring", "onboarding"} {
+		tabs, err := uc.Blueprint(key, 0, 1, memberID)
+		if err != nil || len(tabs) == 0 {
+			t.Errorf("Blueprint(%q) = %d tabs, %v, want the built-in tabs", key, len(tabs), err)
+		}
+	}
+	if _, err := uc.Blueprint("kanban", 0, 1, memberID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Blueprint() of an unknown key error = %v, want %v", err, ErrNotFound)
+	}
+	if tabs, err := uc.Blueprint("", saved.ID, 1, memberID); err != nil || len(tabs) != 2 {
+		t.Errorf("Blueprint() of the saved template = %d tabs, %v, want 2", len(tabs), err)
+	}
+	// Saved templates stay in their workspace
+	if _, err := uc.Blueprint("", saved.ID, 2, memberID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Blueprint() from another workspace error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  uint
+		wantErr error
+	}{
+		{name: "creator deletes", userID: memberID},
+		{name: "admin deletes", userID: adminID},
+		{name: "other members cannot", userID: otherID, wantErr: policy.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo := newTestUseCase()
+			saved, err := uc.SaveFromBoard(context.Background(), 5, memberID, SaveRequest{Name: "Sprint"})
+			if err != nil {
+				t.Fatalf("SaveFromBoard() error = %v", err)
+			}
+
+			_, err = uc.Delete(saved.ID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, kept := repo.templates[saved.ID]; kept != (tt.wantErr != nil) {
+				t.Errorf("Delete() kept the template = %v", kept)
+			}
+		})
+	}
+
+	uc, _ := newTestUseCase()
+	if _, err := uc.Delete(99, adminID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() of a missing template error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -5,14 +5,31 @@ import (
 	"time"
 )
 
+// System roles, independent of workspace and board roles
+const (
+	SystemRoleUser  = "user"
+	SystemRoleAdmin = "admin"
+)
+
 type User struct {
-	ID        uint       `json:"id" gorm:"primaryKey"`
-	Username  string     `json:"username,omitempty"`
-	Email     string     `json:"email,omitempty"`
-	Password  string     `json:"-"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Username        string     `json:"username,omitempty"`
+	Email           string     `json:"email,omitempty"`
+	Password        string     `json:"-"`
+	SystemRole      string     `json:"system_role,omitempty" gorm:"default:user"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	DisabledAt      *time.Time `json:"disabled_at,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+func (u *User) IsAdmin() bool {
+	return u.SystemRole == SystemRoleAdmin
+}
+
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
 }
 
 type RegisterRequest struct {
@@ -34,7 +34,7 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully"})
+	c.JSON(http.StatusCreated, gin.H{"message": response.Message(c, "User registered successfully")})
 }
 
 func (h *Handler) GetAll(c *gin.Context) {
@@ -107,5 +107,5 @@ func (h *Handler) Update(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": response.Message(c, "User updated successfully")})
 }
@@ -2,6 +2,7 @@ package user
 
 import (
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 	"hrm-app/internal/pkg/database"
@@ -13,6 +14,7 @@ type Repository interface {
 	FindByID(id uint) (*User, error)
 	FindByEmail(email string) (*User, error)
 	Update(user *User) error
+	MarkEmailVerified(id uint) error
 	Delete(id uint) error
 }
 
@@ -28,20 +30,20 @@ func (r *usersRepository) Create(user *User) error {
 
 func (r *usersRepository) FindAll() ([]User, error) {
 	var users []User
-	err := database.DB.Find(&users).Error
+	err := database.DB.Where("deleted_at IS NULL").Find(&users).Error
 	return users, err
 }
 
 func (r *usersRepository) FindByID(id uint) (*User, error) {
 	var user User
-	err := database.DB.First(&user, id).Error
+	err := database.DB.Where("deleted_at IS NULL").First(&user, id).Error
 
 	return &user, err
 }
 
 func (r *usersRepository) FindByEmail(email string) (*User, error) {
 	var user User
-	err := database.DB.Where("email = ?", email).First(&user).Error
+	err := database.DB.Where("email = ? AND deleted_at IS NULL", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -56,6 +58,12 @@ func (r *usersRepository) Update(user *User) error {
 	return database.DB.Model(&User{ID: user.ID}).Updates(user).Error
 }
 
+func (r *usersRepository) MarkEmailVerified(id uint) error {
+	return database.DB.Model(&User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", time.Now()).Error
+}
+
 func (r *usersRepository) Delete(id uint) error {
 	return database.DB.Delete(&User{}, id).Error
 }
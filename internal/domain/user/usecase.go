@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 
 	"hrm-app/internal/domain/contact"
 	"hrm-app/internal/domain/storage"
@@ -18,17 +19,38 @@ type UseCase interface {
 	Update(ctx context.Context, id uint, req *UpdateRequest) error
 }
 
+// EmailVerifier sends the verification email for a newly registered user
+type EmailVerifier interface {
+	SendVerification(ctx context.Context, user *User) error
+}
+
+// AccountCloser soft-deletes and anonymizes an account (implemented by account.UseCase)
+type AccountCloser interface {
+	Anonymize(userID uint) error
+}
+
+// SettingsInitializer stores the default settings of a new user (implemented by settings.UseCase)
+type SettingsInitializer interface {
+	CreateDefaults(userID uint) error
+}
+
 type usecase struct {
 	repo          Repository
 	contactRepo   contact.Repository
 	uploadService storage.Service
+	verifier      EmailVerifier
+	closer        AccountCloser
+	settings      SettingsInitializer
 }
 
-func NewUseCase(repo Repository, contactRepo contact.Repository, uploadService storage.Service) UseCase {
+func NewUseCase(repo Repository, contactRepo contact.Repository, uploadService storage.Service, verifier EmailVerifier, closer AccountCloser, settings SettingsInitializer) UseCase {
 	return &usecase{
 		repo:          repo,
 		contactRepo:   contactRepo,
 		uploadService: uploadService,
+		verifier:      verifier,
+		closer:        closer,
+		settings:      settings,
 	}
 }
 
@@ -80,6 +102,18 @@ func (u *usecase) Register(ctx context.Context, req *RegisterRequest) error {
 		return err
 	}
 
+	// 4. Default settings
+	if err := u.settings.CreateDefaults(newUser.ID); err != nil {
+		return err
+	}
+
+	// 5. Send verification email. Pending workspace invitations are accepted
+	// once the email is verified. The account exists either way, and the user
+	// can request a new link if this one never arrives.
+	if err := u.verifier.SendVerification(ctx, newUser); err != nil {
+		log.Printf("[Register] Failed to send verification email to UserID=%d: %v", newUser.ID, err)
+	}
+
 	return nil
 }
 
@@ -149,6 +183,9 @@ func (u *usecase) Update(ctx context.Context, id uint, req *UpdateRequest) error
 
 	return nil
 }
+
+// DeleteByID closes the account. Rows referencing the user (messages, comments)
+// block a hard delete, so the user is anonymized instead.
 func (u *usecase) DeleteByID(id uint) error {
-	return u.repo.Delete(id)
+	return u.closer.Anonymize(id)
 }
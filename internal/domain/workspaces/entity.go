@@ -4,13 +4,63 @@ import (
 	"time"
 )
 
+// Privacy decides how people get into a workspace
+const (
+	PrivacyPublic  = "public"  // listed in the directory, anyone can join
+	PrivacyTeam    = "team"    // listed in the directory, admins approve join requests
+	PrivacyPrivate = "private" // invite only
+)
+
 type Workspace struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	CreatedBy uint      `json:"created_by"`
-	PassCode  string    `json:"pass_code"`
-	Name      string    `json:"name"`
-	Privacy   string    `json:"privacy"`
-	JoinLink  string    `json:"join_link"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	CreatedBy  uint       `json:"created_by"`
+	PassCode   string     `json:"pass_code"`
+	Name       string     `json:"name"`
+	Privacy    string     `json:"privacy"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"` // read-only and hidden from lists while set
+	ArchivedBy *uint      `json:"archived_by,omitempty"`
+	TrashedAt  *time.Time `json:"trashed_at,omitempty"` // purged once the retention period has passed
+	TrashedBy  *uint      `json:"trashed_by,omitempty"`
+	PurgeAt    *time.Time `json:"purge_at,omitempty" gorm:"-"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// stateColumns are changed only by archive, trash and restore, never by Create or Update
+var stateColumns = []string{"archived_at", "archived_by", "trashed_at", "trashed_by"}
+
+// DuplicateRequest copies a workspace with its boards, and optionally its members
+type DuplicateRequest struct {
+	Name           string `json:"name"`
+	IncludeMembers bool   `json:"include_members"`
+}
+
+// DirectoryEntry is a workspace as listed in the directory, visible to non-members
+type DirectoryEntry struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Privacy     string    `json:"privacy"`
+	MemberCount int64     `json:"member_count"`
+	IsMember    bool      `json:"is_member"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DirectoryQuery searches the directory by name
+type DirectoryQuery struct {
+	Search  string `form:"q"`
+	Privacy string `form:"privacy" binding:"omitempty,oneof=public team"`
+	Page    int    `form:"page"`
+	Limit   int    `form:"limit"`
+}
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalRows  int64 `json:"total_rows"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type Directory struct {
+	Workspaces []DirectoryEntry `json:"workspaces"`
+	Pagination Pagination       `json:"pagination"`
 }
@@ -1,20 +1,30 @@
 package workspaces
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Auditor records workspace changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
 type Handler struct {
 	usecase UseCase
+	audit   Auditor
 }
 
-func NewHandler(u UseCase) *Handler {
-	return &Handler{usecase: u}
+func NewHandler(u UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: u, audit: auditor}
 }
 
 func (h *Handler) Create(c *gin.Context) {
@@ -38,6 +48,8 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
+	h.audit.Record(audit.FromRequest(c, "create_workspace", audit.EntityWorkspace, workspace.ID).WithWorkspace(workspace.ID).WithChanges(nil, &workspace))
+
 	response.Success(c, workspace)
 }
 
@@ -91,11 +103,30 @@ func (h *Handler) Update(c *gin.Context) {
 
 	workspace.ID = uint(id)
 
-	if err := h.usecase.Update(&workspace); err != nil {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	before, _ := h.usecase.GetByID(workspace.ID, userID.(uint))
+
+	if err := h.usecase.Update(&workspace, userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	entry := audit.FromRequest(c, "update_workspace", audit.EntityWorkspace, workspace.ID).WithWorkspace(workspace.ID)
+	if after, err := h.usecase.GetByID(workspace.ID, userID.(uint)); err == nil && before != nil {
+		entry = entry.WithChanges(before, after)
+	}
+	h.audit.Record(entry)
+
 	response.Success(c, workspace)
 }
 
@@ -146,10 +177,173 @@ func (h *Handler) Delete(c *gin.Context) {
 		return
 	}
 
+	before, _ := h.usecase.GetByID(uint(id), userID.(uint))
+
 	if err := h.usecase.DeleteByID(uint(id), userID.(uint)); err != nil {
+		respondStateError(c, err)
+		return
+	}
+
+	h.audit.Record(audit.FromRequest(c, "delete_workspace", audit.EntityWorkspace, uint(id)).WithWorkspace(uint(id)).WithChanges(before, nil))
+	response.DeleteSuccess(c, "Workspace moved to the trash")
+}
+
+// Directory lists public and team workspaces. Search with ?q=, narrow with ?privacy=.
+func (h *Handler) Directory(c *gin.Context) {
+	var query DirectoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	directory, err := h.usecase.Directory(userID.(uint), query)
+	if err != nil {
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	response.DeleteSuccess(c, "Workspace deleted successfully")
+	response.Success(c, directory)
+}
+
+// JoinPublic joins a public workspace from the directory
+func (h *Handler) JoinPublic(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	membership, err := h.usecase.JoinPublic(uint(id), userID.(uint))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotPublic):
+			response.Error(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, workspacesUsers.ErrAlreadyMember):
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	h.audit.Record(audit.FromRequest(c, "join_workspace", audit.EntityWorkspaceUser, membership.ID).WithWorkspace(membership.WorkspaceID).WithChanges(nil, membership))
+	response.Success(c, membership)
+}
+
+// GetArchived lists the user's archived workspaces, which GetByUserID leaves out
+func (h *Handler) GetArchived(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	workspaces, err := h.usecase.GetArchived(userID.(uint))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, workspaces)
+}
+
+// GetTrash lists the trashed workspaces the user owns
+func (h *Handler) GetTrash(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	workspaces, err := h.usecase.GetTrash(userID.(uint))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, workspaces)
+}
+
+func (h *Handler) Archive(c *gin.Context) {
+	h.changeState(c, "archive_workspace", h.usecase.Archive)
+}
+
+func (h *Handler) Unarchive(c *gin.Context) {
+	h.changeState(c, "unarchive_workspace", h.usecase.Unarchive)
+}
+
+func (h *Handler) Restore(c *gin.Context) {
+	h.changeState(c, "restore_workspace", h.usecase.Restore)
+}
+
+func (h *Handler) Duplicate(c *gin.Context) {
+	var req DuplicateRequest
+	// Every field is optional, so an empty body is fine
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	h.changeState(c, "duplicate_workspace", func(id, userID uint) (*Workspace, error) {
+		return h.usecase.Duplicate(id, userID, req)
+	})
+}
+
+// changeState runs an archive, trash or copy action on the workspace in the :id
+// param and audits the workspace it returns
+func (h *Handler) changeState(c *gin.Context, action string, run func(id, userID uint) (*Workspace, error)) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	workspace, err := run(uint(id), userID.(uint))
+	if err != nil {
+		respondStateError(c, err)
+		return
+	}
+
+	h.audit.Record(audit.FromRequest(c, action, audit.EntityWorkspace, workspace.ID).WithWorkspace(workspace.ID).WithChanges(nil, workspace))
+	response.Success(c, workspace)
+}
+
+func respondStateError(c *gin.Context, err error) {
+	switch {
+	case policy.IsForbidden(err):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrAlreadyArchived), errors.Is(err, ErrNotArchived), errors.Is(err, ErrTrashed), errors.Is(err, ErrNotTrashed):
+		response.Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrRestoreExpired):
+		response.Error(c, http.StatusGone, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
 }
@@ -0,0 +1,42 @@
+package workspaces
+
+import (
+	"log"
+	"time"
+
+	"hrm-app/config"
+)
+
+// defaultPurgeInterval applies when workspace.purge_interval_minute is unset
+const defaultPurgeInterval = time.Hour
+
+// Purger periodically deletes workspaces whose trash retention has run out
+type Purger struct {
+	usecase  UseCase
+	interval time.Duration
+}
+
+func NewPurger(u UseCase, cfg *config.Config) *Purger {
+	interval := time.Duration(cfg.Workspace.PurgeIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+	return &Purger{usecase: u, interval: interval}
+}
+
+// Run purges once at startup and then on every tick. It never returns, so
+// start it in its own goroutine.
+func (p *Purger) Run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.usecase.PurgeExpired()
+		if err != nil {
+			log.Printf("[Workspace] Purge failed: %v", err)
+		} else if purged > 0 {
+			log.Printf("[Workspace] Purged %d workspace(s) from the trash", purged)
+		}
+		<-ticker.C
+	}
+}
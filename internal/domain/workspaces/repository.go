@@ -1,7 +1,15 @@
 package workspaces
 
 import (
+	"strings"
+	"time"
+
+	"hrm-app/internal/domain/boards"
+	"hrm-app/internal/domain/workspacesUsers"
 	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/policy"
+
+	"gorm.io/gorm"
 )
 
 type Repository interface {
@@ -14,6 +22,14 @@ type Repository interface {
 	FindGuestWorkspaces(userID uint) ([]Workspace, error)
 	Update(workspace *Workspace) error
 	Delete(id uint) error
+	FindListed(query DirectoryQuery, userID uint) ([]DirectoryEntry, int64, error)
+	Archive(id, userID uint) error
+	Unarchive(id uint) error
+	Trash(id, userID uint) error
+	Restore(id uint) error
+	FindTrashed(userID uint) ([]Workspace, error)
+	FindTrashedBefore(cutoff time.Time) ([]uint, error)
+	Duplicate(sourceID uint, workspace *Workspace, includeMembers bool) error
 }
 
 type repository struct{}
@@ -41,7 +57,10 @@ func (r *repository) FindByID(id uint) (*Workspace, error) {
 			"pass_code",
 			"name",
 			"privacy",
-			"join_link",
+			"archived_at",
+			"archived_by",
+			"trashed_at",
+			"trashed_by",
 			"created_at",
 			"updated_at",
 		).
@@ -53,8 +72,8 @@ func (r *repository) FindByID(id uint) (*Workspace, error) {
 func (r *repository) FindByUserID(userID uint) ([]Workspace, error) {
 	var workspaces []Workspace
 	err := database.DB.
-		Select("id", "created_by", "pass_code", "name", "privacy", "join_link", "created_at", "updated_at").
-		Where("created_by = ?", userID).
+		Select("id", "created_by", "pass_code", "name", "privacy", "created_at", "updated_at").
+		Where("created_by = ? AND archived_at IS NULL AND trashed_at IS NULL", userID).
 		Find(&workspaces).Error
 	return workspaces, err
 }
@@ -62,8 +81,8 @@ func (r *repository) FindByUserID(userID uint) ([]Workspace, error) {
 func (r *repository) FindByIDs(ids []uint) ([]Workspace, error) {
 	var workspaces []Workspace
 	err := database.DB.
-		Select("id", "created_by", "pass_code", "name", "privacy", "join_link", "created_at", "updated_at").
-		Where("id IN ?", ids).
+		Select("id", "created_by", "pass_code", "name", "privacy", "created_at", "updated_at").
+		Where("id IN ? AND trashed_at IS NULL", ids).
 		Find(&workspaces).Error
 	return workspaces, err
 }
@@ -73,7 +92,7 @@ func (r *repository) FindByUserAccess(userID uint) ([]Workspace, error) {
 	err := database.DB.Table("workspaces").
 		Select("DISTINCT workspaces.*").
 		Joins("LEFT JOIN workspaces_users ON workspaces_users.workspace_id = workspaces.id").
-		Where("workspaces.created_by = ? OR workspaces_users.user_id = ?", userID, userID).
+		Where("(workspaces.created_by = ? OR workspaces_users.user_id = ?) AND workspaces.trashed_at IS NULL", userID, userID).
 		Find(&workspaces).Error
 	return workspaces, err
 }
@@ -84,14 +103,165 @@ func (r *repository) FindGuestWorkspaces(userID uint) ([]Workspace, error) {
 		Select("DISTINCT workspaces.*").
 		Joins("INNER JOIN workspaces_users ON workspaces_users.workspace_id = workspaces.id").
 		Where("workspaces_users.user_id = ? AND workspaces.created_by != ?", userID, userID).
+		Where("workspaces.archived_at IS NULL AND workspaces.trashed_at IS NULL").
 		Find(&workspaces).Error
 	return workspaces, err
 }
 
 func (r *repository) Update(workspace *Workspace) error {
-	return database.DB.Save(workspace).Error
+	return database.DB.Omit(stateColumns...).Save(workspace).Error
 }
 
+// Delete removes the workspace and everything in it in one transaction. Most
+// foreign keys are ON DELETE RESTRICT, so children go first: card rows, cards,
+// tabs, board members, boards, then room members and messages, rooms and
+// workspace members. Invitations, invite links, ownership transfers, join
+// requests and board import jobs cascade with the workspace, board activity
+// with its board. Audit rows are kept.
 func (r *repository) Delete(id uint) error {
-	return database.DB.Delete(&Workspace{}, id).Error
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		boards := tx.Table("boards").Select("id").Where("workspace_id = ?", id)
+		tabs := tx.Table("task_tabs").Select("id").Where("board_id IN (?)", boards)
+		cards := tx.Table("task_cards").Select("id").Where("task_tab_id IN (?)", tabs)
+		rooms := tx.Table("rooms_chats").Select("id").Where("workspace_id = ?", id)
+
+		steps := []struct {
+			table string
+			where string
+			arg   interface{}
+		}{
+			{"task_card_users", "task_card_id IN (?)", cards},
+			{"task_card_labels", "task_card_id IN (?)", cards},
+			{"task_card_comments", "task_card_id IN (?)", cards},
+			{"task_card_attachments", "task_card_id IN (?)", cards},
+			{"task_cards", "task_tab_id IN (?)", tabs},
+			{"task_tabs", "board_id IN (?)", boards},
+			{"boards_users", "board_id IN (?)", boards},
+			{"boards", "workspace_id = ?", id},
+			{"room_users", "room_id IN (?)", rooms},
+			{"room_messages", "room_id IN (?)", rooms},
+			{"rooms_chats", "workspace_id = ?", id},
+			{"workspaces_users", "workspace_id = ?", id},
+		}
+		for _, step := range steps {
+			if err := tx.Exec("DELETE FROM "+step.table+" WHERE "+step.where, step.arg).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&Workspace{}, id).Error
+	})
+}
+
+// Duplicate creates workspace as a copy of sourceID in one transaction: every
+// board is deep copied with boards.Clone, and with includeMembers the members
+// of the workspace and its boards come along. workspace.CreatedBy owns the copy.
+func (r *repository) Duplicate(sourceID uint, workspace *Workspace, includeMembers bool) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(stateColumns...).Create(workspace).Error; err != nil {
+			return err
+		}
+
+		members := []workspacesUsers.WorkspacesUsers{{WorkspaceID: workspace.ID, UserID: workspace.CreatedBy, Role: policy.RoleOwner}}
+		if includeMembers {
+			var sourceMembers []workspacesUsers.WorkspacesUsers
+			if err := tx.Where("workspace_id = ? AND user_id <> ?", sourceID, workspace.CreatedBy).Order("id").Find(&sourceMembers).Error; err != nil {
+				return err
+			}
+			for _, member := range sourceMembers {
+				// The copy has a single owner, the one who made it
+				role := member.Role
+				if role == policy.RoleOwner {
+					role = policy.RoleAdmin
+				}
+				members = append(members, workspacesUsers.WorkspacesUsers{WorkspaceID: workspace.ID, UserID: member.UserID, Role: role})
+			}
+		}
+		if err := tx.Create(&members).Error; err != nil {
+			return err
+		}
+
+		var sourceBoards []boards.Boards
+		if err := tx.Select("id", "name", "images").Where("workspace_id = ?", sourceID).Order("id").Find(&sourceBoards).Error; err != nil {
+			return err
+		}
+		for _, source := range sourceBoards {
+			board := &boards.Boards{WorkspaceID: workspace.ID, CreatedBy: workspace.CreatedBy, Name: source.Name, Images: source.Images}
+			if err := boards.Clone(tx, source.ID, board, includeMembers); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) Archive(id, userID uint) error {
+	return r.setState(id, map[string]interface{}{"archived_at": time.Now(), "archived_by": userID})
+}
+
+func (r *repository) Unarchive(id uint) error {
+	return r.setState(id, map[string]interface{}{"archived_at": nil, "archived_by": nil})
+}
+
+func (r *repository) Trash(id, userID uint) error {
+	return r.setState(id, map[string]interface{}{"trashed_at": time.Now(), "trashed_by": userID})
+}
+
+func (r *repository) Restore(id uint) error {
+	return r.setState(id, map[string]interface{}{"trashed_at": nil, "trashed_by": nil})
+}
+
+// FindTrashed lists the trashed workspaces the user owns, most recent first
+func (r *repository) FindTrashed(userID uint) ([]Workspace, error) {
+	var workspaces []Workspace
+	err := database.DB.Table("workspaces").
+		Select("workspaces.*").
+		Joins("JOIN workspaces_users ON workspaces_users.workspace_id = workspaces.id").
+		Where("workspaces_users.user_id = ? AND workspaces_users.role = ?", userID, "owner").
+		Where("workspaces.trashed_at IS NOT NULL").
+		Order("workspaces.trashed_at DESC").
+		Find(&workspaces).Error
+	return workspaces, err
+}
+
+// FindTrashedBefore returns the ids of workspaces trashed before cutoff, due for purging
+func (r *repository) FindTrashedBefore(cutoff time.Time) ([]uint, error) {
+	var ids []uint
+	err := database.DB.Table("workspaces").
+		Where("trashed_at IS NOT NULL AND trashed_at < ?", cutoff).
+		Order("trashed_at").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *repository) setState(id uint, columns map[string]interface{}) error {
+	return database.DB.Model(&Workspace{}).Where("id = ?", id).Updates(columns).Error
+}
+
+// FindListed searches the public and team workspaces shown in the directory
+func (r *repository) FindListed(query DirectoryQuery, userID uint) ([]DirectoryEntry, int64, error) {
+	db := database.DB.Table("workspaces").Where("workspaces.archived_at IS NULL AND workspaces.trashed_at IS NULL")
+	if query.Privacy != "" {
+		db = db.Where("workspaces.privacy = ?", query.Privacy)
+	} else {
+		db = db.Where("workspaces.privacy IN ?", []string{PrivacyPublic, PrivacyTeam})
+	}
+	if search := strings.TrimSpace(query.Search); search != "" {
+		db = db.Where("workspaces.name ILIKE ?", "%"+search+"%")
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []DirectoryEntry
+	err := db.Select(`workspaces.id, workspaces.name, workspaces.privacy, workspaces.created_at,
+		(SELECT COUNT(*) FROM workspaces_users wu WHERE wu.workspace_id = workspaces.id) AS member_count,
+		EXISTS (SELECT 1 FROM workspaces_users wu WHERE wu.workspace_id = workspaces.id AND wu.user_id = ?) AS is_member`, userID).
+		Order("LOWER(workspaces.name), workspaces.id").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Scan(&entries).Error
+	return entries, total, err
 }
@@ -1,7 +1,6 @@
 package workspaces
 
 import (
-	"hrm-app/internal/domain/boardsUsers"
 	"hrm-app/internal/domain/workspacesUsers"
 )
 
@@ -23,29 +22,8 @@ func (a *repositoryAdapter) FindByID(id uint) (*workspacesUsers.WorkspaceInfo, e
 
 	return &workspacesUsers.WorkspaceInfo{
 		ID:        workspace.ID,
+		Name:      workspace.Name,
 		CreatedBy: workspace.CreatedBy,
-		PassCode:  workspace.PassCode,
-	}, nil
-}
-
-// boardWorkspaceRepositoryAdapter adapts the workspace Repository to the boardsUsers.WorkspaceRepository interface
-type boardWorkspaceRepositoryAdapter struct {
-	repo Repository
-}
-
-// NewBoardWorkspaceRepositoryAdapter creates an adapter that implements boardsUsers.WorkspaceRepository
-func NewBoardWorkspaceRepositoryAdapter(repo Repository) boardsUsers.WorkspaceRepository {
-	return &boardWorkspaceRepositoryAdapter{repo: repo}
-}
-
-func (a *boardWorkspaceRepositoryAdapter) FindByID(id uint) (*boardsUsers.WorkspaceInfo, error) {
-	workspace, err := a.repo.FindByID(id)
-	if err != nil {
-		return nil, err
-	}
-
-	return &boardsUsers.WorkspaceInfo{
-		ID:       workspace.ID,
-		PassCode: workspace.PassCode,
+		Privacy:   workspace.Privacy,
 	}, nil
 }
@@ -2,9 +2,12 @@ package workspaces
 
 import (
 	"errors"
-	"fmt"
+	"log"
+	"time"
+
 	"hrm-app/config"
 	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/pkg/utils"
 )
 
@@ -15,23 +18,48 @@ type UseCase interface {
 	GetByUserID(userID uint) ([]Workspace, error)
 	GetGuestWorkspaces(userID uint) ([]Workspace, error)
 	DeleteByID(id, userID uint) error
-	Update(workspace *Workspace) error
+	Update(workspace *Workspace, userID uint) error
+	Directory(userID uint, query DirectoryQuery) (*Directory, error)
+	JoinPublic(workspaceID, userID uint) (*workspacesUsers.WorkspacesUsers, error)
+	GetArchived(userID uint) ([]Workspace, error)
+	Archive(id, userID uint) (*Workspace, error)
+	Unarchive(id, userID uint) (*Workspace, error)
+	GetTrash(userID uint) ([]Workspace, error)
+	Restore(id, userID uint) (*Workspace, error)
+	PurgeExpired() (int, error)
+	Duplicate(id, userID uint, req DuplicateRequest) (*Workspace, error)
 }
 
+var (
+	ErrNotPublic       = errors.New("only public workspaces can be joined directly, request to join a team workspace or ask for an invite")
+	ErrNotFound        = errors.New("workspace not found")
+	ErrAlreadyArchived = errors.New("workspace is already archived")
+	ErrNotArchived     = errors.New("workspace is not archived")
+	ErrTrashed         = errors.New("workspace is in the trash, restore it first")
+	ErrNotTrashed      = errors.New("workspace is not in the trash")
+	ErrRestoreExpired  = errors.New("workspace has been in the trash too long to restore")
+)
+
+// defaultTrashRetentionDays applies when workspace.trash_retention_day is unset
+const defaultTrashRetentionDays = 30
+
 type usecase struct {
 	repo              Repository
 	workSpaceUserRepo workspacesUsers.Repository
+	authz             policy.Authorizer
 	cfg               *config.Config
 }
 
-func NewUseCase(repo Repository, workSpaceUserRepo workspacesUsers.Repository, cfg *config.Config) UseCase {
-	return &usecase{repo: repo, workSpaceUserRepo: workSpaceUserRepo, cfg: cfg}
+func NewUseCase(repo Repository, workSpaceUserRepo workspacesUsers.Repository, authz policy.Authorizer, cfg *config.Config) UseCase {
+	return &usecase{repo: repo, workSpaceUserRepo: workSpaceUserRepo, authz: authz, cfg: cfg}
 }
 
 func (u *usecase) Create(workspace *Workspace) error {
-	if workspace.Privacy != "public" && workspace.Privacy != "private" && workspace.Privacy != "team" {
+	if !validPrivacy(workspace.Privacy) {
 		return errors.New("privacy must be either 'public', 'private', or 'team'")
 	}
+	workspace.ArchivedAt, workspace.ArchivedBy = nil, nil
+	workspace.TrashedAt, workspace.TrashedBy = nil, nil
 
 	workspace.PassCode = utils.GeneratePassCode(6)
 
@@ -39,18 +67,11 @@ func (u *usecase) Create(workspace *Workspace) error {
 		return err
 	}
 
-	// Generate Join Token and update JoinLink
-	token, err := utils.GenerateJoinToken(u.cfg, workspace.ID, "workspace", workspace.PassCode)
-	if err == nil {
-		// Example URL, you can adjust the base URL as needed
-		workspace.JoinLink = fmt.Sprintf("http://be.putratek.my.id/api/v1/workspaces/join?token=%s", token)
-		_ = u.repo.Update(workspace)
-	}
-
-	// Add creator to workspace users
+	// Add creator to workspace users as owner
 	workspaceUsers := &workspacesUsers.WorkspacesUsers{
 		WorkspaceID: workspace.ID,
 		UserID:      workspace.CreatedBy,
+		Role:        policy.RoleOwner,
 	}
 
 	return u.workSpaceUserRepo.Create(workspaceUsers)
@@ -75,30 +96,285 @@ func (u *usecase) GetByID(id, userID uint) (*Workspace, error) {
 	return nil, errors.New("unauthorized: you are not a member of this workspace or workspace not found")
 }
 
+// GetByUserID lists the user's workspaces, leaving out archived ones
 func (u *usecase) GetByUserID(userID uint) ([]Workspace, error) {
-	return u.repo.FindByUserAccess(userID)
+	workspaces, err := u.repo.FindByUserAccess(userID)
+	if err != nil {
+		return nil, err
+	}
+	return filterArchived(workspaces, false), nil
+}
+
+func (u *usecase) GetArchived(userID uint) ([]Workspace, error) {
+	workspaces, err := u.repo.FindByUserAccess(userID)
+	if err != nil {
+		return nil, err
+	}
+	return filterArchived(workspaces, true), nil
 }
 
 func (u *usecase) GetGuestWorkspaces(userID uint) ([]Workspace, error) {
 	return u.repo.FindGuestWorkspaces(userID)
 }
 
+// DeleteByID moves the workspace to the trash. Owners can restore it until
+// the retention period ends and the purge job deletes it for good.
 func (u *usecase) DeleteByID(id, userID uint) error {
 	workspace, err := u.repo.FindByID(id)
 	if err != nil || workspace == nil {
-		return errors.New("workspace not found")
+		return ErrNotFound
 	}
 
-	if workspace.CreatedBy != userID {
-		return errors.New("unauthorized: only the workspace creator can delete this workspace")
+	// Archived workspaces are read-only to policy, but may still be trashed
+	if err := u.authorizeRole(id, userID, policy.ActionDelete); err != nil {
+		return err
+	}
+	if workspace.TrashedAt != nil {
+		return nil
 	}
 
-	return u.repo.Delete(id)
+	return u.repo.Trash(id, userID)
 }
 
-func (u *usecase) Update(workspace *Workspace) error {
-	if workspace.Privacy != "public" && workspace.Privacy != "private" && workspace.Privacy != "team" {
+func (u *usecase) Update(workspace *Workspace, userID uint) error {
+	if !validPrivacy(workspace.Privacy) {
 		return errors.New("privacy must be either 'public', 'private', or 'team'")
 	}
+
+	if err := u.authz.AuthorizeWorkspace(workspace.ID, userID, policy.ActionUpdate); err != nil {
+		return err
+	}
+
 	return u.repo.Update(workspace)
 }
+
+// Directory lists the workspaces outsiders can find: public ones to join and
+// team ones to request. Private workspaces are never listed.
+func (u *usecase) Directory(userID uint, query DirectoryQuery) (*Directory, error) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 {
+		query.Limit = 20
+	}
+	if query.Limit > 100 {
+		query.Limit = 100
+	}
+
+	entries, total, err := u.repo.FindListed(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []DirectoryEntry{}
+	}
+
+	return &Directory{
+		Workspaces: entries,
+		Pagination: Pagination{
+			Page:       query.Page,
+			Limit:      query.Limit,
+			TotalRows:  total,
+			TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+		},
+	}, nil
+}
+
+// JoinPublic adds the user to a public workspace as a member, no invite needed
+func (u *usecase) JoinPublic(workspaceID, userID uint) (*workspacesUsers.WorkspacesUsers, error) {
+	workspace, err := u.repo.FindByID(workspaceID)
+	if err != nil || workspace == nil {
+		return nil, errors.New("workspace not found")
+	}
+	if workspace.Privacy != PrivacyPublic || workspace.ArchivedAt != nil || workspace.TrashedAt != nil {
+		return nil, ErrNotPublic
+	}
+
+	existing, _ := u.workSpaceUserRepo.GetByWorkspaceIDAndUserID(workspaceID, userID)
+	if existing != nil && existing.ID != 0 {
+		return nil, workspacesUsers.ErrAlreadyMember
+	}
+
+	member := &workspacesUsers.WorkspacesUsers{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        policy.RoleMember,
+	}
+	if err := u.workSpaceUserRepo.Create(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Archive makes the workspace read-only and hides it from lists and the directory
+func (u *usecase) Archive(id, userID uint) (*Workspace, error) {
+	workspace, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorizeRole(id, userID, policy.ActionUpdate); err != nil {
+		return nil, err
+	}
+	if workspace.TrashedAt != nil {
+		return nil, ErrTrashed
+	}
+	if workspace.ArchivedAt != nil {
+		return nil, ErrAlreadyArchived
+	}
+
+	if err := u.repo.Archive(id, userID); err != nil {
+		return nil, err
+	}
+	return u.find(id)
+}
+
+func (u *usecase) Unarchive(id, userID uint) (*Workspace, error) {
+	workspace, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorizeRole(id, userID, policy.ActionUpdate); err != nil {
+		return nil, err
+	}
+	if workspace.TrashedAt != nil {
+		return nil, ErrTrashed
+	}
+	if workspace.ArchivedAt == nil {
+		return nil, ErrNotArchived
+	}
+
+	if err := u.repo.Unarchive(id); err != nil {
+		return nil, err
+	}
+	return u.find(id)
+}
+
+// GetTrash lists the trashed workspaces the user owns, with the time each one is purged
+func (u *usecase) GetTrash(userID uint) ([]Workspace, error) {
+	workspaces, err := u.repo.FindTrashed(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range workspaces {
+		purgeAt := workspaces[i].TrashedAt.Add(u.retention())
+		workspaces[i].PurgeAt = &purgeAt
+	}
+	return workspaces, nil
+}
+
+// Restore takes the workspace out of the trash within the retention period
+func (u *usecase) Restore(id, userID uint) (*Workspace, error) {
+	workspace, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorizeRole(id, userID, policy.ActionDelete); err != nil {
+		return nil, err
+	}
+	if workspace.TrashedAt == nil {
+		return nil, ErrNotTrashed
+	}
+	if time.Since(*workspace.TrashedAt) > u.retention() {
+		return nil, ErrRestoreExpired
+	}
+
+	if err := u.repo.Restore(id); err != nil {
+		return nil, err
+	}
+	return u.find(id)
+}
+
+// PurgeExpired deletes every workspace that has outlived the trash retention
+// period. A workspace that fails is logged and retried on the next run.
+func (u *usecase) PurgeExpired() (int, error) {
+	ids, err := u.repo.FindTrashedBefore(time.Now().Add(-u.retention()))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := u.repo.Delete(id); err != nil {
+			log.Printf("[Workspace] Failed to purge workspace %d: %v", id, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// Duplicate copies the workspace with all of its boards. Only admins may, as
+// they are the ones who can see every board. Archived workspaces can be copied;
+// the copy starts out active.
+func (u *usecase) Duplicate(id, userID uint, req DuplicateRequest) (*Workspace, error) {
+	source, err := u.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.authorizeRole(id, userID, policy.ActionUpdate); err != nil {
+		return nil, err
+	}
+	if source.TrashedAt != nil {
+		return nil, ErrTrashed
+	}
+
+	if req.Name == "" {
+		req.Name = source.Name + " (copy)"
+	}
+	workspace := &Workspace{
+		CreatedBy: userID,
+		PassCode:  utils.GeneratePassCode(6),
+		Name:      req.Name,
+		Privacy:   source.Privacy,
+	}
+	if err := u.repo.Duplicate(id, workspace, req.IncludeMembers); err != nil {
+		return nil, err
+	}
+	return u.find(workspace.ID)
+}
+
+func (u *usecase) find(id uint) (*Workspace, error) {
+	workspace, err := u.repo.FindByID(id)
+	if err != nil || workspace == nil {
+		return nil, ErrNotFound
+	}
+	return workspace, nil
+}
+
+// authorizeRole checks the caller's role only. Archive, trash and restore act
+// on workspaces that policy already treats as read-only.
+func (u *usecase) authorizeRole(workspaceID, userID uint, action policy.Action) error {
+	role, err := u.authz.WorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Valid() {
+		return policy.ErrNotMember
+	}
+	if !policy.Can(role, action) {
+		return policy.ErrForbidden
+	}
+	return nil
+}
+
+func (u *usecase) retention() time.Duration {
+	days := u.cfg.Workspace.TrashRetentionDays
+	if days == 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func filterArchived(workspaces []Workspace, archived bool) []Workspace {
+	filtered := make([]Workspace, 0, len(workspaces))
+	for _, w := range workspaces {
+		if (w.ArchivedAt != nil) == archived {
+			filtered = append(filtered, w)
+		}
+	}
+	return filtered
+}
+
+func validPrivacy(privacy string) bool {
+	return privacy == PrivacyPublic || privacy == PrivacyPrivate || privacy == PrivacyTeam
+}
@@ -1,10 +1,13 @@
 package workspaces
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"hrm-app/config"
 	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
 )
 
 // mockRepository is a mock implementation of the Repository interface
@@ -14,6 +17,14 @@ type mockRepository struct {
 	findByIDFunc            func(id uint) (*Workspace, error)
 	deleteFunc              func(id uint) error
 	findGuestWorkspacesFunc func(userID uint) ([]Workspace, error)
+	findListedFunc          func(query DirectoryQuery, userID uint) ([]DirectoryEntry, int64, error)
+	findByUserAccessFunc    func(userID uint) ([]Workspace, error)
+	archiveFunc             func(id, userID uint) error
+	unarchiveFunc           func(id uint) error
+	trashFunc               func(id, userID uint) error
+	restoreFunc             func(id uint) error
+	findTrashedBeforeFunc   func(cutoff time.Time) ([]uint, error)
+	duplicateFunc           func(sourceID uint, workspace *Workspace, includeMembers bool) error
 }
 
 func (m *mockRepository) Create(workspace *Workspace) error {
@@ -43,6 +54,9 @@ func (m *mockRepository) FindByIDs(ids []uint) ([]Workspace, error) {
 }
 
 func (m *mockRepository) FindByUserAccess(userID uint) ([]Workspace, error) {
+	if m.findByUserAccessFunc != nil {
+		return m.findByUserAccessFunc(userID)
+	}
 	return nil, nil
 }
 
@@ -67,6 +81,59 @@ func (m *mockRepository) Delete(id uint) error {
 	return nil
 }
 
+func (m *mockRepository) FindListed(query DirectoryQuery, userID uint) ([]DirectoryEntry, int64, error) {
+	if m.findListedFunc != nil {
+		return m.findListedFunc(query, userID)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockRepository) Archive(id, userID uint) error {
+	if m.archiveFunc != nil {
+		return m.archiveFunc(id, userID)
+	}
+	return nil
+}
+
+func (m *mockRepository) Unarchive(id uint) error {
+	if m.unarchiveFunc != nil {
+		return m.unarchiveFunc(id)
+	}
+	return nil
+}
+
+func (m *mockRepository) Trash(id, userID uint) error {
+	if m.trashFunc != nil {
+		return m.trashFunc(id, userID)
+	}
+	return nil
+}
+
+func (m *mockRepository) Restore(id uint) error {
+	if m.restoreFunc != nil {
+		return m.restoreFunc(id)
+	}
+	return nil
+}
+
+func (m *mockRepository) FindTrashed(userID uint) ([]Workspace, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) FindTrashedBefore(cutoff time.Time) ([]uint, error) {
+	if m.findTrashedBeforeFunc != nil {
+		return m.findTrashedBeforeFunc(cutoff)
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) Duplicate(sourceID uint, workspace *Workspace, includeMembers bool) error {
+	if m.duplicateFunc != nil {
+		return m.duplicateFunc(sourceID, workspace, includeMembers)
+	}
+	return nil
+}
+
 type mockWorkspacesUsersRepository struct {
 	createFunc func(wu *workspacesUsers.WorkspacesUsers) error
 }
@@ -102,6 +169,51 @@ func (m *mockWorkspacesUsersRepository) Update(wu *workspacesUsers.WorkspacesUse
 	return nil
 }
 
+func (m *mockWorkspacesUsersRepository) Leave(workspaceID, userID uint) error {
+	return nil
+}
+
+func (m *mockWorkspacesUsersRepository) CreateTransfer(transfer *workspacesUsers.OwnershipTransfer) error {
+	return nil
+}
+
+func (m *mockWorkspacesUsersRepository) FindPendingTransfer(workspaceID uint) (*workspacesUsers.OwnershipTransfer, error) {
+	return nil, nil
+}
+
+func (m *mockWorkspacesUsersRepository) CloseTransfer(transfer *workspacesUsers.OwnershipTransfer, status string) error {
+	return nil
+}
+
+func (m *mockWorkspacesUsersRepository) CompleteTransfer(transfer *workspacesUsers.OwnershipTransfer) error {
+	return nil
+}
+
+// mockPolicyRepository resolves workspace roles from an in-memory map keyed by user ID
+type mockPolicyRepository struct {
+	workspaceRoles map[uint]policy.Role
+}
+
+func (m *mockPolicyRepository) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	return m.workspaceRoles[userID], nil
+}
+
+func (m *mockPolicyRepository) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	return "", nil
+}
+
+func (m *mockPolicyRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 0, nil
+}
+
+func (m *mockPolicyRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return false, nil
+}
+
+func newTestAuthorizer(roles map[uint]policy.Role) policy.Authorizer {
+	return policy.New(&mockPolicyRepository{workspaceRoles: roles})
+}
+
 func TestUseCase_Create_PrivacyValidation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -143,7 +255,7 @@ func TestUseCase_Create_PrivacyValidation(t *testing.T) {
 				},
 			}
 			wuRepo := &mockWorkspacesUsersRepository{}
-			uc := NewUseCase(repo, wuRepo, &config.Config{})
+			uc := NewUseCase(repo, wuRepo, newTestAuthorizer(nil), &config.Config{})
 
 			err := uc.Create(&Workspace{Privacy: tt.privacy})
 			if (err != nil) != tt.wantErr {
@@ -194,9 +306,9 @@ func TestUseCase_Update_PrivacyValidation(t *testing.T) {
 				},
 			}
 			wuRepo := &mockWorkspacesUsersRepository{}
-			uc := NewUseCase(repo, wuRepo, &config.Config{})
+			uc := NewUseCase(repo, wuRepo, newTestAuthorizer(map[uint]policy.Role{1: policy.RoleOwner}), &config.Config{})
 
-			err := uc.Update(&Workspace{Privacy: tt.privacy})
+			err := uc.Update(&Workspace{ID: 10, Privacy: tt.privacy}, 1)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCase.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -209,6 +321,7 @@ func TestUseCase_Create_AssociatesUser(t *testing.T) {
 	expectedWorkspaceID := uint(456)
 	var capturedUserID uint
 	var capturedWorkspaceID uint
+	var capturedRole policy.Role
 
 	repo := &mockRepository{
 		createFunc: func(w *Workspace) error {
@@ -220,10 +333,11 @@ func TestUseCase_Create_AssociatesUser(t *testing.T) {
 		createFunc: func(wu *workspacesUsers.WorkspacesUsers) error {
 			capturedUserID = wu.UserID
 			capturedWorkspaceID = wu.WorkspaceID
+			capturedRole = wu.Role
 			return nil
 		},
 	}
-	uc := NewUseCase(repo, wuRepo, &config.Config{})
+	uc := NewUseCase(repo, wuRepo, newTestAuthorizer(nil), &config.Config{})
 
 	err := uc.Create(&Workspace{Privacy: "public", CreatedBy: expectedUserID})
 	if err != nil {
@@ -236,10 +350,14 @@ func TestUseCase_Create_AssociatesUser(t *testing.T) {
 	if capturedWorkspaceID != expectedWorkspaceID {
 		t.Errorf("expected WorkspaceID %v, got %v", expectedWorkspaceID, capturedWorkspaceID)
 	}
+	if capturedRole != policy.RoleOwner {
+		t.Errorf("expected Role %v, got %v", policy.RoleOwner, capturedRole)
+	}
 }
 func TestUseCase_DeleteByID_Authorization(t *testing.T) {
 	creatorID := uint(1)
 	otherUserID := uint(2)
+	adminID := uint(3)
 	workspaceID := uint(10)
 
 	repo := &mockRepository{
@@ -254,7 +372,11 @@ func TestUseCase_DeleteByID_Authorization(t *testing.T) {
 		},
 	}
 	wuRepo := &mockWorkspacesUsersRepository{}
-	uc := NewUseCase(repo, wuRepo, &config.Config{})
+	authz := newTestAuthorizer(map[uint]policy.Role{
+		creatorID: policy.RoleOwner,
+		adminID:   policy.RoleAdmin,
+	})
+	uc := NewUseCase(repo, wuRepo, authz, &config.Config{})
 
 	t.Run("authorized delete", func(t *testing.T) {
 		err := uc.DeleteByID(workspaceID, creatorID)
@@ -268,8 +390,15 @@ func TestUseCase_DeleteByID_Authorization(t *testing.T) {
 		if err == nil {
 			t.Error("expected unauthorized error, got nil")
 		}
-		if err.Error() != "unauthorized: only the workspace creator can delete this workspace" {
-			t.Errorf("expected specific unauthorized error message, got %v", err.Error())
+		if !policy.IsForbidden(err) {
+			t.Errorf("expected policy error, got %v", err)
+		}
+	})
+
+	t.Run("admin cannot delete", func(t *testing.T) {
+		err := uc.DeleteByID(workspaceID, adminID)
+		if !policy.IsForbidden(err) {
+			t.Errorf("expected policy error, got %v", err)
 		}
 	})
 
@@ -280,3 +409,294 @@ func TestUseCase_DeleteByID_Authorization(t *testing.T) {
 		}
 	})
 }
+
+func TestUseCase_JoinPublic(t *testing.T) {
+	tests := []struct {
+		name    string
+		privacy string
+		wantErr error
+	}{
+		{name: "public", privacy: PrivacyPublic},
+		{name: "team needs a request", privacy: PrivacyTeam, wantErr: ErrNotPublic},
+		{name: "private needs an invite", privacy: PrivacyPrivate, wantErr: ErrNotPublic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{
+				findByIDFunc: func(id uint) (*Workspace, error) {
+					return &Workspace{ID: id, Privacy: tt.privacy}, nil
+				},
+			}
+			var created *workspacesUsers.WorkspacesUsers
+			wuRepo := &mockWorkspacesUsersRepository{
+				createFunc: func(wu *workspacesUsers.WorkspacesUsers) error {
+					created = wu
+					return nil
+				},
+			}
+			uc := NewUseCase(repo, wuRepo, newTestAuthorizer(nil), &config.Config{})
+
+			_, err := uc.JoinPublic(1, 42)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("JoinPublic() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (created == nil || created.UserID != 42 || created.Role != policy.RoleMember) {
+				t.Errorf("JoinPublic() created %+v, want user 42 as member", created)
+			}
+			if tt.wantErr != nil && created != nil {
+				t.Error("JoinPublic() added a member to a non-public workspace")
+			}
+		})
+	}
+}
+
+func TestUseCase_Directory_Pagination(t *testing.T) {
+	var got DirectoryQuery
+	repo := &mockRepository{
+		findListedFunc: func(query DirectoryQuery, userID uint) ([]DirectoryEntry, int64, error) {
+			got = query
+			return nil, 45, nil
+		},
+	}
+	uc := NewUseCase(repo, &mockWorkspacesUsersRepository{}, newTestAuthorizer(nil), &config.Config{})
+
+	directory, err := uc.Directory(1, DirectoryQuery{Limit: 500})
+	if err != nil {
+		t.Fatalf("Directory() error = %v", err)
+	}
+	if got.Page != 1 || got.Limit != 100 {
+		t.Errorf("Directory() queried page %d limit %d, want 1 and 100", got.Page, got.Limit)
+	}
+	if directory.Workspaces == nil || directory.Pagination.TotalPages != 1 {
+		t.Errorf("Directory() = %+v, want an empty list on one page", directory)
+	}
+}
+
+// newStatefulRepository serves one workspace whose archive and trash state
+// follows the repository calls
+func newStatefulRepository(workspace *Workspace) *mockRepository {
+	return &mockRepository{
+		findByIDFunc: func(id uint) (*Workspace, error) {
+			if id != workspace.ID {
+				return nil, nil
+			}
+			found := *workspace
+			return &found, nil
+		},
+		archiveFunc: func(id, userID uint) error {
+			now := time.Now()
+			workspace.ArchivedAt, workspace.ArchivedBy = &now, &userID
+			return nil
+		},
+		unarchiveFunc: func(id uint) error {
+			workspace.ArchivedAt, workspace.ArchivedBy = nil, nil
+			return nil
+		},
+		trashFunc: func(id, userID uint) error {
+			now := time.Now()
+			workspace.TrashedAt, workspace.TrashedBy = &now, &userID
+			return nil
+		},
+		restoreFunc: func(id uint) error {
+			workspace.TrashedAt, workspace.TrashedBy = nil, nil
+			return nil
+		},
+		deleteFunc: func(id uint) error {
+			return errors.New("Delete() must only be called by the purge")
+		},
+	}
+}
+
+func newStateTestUseCase(repo Repository) UseCase {
+	authz := newTestAuthorizer(map[uint]policy.Role{1: policy.RoleOwner, 2: policy.RoleAdmin, 3: policy.RoleMember})
+	return NewUseCase(repo, &mockWorkspacesUsersRepository{}, authz, &config.Config{})
+}
+
+func TestUseCase_ArchiveAndUnarchive(t *testing.T) {
+	workspace := &Workspace{ID: 10}
+	uc := newStateTestUseCase(newStatefulRepository(workspace))
+
+	if _, err := uc.Archive(10, 3); !policy.IsForbidden(err) {
+		t.Errorf("Archive() by a member error = %v, want forbidden", err)
+	}
+	archived, err := uc.Archive(10, 2)
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if archived.ArchivedAt == nil || *archived.ArchivedBy != 2 {
+		t.Errorf("Archive() = %+v, want archived by 2", archived)
+	}
+	if _, err := uc.Archive(10, 2); !errors.Is(err, ErrAlreadyArchived) {
+		t.Errorf("Archive() twice error = %v, want %v", err, ErrAlreadyArchived)
+	}
+
+	unarchived, err := uc.Unarchive(10, 1)
+	if err != nil {
+		t.Fatalf("Unarchive() error = %v", err)
+	}
+	if unarchived.ArchivedAt != nil {
+		t.Error("Unarchive() left the workspace archived")
+	}
+	if _, err := uc.Unarchive(10, 1); !errors.Is(err, ErrNotArchived) {
+		t.Errorf("Unarchive() twice error = %v, want %v", err, ErrNotArchived)
+	}
+
+	if err := uc.DeleteByID(10, 1); err != nil {
+		t.Fatalf("DeleteByID() error = %v", err)
+	}
+	if _, err := uc.Archive(10, 1); !errors.Is(err, ErrTrashed) {
+		t.Errorf("Archive() in the trash error = %v, want %v", err, ErrTrashed)
+	}
+}
+
+func TestUseCase_DeleteByID_MovesToTrash(t *testing.T) {
+	now := time.Now()
+	workspace := &Workspace{ID: 10, ArchivedAt: &now}
+	uc := newStateTestUseCase(newStatefulRepository(workspace))
+
+	// Archived workspaces are read-only, but the owner can still trash them
+	if err := uc.DeleteByID(10, 1); err != nil {
+		t.Fatalf("DeleteByID() error = %v", err)
+	}
+	if workspace.TrashedAt == nil || *workspace.TrashedBy != 1 {
+		t.Errorf("DeleteByID() = %+v, want trashed by the owner", workspace)
+	}
+	if err := uc.DeleteByID(10, 1); err != nil {
+		t.Errorf("DeleteByID() of a trashed workspace error = %v, want nil", err)
+	}
+}
+
+func TestUseCase_Restore(t *testing.T) {
+	tests := []struct {
+		name      string
+		trashedAt time.Duration // ago; 0 means not trashed
+		userID    uint
+		wantErr   error
+	}{
+		{name: "owner restores", trashedAt: 29 * 24 * time.Hour, userID: 1},
+		{name: "admin cannot restore", trashedAt: time.Hour, userID: 2, wantErr: policy.ErrForbidden},
+		{name: "retention has run out", trashedAt: 31 * 24 * time.Hour, userID: 1, wantErr: ErrRestoreExpired},
+		{name: "not in the trash", userID: 1, wantErr: ErrNotTrashed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace := &Workspace{ID: 10}
+			if tt.trashedAt != 0 {
+				trashedAt := time.Now().Add(-tt.trashedAt)
+				workspace.TrashedAt = &trashedAt
+			}
+			uc := newStateTestUseCase(newStatefulRepository(workspace))
+
+			restored, err := uc.Restore(10, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Restore() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && restored.TrashedAt != nil {
+				t.Error("Restore() left the workspace in the trash")
+			}
+		})
+	}
+}
+
+func TestUseCase_Duplicate(t *testing.T) {
+	workspaces := map[uint]*Workspace{10: {ID: 10, Name: "Acme", Privacy: PrivacyTeam, PassCode: "ABC123"}}
+	var copiedFrom uint
+	var withMembers bool
+	repo := &mockRepository{
+		findByIDFunc: func(id uint) (*Workspace, error) {
+			if workspace, ok := workspaces[id]; ok {
+				found := *workspace
+				return &found, nil
+			}
+			return nil, nil
+		},
+		duplicateFunc: func(sourceID uint, workspace *Workspace, includeMembers bool) error {
+			copiedFrom, withMembers = sourceID, includeMembers
+			workspace.ID = 11
+			workspaces[workspace.ID] = workspace
+			return nil
+		},
+	}
+	uc := newStateTestUseCase(repo)
+
+	if _, err := uc.Duplicate(10, 3, DuplicateRequest{}); !policy.IsForbidden(err) {
+		t.Errorf("Duplicate() by a member error = %v, want forbidden", err)
+	}
+	if _, err := uc.Duplicate(12, 1, DuplicateRequest{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Duplicate() of a missing workspace error = %v, want %v", err, ErrNotFound)
+	}
+
+	copied, err := uc.Duplicate(10, 2, DuplicateRequest{IncludeMembers: true})
+	if err != nil {
+		t.Fatalf("Duplicate() error = %v", err)
+	}
+	if copiedFrom != 10 || !withMembers {
+		t.Errorf("Duplicate() copied from %d with members %v, want 10 with members", copiedFrom, withMembers)
+	}
+	if copied.ID != 11 || copied.Name != "Acme (copy)" || copied.Privacy != PrivacyTeam || copied.CreatedBy != 2 {
+		t.Errorf("Duplicate() = %+v, want a team copy named %q owned by 2", copied, "Acme (copy)")
+	}
+	if copied.PassCode == "" || copied.PassCode == "ABC123" {
+		t.Errorf("Duplicate() pass code = %q, want a new one", copied.PassCode)
+	}
+
+	now := time.Now()
+	workspaces[10].TrashedAt = &now
+	if _, err := uc.Duplicate(10, 1, DuplicateRequest{Name: "Again"}); !errors.Is(err, ErrTrashed) {
+		t.Errorf("Duplicate() of a trashed workspace error = %v, want %v", err, ErrTrashed)
+	}
+}
+
+func TestUseCase_PurgeExpired(t *testing.T) {
+	var cutoff time.Time
+	var deleted []uint
+	repo := &mockRepository{
+		findTrashedBeforeFunc: func(c time.Time) ([]uint, error) {
+			cutoff = c
+			return []uint{4, 5, 6}, nil
+		},
+		deleteFunc: func(id uint) error {
+			if id == 5 {
+				return errors.New("deadlock detected")
+			}
+			deleted = append(deleted, id)
+			return nil
+		},
+	}
+	cfg := &config.Config{}
+	cfg.Workspace.TrashRetentionDays = 7
+	uc := NewUseCase(repo, &mockWorkspacesUsersRepository{}, newTestAuthorizer(nil), cfg)
+
+	purged, err := uc.PurgeExpired()
+	if err != nil {
+		t.Fatalf("PurgeExpired() error = %v", err)
+	}
+	if want := time.Now().Add(-7 * 24 * time.Hour); cutoff.Sub(want).Abs() > time.Minute {
+		t.Errorf("PurgeExpired() cutoff = %v, want about %v", cutoff, want)
+	}
+	// A failed workspace is skipped and retried on the next run
+	if purged != 2 || len(deleted) != 2 || deleted[0] != 4 || deleted[1] != 6 {
+		t.Errorf("PurgeExpired() purged %d %v, want 2 [4 6]", purged, deleted)
+	}
+}
+
+func TestUseCase_GetByUserID_HidesArchived(t *testing.T) {
+	now := time.Now()
+	repo := &mockRepository{
+		findByUserAccessFunc: func(userID uint) ([]Workspace, error) {
+			return []Workspace{{ID: 1}, {ID: 2, ArchivedAt: &now}}, nil
+		},
+	}
+	uc := NewUseCase(repo, &mockWorkspacesUsersRepository{}, newTestAuthorizer(nil), &config.Config{})
+
+	active, _ := uc.GetByUserID(1)
+	archived, _ := uc.GetArchived(1)
+	if len(active) != 1 || active[0].ID != 1 {
+		t.Errorf("GetByUserID() = %+v, want only workspace 1", active)
+	}
+	if len(archived) != 1 || archived[0].ID != 2 {
+		t.Errorf("GetArchived() = %+v, want only workspace 2", archived)
+	}
+}
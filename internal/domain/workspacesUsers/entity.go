@@ -2,14 +2,45 @@ package workspacesUsers
 
 import (
 	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/policy"
 	"time"
 )
 
 type WorkspacesUsers struct {
-	ID          uint       `json:"id"`
+	ID          uint        `json:"id"`
+	WorkspaceID uint        `json:"workspace_id"`
+	UserID      uint        `json:"user_id"`
+	Role        policy.Role `json:"role" gorm:"default:member"`
+	User        *user.User  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Ownership transfer states
+const (
+	TransferPending   = "pending"
+	TransferAccepted  = "accepted"
+	TransferDeclined  = "declined"
+	TransferCancelled = "cancelled"
+)
+
+// OwnershipTransfer offers a workspace's ownership to another member. Nothing
+// changes hands until that member accepts.
+type OwnershipTransfer struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
 	WorkspaceID uint       `json:"workspace_id"`
-	UserID      uint       `json:"user_id"`
-	User        *user.User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	FromUserID  uint       `json:"from_user_id"`
+	ToUserID    uint       `json:"to_user_id"`
+	Status      string     `json:"status"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
+
+func (OwnershipTransfer) TableName() string {
+	return "workspace_ownership_transfers"
+}
+
+type TransferRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
@@ -1,6 +1,9 @@
 package workspacesUsers
 
 import (
+	"errors"
+	"hrm-app/internal/domain/audit"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/response"
 	"net/http"
 	"strconv"
@@ -8,12 +11,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Auditor records membership changes (implemented by audit.UseCase)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
 type Handler struct {
 	usecase UseCase
+	audit   Auditor
 }
 
-func NewHandler(u UseCase) *Handler {
-	return &Handler{usecase: u}
+func NewHandler(u UseCase, auditor Auditor) *Handler {
+	return &Handler{usecase: u, audit: auditor}
 }
 
 func (h *Handler) Create(c *gin.Context) {
@@ -31,10 +40,15 @@ func (h *Handler) Create(c *gin.Context) {
 	}
 
 	if err := h.usecase.Create(&workspacesUsers, userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	h.audit.Record(audit.FromRequest(c, "assign_workspace_user", audit.EntityWorkspaceUser, workspacesUsers.ID).WithWorkspace(workspacesUsers.WorkspaceID).WithChanges(nil, &workspacesUsers))
 	response.Success(c, workspacesUsers)
 }
 
@@ -96,11 +110,25 @@ func (h *Handler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.Delete(uint(idInt)); err != nil {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	existing, _ := h.usecase.GetByID(uint(idInt))
+
+	if err := h.usecase.Delete(uint(idInt), userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to delete workspacesUsers")
 		return
 	}
 
+	h.audit.Record(audit.FromRequest(c, "unassign_workspace_user", audit.EntityWorkspaceUser, uint(idInt)).WithWorkspace(existing.WorkspaceID).WithChanges(&existing, nil))
 	response.DeleteSuccess(c, "WorkspacesUsers deleted successfully")
 }
 
@@ -120,17 +148,31 @@ func (h *Handler) Update(c *gin.Context) {
 
 	workspacesUsers.ID = uint(idInt)
 
-	if err := h.usecase.Update(&workspacesUsers); err != nil {
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	before, _ := h.usecase.GetByID(workspacesUsers.ID)
+
+	if err := h.usecase.Update(&workspacesUsers, userID.(uint)); err != nil {
+		if policy.IsForbidden(err) {
+			response.Error(c, http.StatusForbidden, err.Error())
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	h.audit.Record(audit.FromRequest(c, "update_workspace_user", audit.EntityWorkspaceUser, workspacesUsers.ID).WithWorkspace(workspacesUsers.WorkspaceID).WithChanges(&before, &workspacesUsers))
 	response.Success(c, workspacesUsers)
 }
 
 func (h *Handler) Join(c *gin.Context) {
 	var req struct {
-		Token string `json:"token"`
+		Token string `json:"token" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		response.Error(c, http.StatusBadRequest, err.Error())
@@ -144,19 +186,31 @@ func (h *Handler) Join(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.Join(userID.(uint), req.Token); err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+	membership, err := h.usecase.Join(userID.(uint), req.Token)
+	if err != nil {
+		var linkErr *InviteLinkError
+		switch {
+		case errors.As(err, &linkErr):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		case policy.IsForbidden(err):
+			response.Error(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrAlreadyMember):
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
-	response.Success(c, gin.H{"message": "Joined workspace successfully"})
+	h.audit.Record(audit.FromRequest(c, "join_workspace", audit.EntityWorkspaceUser, membership.ID).WithWorkspace(membership.WorkspaceID).WithChanges(nil, membership))
+
+	response.Success(c, gin.H{"message": response.Message(c, "Joined workspace successfully")})
 }
 
-func (h *Handler) GenerateJoinToken(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil || id < 0 {
-		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+// Leave removes the caller from the workspace in the :id param
+func (h *Handler) Leave(c *gin.Context) {
+	workspaceID, ok := idParam(c)
+	if !ok {
 		return
 	}
 
@@ -167,11 +221,112 @@ func (h *Handler) GenerateJoinToken(c *gin.Context) {
 		return
 	}
 
-	token, err := h.usecase.GenerateJoinToken(uint(id), userID.(uint))
+	membership, err := h.usecase.Leave(workspaceID, userID.(uint))
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		respondTransferError(c, err)
+		return
+	}
+
+	h.audit.Record(audit.FromRequest(c, "leave_workspace", audit.EntityWorkspaceUser, membership.ID).WithWorkspace(workspaceID).WithChanges(membership, nil))
+	response.Success(c, gin.H{"message": response.Message(c, "Left workspace successfully")})
+}
+
+func (h *Handler) RequestTransfer(c *gin.Context) {
+	workspaceID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	response.Success(c, gin.H{"token": token})
+	transfer, err := h.usecase.RequestTransfer(workspaceID, userID.(uint), req.UserID)
+	if err != nil {
+		respondTransferError(c, err)
+		return
+	}
+
+	h.recordTransfer(c, "request_ownership_transfer", transfer)
+	response.Success(c, transfer)
+}
+
+func (h *Handler) GetTransfer(c *gin.Context) {
+	h.transfer(c, "", h.usecase.GetTransfer)
+}
+
+func (h *Handler) AcceptTransfer(c *gin.Context) {
+	h.transfer(c, "accept_ownership_transfer", h.usecase.AcceptTransfer)
+}
+
+func (h *Handler) DeclineTransfer(c *gin.Context) {
+	h.transfer(c, "decline_ownership_transfer", h.usecase.DeclineTransfer)
+}
+
+func (h *Handler) CancelTransfer(c *gin.Context) {
+	h.transfer(c, "cancel_ownership_transfer", h.usecase.CancelTransfer)
+}
+
+// transfer runs an action on the pending transfer of the workspace in the :id
+// param, auditing it unless action is empty
+func (h *Handler) transfer(c *gin.Context, action string, run func(workspaceID, userID uint) (*OwnershipTransfer, error)) {
+	workspaceID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Get user_id from context (set by AuthMiddleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	transfer, err := run(workspaceID, userID.(uint))
+	if err != nil {
+		respondTransferError(c, err)
+		return
+	}
+
+	if action != "" {
+		h.recordTransfer(c, action, transfer)
+	}
+	response.Success(c, transfer)
+}
+
+func (h *Handler) recordTransfer(c *gin.Context, action string, transfer *OwnershipTransfer) {
+	h.audit.Record(audit.FromRequest(c, action, audit.EntityOwnershipTransfer, transfer.ID).WithWorkspace(transfer.WorkspaceID).WithChanges(nil, transfer))
+}
+
+func idParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid id parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondTransferError(c *gin.Context, err error) {
+	switch {
+	case policy.IsForbidden(err):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrNotMember), errors.Is(err, ErrTransferNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrLastOwner), errors.Is(err, ErrTransferPending):
+		response.Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrTransferToSelf):
+		response.Error(c, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, err.Error())
+	}
 }
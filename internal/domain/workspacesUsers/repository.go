@@ -1,7 +1,15 @@
 package workspacesUsers
 
 import (
+	"errors"
+	"slices"
+	"time"
+
 	"hrm-app/internal/pkg/database"
+	"hrm-app/internal/pkg/policy"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -12,6 +20,11 @@ type Repository interface {
 	GetByID(id uint) (WorkspacesUsers, error)
 	Delete(id uint) error
 	Update(workspacesUsers *WorkspacesUsers) error
+	Leave(workspaceID, userID uint) error
+	CreateTransfer(transfer *OwnershipTransfer) error
+	FindPendingTransfer(workspaceID uint) (*OwnershipTransfer, error)
+	CloseTransfer(transfer *OwnershipTransfer, status string) error
+	CompleteTransfer(transfer *OwnershipTransfer) error
 }
 
 type repository struct{}
@@ -63,3 +76,127 @@ func (r *repository) Delete(id uint) error {
 func (r *repository) Update(workspacesUsers *WorkspacesUsers) error {
 	return database.DB.Save(workspacesUsers).Error
 }
+
+// Leave removes the user from the workspace and from everything inside it:
+// its boards, chat rooms and card assignments. Transfers the user is part of
+// are cancelled. The owner rows are locked while counting them, so two owners
+// leaving at once cannot both pass the last-owner check.
+func (r *repository) Leave(workspaceID, userID uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var ownerIDs []uint
+		if err := tx.Model(&WorkspacesUsers{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("workspace_id = ? AND role = ?", workspaceID, policy.RoleOwner).
+			Pluck("user_id", &ownerIDs).Error; err != nil {
+			return err
+		}
+		if len(ownerIDs) <= 1 && slices.Contains(ownerIDs, userID) {
+			return ErrLastOwner
+		}
+
+		boardIDs := tx.Table("boards").Select("id").Where("workspace_id = ?", workspaceID)
+		cardIDs := tx.Table("task_cards").
+			Select("task_cards.id").
+			Joins("JOIN task_tabs ON task_tabs.id = task_cards.task_tab_id").
+			Joins("JOIN boards ON boards.id = task_tabs.board_id").
+			Where("boards.workspace_id = ?", workspaceID)
+		roomIDs := tx.Table("rooms_chats").Select("id").Where("workspace_id = ?", workspaceID)
+
+		if err := tx.Exec("DELETE FROM task_card_users WHERE user_id = ? AND task_card_id IN (?)", userID, cardIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM boards_users WHERE user_id = ? AND board_id IN (?)", userID, boardIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM room_users WHERE user_id = ? AND room_id IN (?)", userID, roomIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&OwnershipTransfer{}).
+			Where("workspace_id = ? AND status = ? AND (from_user_id = ? OR to_user_id = ?)", workspaceID, TransferPending, userID, userID).
+			Updates(map[string]interface{}{"status": TransferCancelled, "responded_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&WorkspacesUsers{}).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *repository) CreateTransfer(transfer *OwnershipTransfer) error {
+	return database.DB.Create(transfer).Error
+}
+
+func (r *repository) FindPendingTransfer(workspaceID uint) (*OwnershipTransfer, error) {
+	var transfer OwnershipTransfer
+	err := database.DB.Where("workspace_id = ? AND status = ?", workspaceID, TransferPending).First(&transfer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// CloseTransfer ends a pending transfer without moving ownership
+func (r *repository) CloseTransfer(transfer *OwnershipTransfer, status string) error {
+	now := time.Now()
+	result := database.DB.Model(&OwnershipTransfer{}).
+		Where("id = ? AND status = ?", transfer.ID, TransferPending).
+		Updates(map[string]interface{}{"status": status, "responded_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransferNotFound
+	}
+
+	transfer.Status = status
+	transfer.RespondedAt = &now
+	return nil
+}
+
+// CompleteTransfer makes the recipient the owner and the previous owner an
+// admin, in one transaction with closing the transfer
+func (r *repository) CompleteTransfer(transfer *OwnershipTransfer) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&OwnershipTransfer{}).
+			Where("id = ? AND status = ?", transfer.ID, TransferPending).
+			Updates(map[string]interface{}{"status": TransferAccepted, "responded_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		// Cancelled or answered concurrently
+		if result.RowsAffected == 0 {
+			return ErrTransferNotFound
+		}
+
+		result = tx.Model(&WorkspacesUsers{}).
+			Where("workspace_id = ? AND user_id = ?", transfer.WorkspaceID, transfer.ToUserID).
+			Update("role", policy.RoleOwner)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotMember
+		}
+
+		if err := tx.Model(&WorkspacesUsers{}).
+			Where("workspace_id = ? AND user_id = ? AND role = ?", transfer.WorkspaceID, transfer.FromUserID, policy.RoleOwner).
+			Update("role", policy.RoleAdmin).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Table("workspaces").
+			Where("id = ?", transfer.WorkspaceID).
+			Update("created_by", transfer.ToUserID).Error; err != nil {
+			return err
+		}
+
+		transfer.Status = TransferAccepted
+		transfer.RespondedAt = &now
+		return nil
+	})
+}
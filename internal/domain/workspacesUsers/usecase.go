@@ -3,7 +3,17 @@ package workspacesUsers
 import (
 	"errors"
 	"hrm-app/config"
-	"hrm-app/internal/pkg/utils"
+	"hrm-app/internal/domain/notification"
+	"hrm-app/internal/pkg/policy"
+)
+
+var (
+	ErrAlreadyMember    = errors.New("user already assigned to this workspace")
+	ErrNotMember        = errors.New("user is not a member of this workspace")
+	ErrLastOwner        = errors.New("the last owner cannot leave the workspace, transfer ownership first")
+	ErrTransferNotFound = errors.New("no pending ownership transfer for this workspace")
+	ErrTransferPending  = errors.New("an ownership transfer is already pending, cancel it first")
+	ErrTransferToSelf   = errors.New("cannot transfer ownership to yourself")
 )
 
 // WorkspaceRepository defines minimal interface needed to verify workspace ownership
@@ -14,8 +24,36 @@ type WorkspaceRepository interface {
 // WorkspaceInfo contains minimal workspace information needed for authorization
 type WorkspaceInfo struct {
 	ID        uint
+	Name      string
 	CreatedBy uint
-	PassCode  string
+	Privacy   string
+}
+
+// InviteLinks checks and counts invite link uses (implemented by inviteLink.NewWorkspaceAdapter)
+type InviteLinks interface {
+	Resolve(token string, userID uint) (*InviteLinkInfo, error)
+	Consume(id uint) error
+}
+
+// InviteLinkInfo is what Join needs to know about a usable invite link
+type InviteLinkInfo struct {
+	ID          uint
+	WorkspaceID uint
+	Role        policy.Role
+}
+
+// InviteLinkError marks a Join refused because of the link itself, such as a
+// revoked or used up link, as opposed to a server failure
+type InviteLinkError struct {
+	Err error
+}
+
+func (e *InviteLinkError) Error() string { return e.Err.Error() }
+func (e *InviteLinkError) Unwrap() error { return e.Err }
+
+// Notifier tells users they were added (implemented by notification.UseCase)
+type Notifier interface {
+	Notify(userID uint, n notification.Notification)
 }
 
 type UseCase interface {
@@ -23,46 +61,77 @@ type UseCase interface {
 	GetByWorkspaceID(workspaceID uint) ([]WorkspacesUsers, error)
 	GetByUserID(userID uint) ([]WorkspacesUsers, error)
 	GetByID(id uint) (WorkspacesUsers, error)
-	Delete(id uint) error
-	Update(workspacesUsers *WorkspacesUsers) error
-	Join(userID uint, token string) error
-	GenerateJoinToken(workspaceID, userID uint) (string, error)
+	Delete(id, requestingUserID uint) error
+	Update(workspacesUsers *WorkspacesUsers, requestingUserID uint) error
+	Join(userID uint, token string) (*WorkspacesUsers, error)
+	Leave(workspaceID, userID uint) (*WorkspacesUsers, error)
+	RequestTransfer(workspaceID, ownerID, toUserID uint) (*OwnershipTransfer, error)
+	GetTransfer(workspaceID, userID uint) (*OwnershipTransfer, error)
+	AcceptTransfer(workspaceID, userID uint) (*OwnershipTransfer, error)
+	DeclineTransfer(workspaceID, userID uint) (*OwnershipTransfer, error)
+	CancelTransfer(workspaceID, userID uint) (*OwnershipTransfer, error)
 }
 
 type usecase struct {
 	repo          Repository
 	workspaceRepo WorkspaceRepository
+	links         InviteLinks
+	authz         policy.Authorizer
+	notifier      Notifier
 	cfg           *config.Config
 }
 
-func NewUseCase(repo Repository, workspaceRepo WorkspaceRepository, cfg *config.Config) UseCase {
+func NewUseCase(repo Repository, workspaceRepo WorkspaceRepository, links InviteLinks, authz policy.Authorizer, notifier Notifier, cfg *config.Config) UseCase {
 	return &usecase{
 		repo:          repo,
 		workspaceRepo: workspaceRepo,
+		links:         links,
+		authz:         authz,
+		notifier:      notifier,
 		cfg:           cfg,
 	}
 }
 
 func (u *usecase) Create(workspacesUsers *WorkspacesUsers, requestingUserID uint) error {
-	// Fetch the workspace to verify ownership
 	workspace, err := u.workspaceRepo.FindByID(workspacesUsers.WorkspaceID)
-
 	if err != nil {
 		return errors.New("workspace not found")
 	}
 
-	// Check if the requesting user is the creator of the workspace
-	if workspace.CreatedBy != requestingUserID {
-		return errors.New("unauthorized: only workspace creator can add users")
+	if workspacesUsers.Role == "" {
+		workspacesUsers.Role = policy.RoleMember
+	}
+
+	// Only admins and owners can add users, and only below their own role
+	actorRole, err := u.authz.WorkspaceRole(workspacesUsers.WorkspaceID, requestingUserID)
+	if err != nil {
+		return err
+	}
+	if !policy.CanAssignRole(actorRole, "", workspacesUsers.Role) {
+		return policy.ErrForbidden
+	}
+	if err := u.authz.CheckWritable(workspacesUsers.WorkspaceID); err != nil {
+		return err
 	}
 
 	// Check if user is already assigned to the workspace
 	existingUser, _ := u.repo.GetByWorkspaceIDAndUserID(workspacesUsers.WorkspaceID, workspacesUsers.UserID)
 	if existingUser != nil && existingUser.ID != 0 {
-		return errors.New("user already assigned to this workspace")
+		return ErrAlreadyMember
+	}
+
+	if err := u.repo.Create(workspacesUsers); err != nil {
+		return err
 	}
 
-	return u.repo.Create(workspacesUsers)
+	if workspacesUsers.UserID != requestingUserID {
+		u.notifier.Notify(workspacesUsers.UserID, notification.Notification{
+			Type: notification.TypeWorkspaceMemberAdded,
+			Args: []interface{}{workspace.Name, workspacesUsers.Role},
+			Data: map[string]interface{}{"workspace_id": workspace.ID},
+		})
+	}
+	return nil
 }
 
 func (u *usecase) GetByWorkspaceID(workspaceID uint) ([]WorkspacesUsers, error) {
@@ -77,62 +146,250 @@ func (u *usecase) GetByID(id uint) (WorkspacesUsers, error) {
 	return u.repo.GetByID(id)
 }
 
-func (u *usecase) Delete(id uint) error {
+func (u *usecase) Delete(id, requestingUserID uint) error {
+	existing, err := u.repo.GetByID(id)
+	if err != nil || existing.ID == 0 {
+		return errors.New("workspace user not found")
+	}
+
+	actorRole, err := u.authz.WorkspaceRole(existing.WorkspaceID, requestingUserID)
+	if err != nil {
+		return err
+	}
+	if !policy.CanRemoveMember(actorRole, existing.Role) {
+		return policy.ErrForbidden
+	}
+	if err := u.authz.CheckWritable(existing.WorkspaceID); err != nil {
+		return err
+	}
+
 	return u.repo.Delete(id)
 }
 
-func (u *usecase) Update(workspacesUsers *WorkspacesUsers) error {
-	return u.repo.Update(workspacesUsers)
+// Update changes the role of a workspace member. Other fields are immutable.
+func (u *usecase) Update(workspacesUsers *WorkspacesUsers, requestingUserID uint) error {
+	existing, err := u.repo.GetByID(workspacesUsers.ID)
+	if err != nil || existing.ID == 0 {
+		return errors.New("workspace user not found")
+	}
+
+	actorRole, err := u.authz.WorkspaceRole(existing.WorkspaceID, requestingUserID)
+	if err != nil {
+		return err
+	}
+	if !policy.CanAssignRole(actorRole, existing.Role, workspacesUsers.Role) {
+		return policy.ErrForbidden
+	}
+	if err := u.authz.CheckWritable(existing.WorkspaceID); err != nil {
+		return err
+	}
+
+	existing.Role = workspacesUsers.Role
+	existing.User = nil
+	if err := u.repo.Update(&existing); err != nil {
+		return err
+	}
+
+	*workspacesUsers = existing
+	return nil
 }
 
-func (u *usecase) GenerateJoinToken(workspaceID, userID uint) (string, error) {
-	workspace, err := u.workspaceRepo.FindByID(workspaceID)
+// Join adds the user with the role the invite link grants. A use is counted
+// only once the user is known not to be a member, so clicking a link twice
+// does not burn a use.
+func (u *usecase) Join(userID uint, token string) (*WorkspacesUsers, error) {
+	link, err := u.links.Resolve(token, userID)
 	if err != nil {
-		return "", errors.New("workspace not found")
+		return nil, err
+	}
+
+	// Check if user is already assigned to the workspace
+	existingUser, _ := u.repo.GetByWorkspaceIDAndUserID(link.WorkspaceID, userID)
+	if existingUser != nil && existingUser.ID != 0 {
+		return nil, ErrAlreadyMember
 	}
 
-	// Only workspace creator can generate join token
-	if workspace.CreatedBy != userID {
-		return "", errors.New("unauthorized: only workspace creator can generate join token")
+	if err := u.links.Consume(link.ID); err != nil {
+		return nil, err
 	}
 
-	return utils.GenerateJoinToken(u.cfg, workspaceID, "workspace", workspace.PassCode)
+	workspacesUsers := &WorkspacesUsers{
+		WorkspaceID: link.WorkspaceID,
+		UserID:      userID,
+		Role:        link.Role,
+	}
+
+	if err := u.repo.Create(workspacesUsers); err != nil {
+		return nil, err
+	}
+	return workspacesUsers, nil
 }
 
-func (u *usecase) Join(userID uint, token string) error {
-	claims, err := utils.ValidateJoinToken(u.cfg, token)
+// Leave removes the caller from the workspace and its boards, rooms and cards.
+// A workspace always keeps an owner, so its last owner has to transfer
+// ownership before leaving.
+func (u *usecase) Leave(workspaceID, userID uint) (*WorkspacesUsers, error) {
+	member, err := u.member(workspaceID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if claims.EntityType != "workspace" {
-		return errors.New("invalid token type")
+	// The repository checks for another owner inside the transaction
+	if err := u.repo.Leave(workspaceID, userID); err != nil {
+		return nil, err
 	}
 
-	workspaceID := claims.EntityID
-	passCode := claims.PassCode
+	member.User = nil
+	return member, nil
+}
 
-	// Fetch the workspace to verify passcode
+// RequestTransfer offers ownership to another member. The owner keeps
+// ownership until the recipient accepts.
+func (u *usecase) RequestTransfer(workspaceID, ownerID, toUserID uint) (*OwnershipTransfer, error) {
 	workspace, err := u.workspaceRepo.FindByID(workspaceID)
-	if err != nil {
-		return errors.New("workspace not found")
+	if err != nil || workspace == nil {
+		return nil, errors.New("workspace not found")
 	}
 
-	// Check if the passcode matches
-	if workspace.PassCode != passCode {
-		return errors.New("invalid passcode")
+	owner, err := u.member(workspaceID, ownerID)
+	if err != nil {
+		return nil, policy.ErrNotMember
+	}
+	if owner.Role != policy.RoleOwner {
+		return nil, policy.ErrForbidden
+	}
+	if toUserID == ownerID {
+		return nil, ErrTransferToSelf
+	}
+	if _, err := u.member(workspaceID, toUserID); err != nil {
+		return nil, err
 	}
 
-	// Check if user is already assigned to the workspace
-	existingUser, _ := u.repo.GetByWorkspaceIDAndUserID(workspaceID, userID)
-	if existingUser != nil && existingUser.ID != 0 {
-		return errors.New("user already assigned to this workspace")
+	pending, err := u.repo.FindPendingTransfer(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, ErrTransferPending
 	}
 
-	workspacesUsers := &WorkspacesUsers{
+	transfer := &OwnershipTransfer{
 		WorkspaceID: workspaceID,
-		UserID:      userID,
+		FromUserID:  ownerID,
+		ToUserID:    toUserID,
+		Status:      TransferPending,
+	}
+	if err := u.repo.CreateTransfer(transfer); err != nil {
+		return nil, err
+	}
+
+	u.notifier.Notify(toUserID, notification.Notification{
+		Type: notification.TypeWorkspaceOwnershipOffered,
+		Args: []interface{}{username(owner), workspace.Name},
+		Data: map[string]interface{}{"workspace_id": workspaceID, "transfer_id": transfer.ID},
+	})
+	return transfer, nil
+}
+
+// GetTransfer returns the pending transfer to the two members involved
+func (u *usecase) GetTransfer(workspaceID, userID uint) (*OwnershipTransfer, error) {
+	transfer, err := u.repo.FindPendingTransfer(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, ErrTransferNotFound
+	}
+	if transfer.FromUserID != userID && transfer.ToUserID != userID {
+		return nil, policy.ErrForbidden
+	}
+	return transfer, nil
+}
+
+func (u *usecase) AcceptTransfer(workspaceID, userID uint) (*OwnershipTransfer, error) {
+	transfer, err := u.pendingTransferTo(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.CompleteTransfer(transfer); err != nil {
+		return nil, err
+	}
+
+	u.notifyOwner(transfer, notification.TypeWorkspaceOwnershipAccepted)
+	return transfer, nil
+}
+
+func (u *usecase) DeclineTransfer(workspaceID, userID uint) (*OwnershipTransfer, error) {
+	transfer, err := u.pendingTransferTo(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.CloseTransfer(transfer, TransferDeclined); err != nil {
+		return nil, err
+	}
+
+	u.notifyOwner(transfer, notification.TypeWorkspaceOwnershipDeclined)
+	return transfer, nil
+}
+
+// CancelTransfer withdraws the offer. Only the owner who made it may cancel.
+func (u *usecase) CancelTransfer(workspaceID, userID uint) (*OwnershipTransfer, error) {
+	transfer, err := u.GetTransfer(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromUserID != userID {
+		return nil, policy.ErrForbidden
+	}
+
+	if err := u.repo.CloseTransfer(transfer, TransferCancelled); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (u *usecase) pendingTransferTo(workspaceID, userID uint) (*OwnershipTransfer, error) {
+	transfer, err := u.GetTransfer(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != userID {
+		return nil, policy.ErrForbidden
+	}
+	return transfer, nil
+}
+
+// notifyOwner tells the owner who offered the transfer how the recipient answered
+func (u *usecase) notifyOwner(transfer *OwnershipTransfer, notificationType string) {
+	workspaceName := ""
+	if workspace, err := u.workspaceRepo.FindByID(transfer.WorkspaceID); err == nil && workspace != nil {
+		workspaceName = workspace.Name
 	}
+	recipientName := ""
+	if recipient, err := u.member(transfer.WorkspaceID, transfer.ToUserID); err == nil {
+		recipientName = username(recipient)
+	}
+
+	u.notifier.Notify(transfer.FromUserID, notification.Notification{
+		Type: notificationType,
+		Args: []interface{}{recipientName, workspaceName},
+		Data: map[string]interface{}{"workspace_id": transfer.WorkspaceID, "transfer_id": transfer.ID},
+	})
+}
 
-	return u.repo.Create(workspacesUsers)
+func (u *usecase) member(workspaceID, userID uint) (*WorkspacesUsers, error) {
+	member, err := u.repo.GetByWorkspaceIDAndUserID(workspaceID, userID)
+	if err != nil || member == nil || member.ID == 0 {
+		return nil, ErrNotMember
+	}
+	return member, nil
+}
+
+func username(member *WorkspacesUsers) string {
+	if member.User == nil {
+		return ""
+	}
+	return member.User.Username
 }
@@ -0,0 +1,272 @@
+package workspacesUsers
+
+import (
+	"errors"
+	"testing"
+
+	"hrm-app/config"
+	"hrm-app/internal/domain/notification"
+	"hrm-app/internal/domain/user"
+	"hrm-app/internal/pkg/policy"
+)
+
+// mockRepository keeps the members of one workspace in memory, keyed by user ID
+type mockRepository struct {
+	members   map[uint]*WorkspacesUsers
+	transfer  *OwnershipTransfer
+	left      []uint
+	completed bool
+}
+
+func newMockRepository(roles map[uint]policy.Role) *mockRepository {
+	m := &mockRepository{members: map[uint]*WorkspacesUsers{}}
+	for userID, role := range roles {
+		m.members[userID] = &WorkspacesUsers{ID: userID + 100, WorkspaceID: 1, UserID: userID, Role: role, User: &user.User{ID: userID, Username: "user"}}
+	}
+	return m
+}
+
+func (m *mockRepository) Create(wu *WorkspacesUsers) error {
+	m.members[wu.UserID] = wu
+	return nil
+}
+
+func (m *mockRepository) GetByWorkspaceID(workspaceID uint) ([]WorkspacesUsers, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) GetByUserID(userID uint) ([]WorkspacesUsers, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) GetByWorkspaceIDAndUserID(workspaceID, userID uint) (*WorkspacesUsers, error) {
+	if member, ok := m.members[userID]; ok {
+		found := *member
+		return &found, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func (m *mockRepository) GetByID(id uint) (WorkspacesUsers, error) {
+	return WorkspacesUsers{}, nil
+}
+
+func (m *mockRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *mockRepository) Update(wu *WorkspacesUsers) error {
+	return nil
+}
+
+func (m *mockRepository) Leave(workspaceID, userID uint) error {
+	if m.members[userID] != nil && m.members[userID].Role == policy.RoleOwner {
+		var owners int
+		for _, member := range m.members {
+			if member.Role == policy.RoleOwner {
+				owners++
+			}
+		}
+		if owners <= 1 {
+			return ErrLastOwner
+		}
+	}
+	delete(m.members, userID)
+	m.left = append(m.left, userID)
+	return nil
+}
+
+func (m *mockRepository) CreateTransfer(transfer *OwnershipTransfer) error {
+	transfer.ID = 1
+	m.transfer = transfer
+	return nil
+}
+
+func (m *mockRepository) FindPendingTransfer(workspaceID uint) (*OwnershipTransfer, error) {
+	if m.transfer == nil || m.transfer.Status != TransferPending {
+		return nil, nil
+	}
+	return m.transfer, nil
+}
+
+func (m *mockRepository) CloseTransfer(transfer *OwnershipTransfer, status string) error {
+	transfer.Status = status
+	return nil
+}
+
+func (m *mockRepository) CompleteTransfer(transfer *OwnershipTransfer) error {
+	transfer.Status = TransferAccepted
+	m.members[transfer.ToUserID].Role = policy.RoleOwner
+	m.members[transfer.FromUserID].Role = policy.RoleAdmin
+	m.completed = true
+	return nil
+}
+
+type mockWorkspaceRepository struct{}
+
+func (m *mockWorkspaceRepository) FindByID(id uint) (*WorkspaceInfo, error) {
+	return &WorkspaceInfo{ID: id, Name: "Acme"}, nil
+}
+
+type mockNotifier struct {
+	sent []notification.Notification
+	to   []uint
+}
+
+func (m *mockNotifier) Notify(userID uint, n notification.Notification) {
+	m.to = append(m.to, userID)
+	m.sent = append(m.sent, n)
+}
+
+const (
+	ownerID  uint = 1
+	adminID  uint = 2
+	memberID uint = 3
+	outsider uint = 9
+)
+
+func newTestUseCase(repo *mockRepository, notifier *mockNotifier) UseCase {
+	return NewUseCase(repo, &mockWorkspaceRepository{}, nil, nil, notifier, &config.Config{})
+}
+
+func defaultRoles() map[uint]policy.Role {
+	return map[uint]policy.Role{ownerID: policy.RoleOwner, adminID: policy.RoleAdmin, memberID: policy.RoleMember}
+}
+
+func TestUseCase_Leave(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   map[uint]policy.Role
+		userID  uint
+		wantErr error
+	}{
+		{name: "member leaves", roles: defaultRoles(), userID: memberID},
+		{name: "last owner cannot leave", roles: defaultRoles(), userID: ownerID, wantErr: ErrLastOwner},
+		{name: "one of two owners leaves", roles: map[uint]policy.Role{ownerID: policy.RoleOwner, adminID: policy.RoleOwner}, userID: ownerID},
+		{name: "not a member", roles: defaultRoles(), userID: outsider, wantErr: ErrNotMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository(tt.roles)
+			membership, err := newTestUseCase(repo, &mockNotifier{}).Leave(1, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Leave() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.left) != 0 {
+					t.Errorf("Leave() removed %v on failure", repo.left)
+				}
+				return
+			}
+			if len(repo.left) != 1 || repo.left[0] != tt.userID || membership.UserID != tt.userID {
+				t.Errorf("Leave() removed %v, want [%d]", repo.left, tt.userID)
+			}
+		})
+	}
+}
+
+func TestUseCase_RequestTransfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID uint
+		toID    uint
+		pending bool
+		wantErr error
+	}{
+		{name: "owner offers to member", actorID: ownerID, toID: memberID},
+		{name: "admin cannot offer", actorID: adminID, toID: memberID, wantErr: policy.ErrForbidden},
+		{name: "outsider cannot offer", actorID: outsider, toID: memberID, wantErr: policy.ErrNotMember},
+		{name: "recipient must be a member", actorID: ownerID, toID: outsider, wantErr: ErrNotMember},
+		{name: "not to yourself", actorID: ownerID, toID: ownerID, wantErr: ErrTransferToSelf},
+		{name: "one at a time", actorID: ownerID, toID: memberID, pending: true, wantErr: ErrTransferPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository(defaultRoles())
+			if tt.pending {
+				repo.transfer = &OwnershipTransfer{WorkspaceID: 1, FromUserID: ownerID, ToUserID: adminID, Status: TransferPending}
+			}
+			notifier := &mockNotifier{}
+
+			transfer, err := newTestUseCase(repo, notifier).RequestTransfer(1, tt.actorID, tt.toID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RequestTransfer() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if transfer.Status != TransferPending || repo.members[ownerID].Role != policy.RoleOwner {
+				t.Error("RequestTransfer() must not move ownership before the recipient accepts")
+			}
+			if len(notifier.to) != 1 || notifier.to[0] != tt.toID || notifier.sent[0].Type != notification.TypeWorkspaceOwnershipOffered {
+				t.Errorf("RequestTransfer() notified %v, want the recipient %d", notifier.to, tt.toID)
+			}
+		})
+	}
+}
+
+func TestUseCase_AcceptTransfer(t *testing.T) {
+	repo := newMockRepository(defaultRoles())
+	notifier := &mockNotifier{}
+	uc := newTestUseCase(repo, notifier)
+
+	if _, err := uc.RequestTransfer(1, ownerID, memberID); err != nil {
+		t.Fatalf("RequestTransfer() error = %v", err)
+	}
+	if _, err := uc.AcceptTransfer(1, adminID); !policy.IsForbidden(err) {
+		t.Errorf("AcceptTransfer() by a bystander error = %v, want forbidden", err)
+	}
+	if _, err := uc.AcceptTransfer(1, ownerID); !policy.IsForbidden(err) {
+		t.Errorf("AcceptTransfer() by the current owner error = %v, want forbidden", err)
+	}
+
+	transfer, err := uc.AcceptTransfer(1, memberID)
+	if err != nil {
+		t.Fatalf("AcceptTransfer() error = %v", err)
+	}
+	if !repo.completed || transfer.Status != TransferAccepted {
+		t.Error("AcceptTransfer() did not complete the transfer")
+	}
+	if last := notifier.to[len(notifier.to)-1]; last != ownerID {
+		t.Errorf("AcceptTransfer() notified %d, want the previous owner %d", last, ownerID)
+	}
+
+	// The previous owner is now an admin and free to leave
+	if _, err := uc.Leave(1, ownerID); err != nil {
+		t.Errorf("Leave() by the previous owner error = %v", err)
+	}
+	if _, err := uc.Leave(1, memberID); !errors.Is(err, ErrLastOwner) {
+		t.Errorf("Leave() by the new owner error = %v, want %v", err, ErrLastOwner)
+	}
+}
+
+func TestUseCase_DeclineAndCancelTransfer(t *testing.T) {
+	repo := newMockRepository(defaultRoles())
+	uc := newTestUseCase(repo, &mockNotifier{})
+
+	if _, err := uc.RequestTransfer(1, ownerID, memberID); err != nil {
+		t.Fatalf("RequestTransfer() error = %v", err)
+	}
+	if _, err := uc.CancelTransfer(1, memberID); !policy.IsForbidden(err) {
+		t.Errorf("CancelTransfer() by the recipient error = %v, want forbidden", err)
+	}
+	transfer, err := uc.DeclineTransfer(1, memberID)
+	if err != nil {
+		t.Fatalf("DeclineTransfer() error = %v", err)
+	}
+	if transfer.Status != TransferDeclined || repo.completed {
+		t.Errorf("DeclineTransfer() status = %q, want %q without moving ownership", transfer.Status, TransferDeclined)
+	}
+
+	if _, err := uc.RequestTransfer(1, ownerID, adminID); err != nil {
+		t.Fatalf("RequestTransfer() after decline error = %v", err)
+	}
+	if _, err := uc.CancelTransfer(1, ownerID); err != nil {
+		t.Fatalf("CancelTransfer() error = %v", err)
+	}
+	if _, err := uc.GetTransfer(1, ownerID); !errors.Is(err, ErrTransferNotFound) {
+		t.Errorf("GetTransfer() after cancel error = %v, want %v", err, ErrTransferNotFound)
+	}
+}
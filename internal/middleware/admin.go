@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuthorizer decides whether a user may act as a system administrator
+type AdminAuthorizer interface {
+	AuthorizeAdmin(userID uint) error
+}
+
+// AdminOnly allows callers holding the system admin role.
+// It must run after AuthMiddleware.
+func AdminOnly(admins AdminAuthorizer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := admins.AuthorizeAdmin(c.GetUint("user_id")); err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// SelfOrAdmin allows callers whose user id matches the given route param,
+// and system admins acting on any user. It must run after AuthMiddleware.
+func SelfOrAdmin(admins AdminAuthorizer, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetUint("user_id")
+		if id, err := strconv.ParseUint(c.Param(param), 10, 64); err == nil && uint(id) == userID {
+			c.Next()
+			return
+		}
+
+		if err := admins.AuthorizeAdmin(userID); err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": response.Message(c, "You can only manage your own account"),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
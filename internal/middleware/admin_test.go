@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockAdmins map[uint]bool
+
+func (m mockAdmins) AuthorizeAdmin(userID uint) error {
+	if !m[userID] {
+		return errors.New("admin access required")
+	}
+	return nil
+}
+
+func TestAdminOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	admins := mockAdmins{1: true}
+
+	tests := []struct {
+		userID uint
+		want   int
+	}{
+		{1, http.StatusOK},
+		{2, http.StatusForbidden},
+		{0, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		r := gin.New()
+		r.Use(func(c *gin.Context) { c.Set("user_id", tt.userID) }, AdminOnly(admins))
+		r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if w.Code != tt.want {
+			t.Errorf("user %d: status = %d, want %d", tt.userID, w.Code, tt.want)
+		}
+	}
+}
+
+func TestSelfOrAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	admins := mockAdmins{1: true}
+
+	tests := []struct {
+		userID uint
+		path   string
+		want   int
+	}{
+		{2, "/users/2", http.StatusOK},
+		{2, "/users/3", http.StatusForbidden},
+		{1, "/users/3", http.StatusOK},
+		{2, "/users/abc", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		r := gin.New()
+		r.Use(func(c *gin.Context) { c.Set("user_id", tt.userID) })
+		r.PUT("/users/:id", SelfOrAdmin(admins, "id"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("user %d %s: status = %d, want %d", tt.userID, tt.path, w.Code, tt.want)
+		}
+	}
+}
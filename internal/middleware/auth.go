@@ -6,80 +6,153 @@ import (
 	"time"
 
 	"hrm-app/config"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/pkg/utils"
+	"hrm-app/internal/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// AccessTokenAuthenticator resolves a personal access token to its owner and scopes
+type AccessTokenAuthenticator interface {
+	Authenticate(token string) (uint, []policy.Scope, error)
+}
+
+// AuthMiddleware accepts JWT sessions only
 func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenStr := ""
-
-		// 1. Coba ambil dari Cookie
-		if cookie, err := c.Cookie("access_token"); err == nil {
-			tokenStr = cookie
-		}
-
-		// 2. Jika tidak ada di cookie, coba ambil dari Authorization header
+		tokenStr := tokenFromRequest(c)
 		if tokenStr == "" {
-			authHeader := c.GetHeader("Authorization")
-			if authHeader != "" {
-				parts := strings.SplitN(authHeader, " ", 2)
-				if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
-					tokenStr = parts[1]
-				}
-			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": response.Message(c, "Authentication required"),
+			})
+			return
 		}
 
-		// 3. Jika masih tidak ada, coba ambil dari query parameter (untuk WebSocket)
-		if tokenStr == "" {
-			tokenStr = c.Query("token")
+		if authenticateSession(c, cfg, tokenStr) {
+			c.Next()
 		}
+	}
+}
 
+// ScopedAuthMiddleware accepts JWT sessions, and personal access tokens holding a scope
+// for resource: "<resource>:read" for GET/HEAD and "<resource>:write" for everything else.
+func ScopedAuthMiddleware(cfg *config.Config, tokens AccessTokenAuthenticator, resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenStr := tokenFromRequest(c)
 		if tokenStr == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":   "Unauthorized",
-				"message": "Authentication required",
+				"message": response.Message(c, "Authentication required"),
 			})
 			return
 		}
 
-		// 3. Validasi JWT
-		claims, err := utils.ValidateToken(cfg, tokenStr)
+		// JWTs always have three dot-separated parts; personal access tokens never do
+		if strings.Count(tokenStr, ".") == 2 {
+			if authenticateSession(c, cfg, tokenStr) {
+				c.Next()
+			}
+			return
+		}
+
+		userID, scopes, err := tokens.Authenticate(tokenStr)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":   "Unauthorized",
-				"message": "Invalid or expired token",
+				"message": response.Message(c, "Invalid or expired access token"),
 			})
 			return
 		}
 
-		// 4. Validasi Session di Redis
-		_, err = utils.GetSession(claims.UserID, tokenStr)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Session expired or invalid",
+		write := c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead
+		if !policy.HasScope(scopes, resource, write) {
+			required := resource + ":read"
+			if write {
+				required = resource + ":write"
+			}
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": response.Message(c, "Access token is missing the %s scope", required),
 			})
 			return
 		}
 
-		// Simpan seluruh claims ke context biar bisa diakses langsung di handler lain
-		c.Set("claims", claims)
-		c.Set("user_id", claims.UserID)
-		c.Set("email", claims.Email)
+		c.Set("user_id", userID)
+		c.Set("token_scopes", scopes)
+
+		c.Next()
+	}
+}
+
+func tokenFromRequest(c *gin.Context) string {
+	tokenStr := ""
 
-		// 5. Extend Session (Activity Check / Sliding Window)
-		// Kita perpanjang session setiap kali ada request agar tidak expired selama user aktif
-		expMinutes := cfg.JWT.ExpiresInMinutes
-		if expMinutes == 0 {
-			expMinutes = 15
+	// 1. Coba ambil dari Cookie
+	if cookie, err := c.Cookie("access_token"); err == nil {
+		tokenStr = cookie
+	}
+
+	// 2. Jika tidak ada di cookie, coba ambil dari Authorization header
+	if tokenStr == "" {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader != "" {
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+				tokenStr = parts[1]
+			}
 		}
-		_ = utils.ExtendSession(claims.UserID, tokenStr, time.Duration(expMinutes)*time.Minute)
+	}
 
-		// 6. Perbarui Cookie Access Token agar browser tidak menghapus cookie sebelum Redis expired
-		c.SetCookie("access_token", tokenStr, expMinutes*60, "/", "", false, true)
+	// 3. Jika masih tidak ada, coba ambil dari query parameter (untuk WebSocket)
+	if tokenStr == "" {
+		tokenStr = c.Query("token")
+	}
 
-		c.Next()
+	return tokenStr
+}
+
+// authenticateSession validates a JWT session and stores the caller in the context.
+// It aborts the request and returns false on failure.
+func authenticateSession(c *gin.Context, cfg *config.Config, tokenStr string) bool {
+	// 3. Validasi JWT
+	claims, err := utils.ValidateToken(cfg, tokenStr)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":   "Unauthorized",
+			"message": response.Message(c, "Invalid or expired token"),
+		})
+		return false
+	}
+
+	// 4. Validasi Session di Redis
+	_, err = utils.GetSession(claims.UserID, tokenStr)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":   "Unauthorized",
+			"message": response.Message(c, "Session expired or invalid"),
+		})
+		return false
 	}
+
+	// Simpan seluruh claims ke context biar bisa diakses langsung di handler lain
+	c.Set("claims", claims)
+	c.Set("user_id", claims.UserID)
+	c.Set("email", claims.Email)
+	c.Set("session_id", utils.SessionID(tokenStr))
+
+	// 5. Extend Session (Activity Check / Sliding Window)
+	// Kita perpanjang session setiap kali ada request agar tidak expired selama user aktif
+	expMinutes := cfg.JWT.ExpiresInMinutes
+	if expMinutes == 0 {
+		expMinutes = 15
+	}
+	// Last-seen time, IP and user agent are refreshed for the session list
+	_ = utils.TouchSession(claims.UserID, tokenStr, c.ClientIP(), c.Request.UserAgent(), time.Duration(expMinutes)*time.Minute)
+
+	// 6. Perbarui Cookie Access Token agar browser tidak menghapus cookie sebelum Redis expired
+	c.SetCookie("access_token", tokenStr, expMinutes*60, "/", "", false, true)
+
+	return true
 }
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hrm-app/config"
+	"hrm-app/internal/pkg/policy"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockAccessTokens map[string][]policy.Scope
+
+func (m mockAccessTokens) Authenticate(token string) (uint, []policy.Scope, error) {
+	scopes, ok := m[token]
+	if !ok {
+		return 0, nil, errors.New("invalid token")
+	}
+	return 42, scopes, nil
+}
+
+func TestScopedAuthMiddleware_AccessTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokens := mockAccessTokens{"tpat_reader": {policy.ScopeBoardsRead}}
+	r := gin.New()
+	r.Use(ScopedAuthMiddleware(&config.Config{}, tokens, policy.ResourceBoards))
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetUint("user_id")})
+	}
+	r.GET("/boards", handler)
+	r.POST("/boards", handler)
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		want   int
+	}{
+		{"read scope allows GET", http.MethodGet, "tpat_reader", http.StatusOK},
+		{"read scope denies POST", http.MethodPost, "tpat_reader", http.StatusForbidden},
+		{"unknown token", http.MethodGet, "tpat_unknown", http.StatusUnauthorized},
+		{"missing token", http.MethodGet, "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/boards", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"hrm-app/config"
 	"hrm-app/internal/pkg/utils"
+	"hrm-app/internal/response"
 
 	"github.com/gin-gonic/gin"
 )
@@ -52,7 +53,7 @@ func AuthMiddlewareWS(cfg *config.Config) gin.HandlerFunc {
 			log.Println("[WS Auth] Rejecting: Token missing in all sources (Query, Header, Cookie)")
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":   "Unauthorized",
-				"message": "Authentication token required",
+				"message": response.Message(c, "Authentication token required"),
 			})
 			return
 		}
@@ -65,7 +66,7 @@ func AuthMiddlewareWS(cfg *config.Config) gin.HandlerFunc {
 			log.Printf("[WS Auth] Rejecting: JWT validation failed (Token: %s...): %v", tokenStr[:10], err)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":   "Unauthorized",
-				"message": "Invalid or expired token",
+				"message": response.Message(c, "Invalid or expired token"),
 			})
 			return
 		}
@@ -78,7 +79,7 @@ func AuthMiddlewareWS(cfg *config.Config) gin.HandlerFunc {
 			log.Printf("[WS Auth] Session validation failed for UserID %d: %v", claims.UserID, err)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":   "Unauthorized",
-				"message": "Session expired or invalid",
+				"message": response.Message(c, "Session expired or invalid"),
 			})
 			return
 		}
@@ -87,6 +88,7 @@ func AuthMiddlewareWS(cfg *config.Config) gin.HandlerFunc {
 		c.Set("claims", claims)
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("session_id", utils.SessionID(tokenStr))
 
 		log.Printf("[WS Auth] Successful handshake for UserID %d", claims.UserID)
 
@@ -95,7 +97,7 @@ func AuthMiddlewareWS(cfg *config.Config) gin.HandlerFunc {
 		if expMinutes == 0 {
 			expMinutes = 60 // Default can follow config or 60
 		}
-		_ = utils.ExtendSession(claims.UserID, tokenStr, time.Duration(expMinutes)*time.Minute)
+		_ = utils.TouchSession(claims.UserID, tokenStr, c.ClientIP(), c.Request.UserAgent(), time.Duration(expMinutes)*time.Minute)
 
 		c.Next()
 	}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"hrm-app/internal/pkg/policy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BoardTarget finds boards a request touches. It returns no boards when the
+// request does not name the entity it looks for.
+type BoardTarget func(c *gin.Context) ([]uint, error)
+
+// BoardAccess requires the caller's board role to allow action on every board
+// the targets find, like the WebSocket BoardGuard does for board actions. A
+// request that names no board is rejected. It must run after AuthMiddleware.
+func BoardAccess(authz policy.Authorizer, action policy.Action, targets ...BoardTarget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var boardIDs []uint
+		for _, target := range targets {
+			ids, err := target(c)
+			if err != nil {
+				abortBoardAccess(c, err)
+				return
+			}
+			boardIDs = append(boardIDs, ids...)
+		}
+		if len(boardIDs) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Board could not be determined from the request"})
+			return
+		}
+
+		userID := c.GetUint("user_id")
+		for _, boardID := range boardIDs {
+			if err := authz.AuthorizeBoard(boardID, userID, action); err != nil {
+				abortBoardAccess(c, err)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// BoardParam reads the board id from a route param
+func BoardParam(param string) BoardTarget {
+	return ParamTarget(param, nil)
+}
+
+// ParamTarget reads an entity id from a route param and finds its board with
+// locate, e.g. policy.BoardLocator.BoardIDByTaskCard. A nil locate means the
+// id is a board id.
+func ParamTarget(param string, locate func(id uint) (uint, error)) BoardTarget {
+	return func(c *gin.Context) ([]uint, error) {
+		id, err := strconv.ParseUint(c.Param(param), 10, 32)
+		if err != nil {
+			return nil, errInvalidTarget
+		}
+		return locateBoard(uint(id), locate)
+	}
+}
+
+// BodyTarget reads an entity id from a field of the JSON body and finds its
+// board with locate. The body is restored for the handler. A missing or zero
+// field finds no board.
+//
+// The id is decoded into a struct field tagged with field, so keys match the
+// way ShouldBindJSON matches them: case-insensitively, with the last match
+// winning. Looking the key up exactly would let a case-variant duplicate
+// point the guard and the handler at different entities.
+func BodyTarget(field string, locate func(id uint) (uint, error)) BoardTarget {
+	target := reflect.StructOf([]reflect.StructField{{
+		Name: "ID",
+		Type: reflect.TypeOf(uint(0)),
+		Tag:  reflect.StructTag(`json:"` + field + `"`),
+	}})
+
+	return func(c *gin.Context) ([]uint, error) {
+		if c.Request.Body == nil {
+			return nil, nil
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return nil, errInvalidTarget
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fields := reflect.New(target)
+		if len(body) == 0 || json.Unmarshal(body, fields.Interface()) != nil {
+			return nil, nil
+		}
+		id := uint(fields.Elem().Field(0).Uint())
+		if id == 0 {
+			return nil, nil
+		}
+		return locateBoard(id, locate)
+	}
+}
+
+var errInvalidTarget = errors.New("invalid target id")
+
+func locateBoard(id uint, locate func(id uint) (uint, error)) ([]uint, error) {
+	if locate == nil {
+		return []uint{id}, nil
+	}
+	boardID, err := locate(id)
+	if err != nil {
+		return nil, err
+	}
+	return []uint{boardID}, nil
+}
+
+func abortBoardAccess(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errInvalidTarget):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid id parameter"})
+	case errors.Is(err, policy.ErrTargetNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Target not found"})
+	case policy.IsForbidden(err):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error":   "Forbidden",
+			"message": err.Error(),
+		})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
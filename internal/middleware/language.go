@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Languages reads a user's language setting (implemented by settings.UseCase)
+type Languages interface {
+	Language(userID uint) string
+}
+
+// Language lets response messages follow the caller's settings.language. The
+// setting is only read when a message is written, and after the auth
+// middleware has set user_id; anonymous callers get their Accept-Language.
+func Language(languages Languages) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(response.LanguageKey, func(c *gin.Context) string {
+			if userID, ok := c.Get("user_id"); ok {
+				if id, ok := userID.(uint); ok && id != 0 {
+					return languages.Language(id)
+				}
+			}
+			return i18n.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+		})
+		c.Next()
+	}
+}
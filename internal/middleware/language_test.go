@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hrm-app/internal/pkg/i18n"
+	"hrm-app/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockLanguages map[uint]string
+
+func (m mockLanguages) Language(userID uint) string {
+	return m[userID]
+}
+
+func TestLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	languages := mockLanguages{1: i18n.Indonesian, 2: i18n.English}
+
+	tests := []struct {
+		name   string
+		userID uint
+		header string
+		want   string
+	}{
+		{"user setting", 1, "", "Pengguna tidak ditemukan"},
+		{"user setting wins over header", 2, "id-ID", "User not found"},
+		{"anonymous with header", 0, "id-ID", "Pengguna tidak ditemukan"},
+		{"anonymous without header", 0, "", "User not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Language(languages))
+			r.GET("/", func(c *gin.Context) {
+				if tt.userID != 0 {
+					c.Set("user_id", tt.userID)
+				}
+				response.Error(c, http.StatusNotFound, "User not found")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tt.header)
+			r.ServeHTTP(w, req)
+
+			if want := `{"error":"` + tt.want + `"}`; w.Body.String() != want {
+				t.Errorf("body = %s, want %s", w.Body.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package i18n translates user-facing texts (emails, notifications and API
+// response messages) into the languages a user can pick in their settings.
+package i18n
+
+import "fmt"
+
+// Languages stored in settings.language
+const (
+	English    = "en_us"
+	Indonesian = "indo"
+
+	Default = English
+)
+
+// Supported reports whether lang is a known language
+func Supported(lang string) bool {
+	_, ok := catalog[lang]
+	return ok
+}
+
+// T returns the text for key in lang, formatted with args. Unknown languages
+// fall back to English, unknown keys to the key itself.
+func T(lang, key string, args ...interface{}) string {
+	text, ok := catalog[lang][key]
+	if !ok {
+		text, ok = catalog[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+var catalog = map[string]map[string]string{
+	English: {
+		"email.password_reset.subject": "Reset your password",
+		"email.password_reset.body":    "Hi %s,\n\nUse the link below to reset your password. It expires in %d minutes.\n\n%s\n\nIf you did not request a password reset, you can ignore this email.\n",
+
+		"email.verification.subject": "Verify your email address",
+		"email.verification.body":    "Hi %s,\n\nPlease confirm your email address by opening the link below. It expires on %s.\n\n%s\n\nIf you did not create an account, you can ignore this email.\n",
+
+		"email.invitation.subject": "You're invited to join %s",
+		"email.invitation.body":    "Hi,\n\n%s invited you to join the workspace \"%s\" as %s.\n\nOpen the link below to accept or decline. If you don't have an account yet, sign up with this email address and the invitation is accepted for you.\n\n%s\n\nThe invitation expires on %s.\n",
+
+		"notification.workspace_member_added.title":       "You were added to a workspace",
+		"notification.workspace_member_added.body":        "You were added to the workspace \"%s\" as %s.",
+		"notification.board_member_added.title":           "You were added to a board",
+		"notification.board_member_added.body":            "You were added to the board \"%s\" as %s.",
+		"notification.task_card_assigned.title":           "You were assigned to a card",
+		"notification.task_card_assigned.body":            "You were assigned to the card \"%s\".",
+		"notification.workspace_ownership_offered.title":  "Workspace ownership offered to you",
+		"notification.workspace_ownership_offered.body":   "%s wants to make you the owner of the workspace \"%s\". Accept or decline the transfer in the workspace settings.",
+		"notification.workspace_ownership_accepted.title": "Ownership transfer accepted",
+		"notification.workspace_ownership_accepted.body":  "%s is now the owner of the workspace \"%s\". You remain an admin.",
+		"notification.workspace_ownership_declined.title": "Ownership transfer declined",
+		"notification.workspace_ownership_declined.body":  "%s declined to become the owner of the workspace \"%s\".",
+		"notification.workspace_join_requested.title":     "New request to join a workspace",
+		"notification.workspace_join_requested.body":      "%s asked to join the workspace \"%s\". Approve or reject the request in the workspace settings.",
+		"notification.workspace_join_approved.title":      "Join request approved",
+		"notification.workspace_join_approved.body":       "Your request to join the workspace \"%s\" was approved. You joined as %s.",
+		"notification.workspace_join_rejected.title":      "Join request rejected",
+		"notification.workspace_join_rejected.body":       "Your request to join the workspace \"%s\" was rejected.",
+
+		"email.notification.body": "Hi %s,\n\n%s\n\nYou can turn off notifications in your settings.\n",
+	},
+	Indonesian: {
+		"email.password_reset.subject": "Atur ulang kata sandi Anda",
+		"email.password_reset.body":    "Halo %s,\n\nGunakan tautan di bawah ini untuk mengatur ulang kata sandi Anda. Tautan berlaku selama %d menit.\n\n%s\n\nJika Anda tidak meminta pengaturan ulang kata sandi, abaikan email ini.\n",
+
+		"email.verification.subject": "Verifikasi alamat email Anda",
+		"email.verification.body":    "Halo %s,\n\nSilakan konfirmasi alamat email Anda dengan membuka tautan di bawah ini. Tautan berlaku hingga %s.\n\n%s\n\nJika Anda tidak membuat akun, abaikan email ini.\n",
+
+		"email.invitation.subject": "Anda diundang untuk bergabung dengan %s",
+		"email.invitation.body":    "Halo,\n\n%s mengundang Anda untuk bergabung dengan workspace \"%s\" sebagai %s.\n\nBuka tautan di bawah ini untuk menerima atau menolak. Jika Anda belum memiliki akun, daftar dengan alamat email ini dan undangan akan diterima secara otomatis.\n\n%s\n\nUndangan berlaku hingga %s.\n",
+
+		"notification.workspace_member_added.title":       "Anda ditambahkan ke workspace",
+		"notification.workspace_member_added.body":        "Anda ditambahkan ke workspace \"%s\" sebagai %s.",
+		"notification.board_member_added.title":           "Anda ditambahkan ke board",
+		"notification.board_member_added.body":            "Anda ditambahkan ke board \"%s\" sebagai %s.",
+		"notification.task_card_assigned.title":           "Anda ditugaskan ke kartu",
+		"notification.task_card_assigned.body":            "Anda ditugaskan ke kartu \"%s\".",
+		"notification.workspace_ownership_offered.title":  "Kepemilikan workspace ditawarkan kepada Anda",
+		"notification.workspace_ownership_offered.body":   "%s ingin menjadikan Anda pemilik workspace \"%s\". Terima atau tolak pengalihan di pengaturan workspace.",
+		"notification.workspace_ownership_accepted.title": "Pengalihan kepemilikan diterima",
+		"notification.workspace_ownership_accepted.body":  "%s sekarang menjadi pemilik workspace \"%s\". Anda tetap menjadi admin.",
+		"notification.workspace_ownership_declined.title": "Pengalihan kepemilikan ditolak",
+		"notification.workspace_ownership_declined.body":  "%s menolak menjadi pemilik workspace \"%s\".",
+		"notification.workspace_join_requested.title":     "Permintaan baru untuk bergabung dengan workspace",
+		"notification.workspace_join_requested.body":      "%s meminta untuk bergabung dengan workspace \"%s\". Setujui atau tolak permintaan di pengaturan workspace.",
+		"notification.workspace_join_approved.title":      "Permintaan bergabung disetujui",
+		"notification.workspace_join_approved.body":       "Permintaan Anda untuk bergabung dengan workspace \"%s\" disetujui. Anda bergabung sebagai %s.",
+		"notification.workspace_join_rejected.title":      "Permintaan bergabung ditolak",
+		"notification.workspace_join_rejected.body":       "Permintaan Anda untuk bergabung dengan workspace \"%s\" ditolak.",
+
+		"email.notification.body": "Halo %s,\n\n%s\n\nAnda dapat menonaktifkan notifikasi di pengaturan.\n",
+	},
+}
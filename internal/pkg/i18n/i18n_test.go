@@ -0,0 +1,58 @@
+package i18n
+
+import "testing"
+
+func TestT(t *testing.T) {
+	if got := T(Indonesian, "notification.board_member_added.body", "Sprint", "editor"); got != "Anda ditambahkan ke board \"Sprint\" sebagai editor." {
+		t.Errorf("indo = %q", got)
+	}
+	if got := T("fr", "email.verification.subject"); got != "Verify your email address" {
+		t.Errorf("unknown language should fall back to English, got %q", got)
+	}
+	if got := T(English, "missing.key"); got != "missing.key" {
+		t.Errorf("unknown key = %q", got)
+	}
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for key := range catalog[Default] {
+		for lang, texts := range catalog {
+			if _, ok := texts[key]; !ok {
+				t.Errorf("%s is missing %q", lang, key)
+			}
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	if got := Message(Indonesian, "Board created successfully"); got != "Board berhasil dibuat" {
+		t.Errorf("indo = %q", got)
+	}
+	if got := Message(English, "Board created successfully"); got != "Board created successfully" {
+		t.Errorf("en = %q", got)
+	}
+	if got := Message(Indonesian, "record not found"); got != "record not found" {
+		t.Errorf("untranslated message should stay English, got %q", got)
+	}
+	if got := Message(Indonesian, "Access token is missing the %s scope", "boards:write"); got != "Token akses tidak memiliki scope boards:write" {
+		t.Errorf("formatted indo = %q", got)
+	}
+	if got := Message(English, "Access token is missing the %s scope", "boards:write"); got != "Access token is missing the boards:write scope" {
+		t.Errorf("formatted en = %q", got)
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                        Default,
+		"id-ID,id;q=0.9,en;q=0.8": Indonesian,
+		"en-US,en;q=0.9,id;q=0.8": English,
+		"fr-FR,fr;q=0.9,id;q=0.5": Indonesian,
+		"de":                      Default,
+	}
+	for header, want := range tests {
+		if got := FromAcceptLanguage(header); got != want {
+			t.Errorf("FromAcceptLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
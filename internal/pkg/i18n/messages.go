@@ -0,0 +1,152 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message translates an API response message into lang, formatted with args.
+// API messages are keyed by their English text so handlers keep writing plain
+// strings; messages without a translation, such as errors passed through from
+// lower layers, stay English.
+func Message(lang, text string, args ...interface{}) string {
+	if translated, ok := messages[lang][text]; ok {
+		text = translated
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// FromAcceptLanguage picks the language for a caller without settings from an
+// Accept-Language header, e.g. "id-ID,id;q=0.9,en;q=0.8"
+func FromAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.ToLower(strings.TrimSpace(strings.SplitN(part, ";", 2)[0]))
+		switch {
+		case tag == "id" || strings.HasPrefix(tag, "id-"):
+			return Indonesian
+		case tag == "en" || strings.HasPrefix(tag, "en-"):
+			return English
+		}
+	}
+	return Default
+}
+
+var messages = map[string]map[string]string{
+	Indonesian: {
+		// Generic
+		"Unauthorized":            "Tidak diizinkan",
+		"Authentication required": "Autentikasi diperlukan",
+		"invalid request body":    "Isi permintaan tidak valid",
+		"file is required":        "File wajib diisi",
+		"File is required":        "File wajib diisi",
+		"token is required":       "Token wajib diisi",
+		"User not found":          "Pengguna tidak ditemukan",
+		"Comment not found":       "Komentar tidak ditemukan",
+		"Contact not found":       "Kontak tidak ditemukan",
+
+		// Invalid parameters
+		"Invalid ID parameter":           "Parameter ID tidak valid",
+		"invalid ID parameter":           "Parameter ID tidak valid",
+		"Invalid id parameter":           "Parameter id tidak valid",
+		"Invalid board ID":               "ID board tidak valid",
+		"Invalid board_id parameter":     "Parameter board_id tidak valid",
+		"Invalid task card ID parameter": "Parameter ID kartu tidak valid",
+		"invalid task card id":           "ID kartu tidak valid",
+		"Invalid task tab ID parameter":  "Parameter ID tab tidak valid",
+		"Invalid task tab ID":            "ID tab tidak valid",
+		"Invalid workspace ID":           "ID workspace tidak valid",
+		"Invalid workspace_id parameter": "Parameter workspace_id tidak valid",
+		"invalid workspace ID parameter": "Parameter ID workspace tidak valid",
+		"invalid room ID parameter":      "Parameter ID room tidak valid",
+		"email or ip is required":        "Email atau IP wajib diisi",
+		"format must be json or csv":     "Format harus json atau csv",
+		"format must be json or zip":     "Format harus json atau zip",
+
+		// Authentication and sessions
+		"Login successful":                                       "Berhasil masuk",
+		"Logged out successfully":                                "Berhasil keluar",
+		"Logged out from all sessions":                           "Berhasil keluar dari semua sesi",
+		"User logged out from all sessions":                      "Pengguna dikeluarkan dari semua sesi",
+		"Account is disabled":                                    "Akun dinonaktifkan",
+		"Email address has not been verified":                    "Alamat email belum diverifikasi",
+		"Authentication token required":                          "Token autentikasi diperlukan",
+		"Invalid or expired token":                               "Token tidak valid atau kedaluwarsa",
+		"Invalid or expired access token":                        "Token akses tidak valid atau kedaluwarsa",
+		"Access token is missing the %s scope":                   "Token akses tidak memiliki scope %s",
+		"Invalid or expired MFA token":                           "Token MFA tidak valid atau kedaluwarsa",
+		"Session expired or invalid":                             "Sesi kedaluwarsa atau tidak valid",
+		"Invalid refresh token":                                  "Refresh token tidak valid",
+		"Refresh token missing":                                  "Refresh token tidak ada",
+		"Refresh token has been revoked":                         "Refresh token telah dicabut",
+		"Refresh token reuse detected, please log in again":      "Penggunaan ulang refresh token terdeteksi, silakan masuk kembali",
+		"Failed to rotate refresh token":                         "Gagal memperbarui refresh token",
+		"Failed to generate tokens":                              "Gagal membuat token",
+		"Failed to store session":                                "Gagal menyimpan sesi",
+		"Signing keys unavailable":                               "Kunci penandatanganan tidak tersedia",
+		"Too many failed login attempts, please try again later": "Terlalu banyak percobaan masuk yang gagal, silakan coba lagi nanti",
+		"Login unlocked":                                         "Kunci login dibuka",
+		"Failed to unlock login":                                 "Gagal membuka kunci login",
+		"Two-factor authentication required":                     "Autentikasi dua faktor diperlukan",
+		"Two-factor authentication disabled":                     "Autentikasi dua faktor dinonaktifkan",
+		"Failed to check two-factor authentication":              "Gagal memeriksa autentikasi dua faktor",
+		"Identity provider is unavailable":                       "Penyedia identitas tidak tersedia",
+		"Single sign-on failed":                                  "Single sign-on gagal",
+		"Session revoked successfully":                           "Sesi berhasil dicabut",
+		"Failed to list sessions":                                "Gagal memuat daftar sesi",
+		"Failed to revoke session":                               "Gagal mencabut sesi",
+		"Failed to revoke sessions":                              "Gagal mencabut sesi",
+		"Access token not found":                                 "Token akses tidak ditemukan",
+		"Access token revoked successfully":                      "Token akses berhasil dicabut",
+		"Failed to create access token":                          "Gagal membuat token akses",
+
+		// Account
+		"User registered successfully":         "Pengguna berhasil didaftarkan",
+		"User updated successfully":            "Pengguna berhasil diperbarui",
+		"User deleted successfully":            "Pengguna berhasil dihapus",
+		"User disabled successfully":           "Pengguna berhasil dinonaktifkan",
+		"User enabled successfully":            "Pengguna berhasil diaktifkan",
+		"Failed to list users":                 "Gagal memuat daftar pengguna",
+		"You can only manage your own account": "Anda hanya dapat mengelola akun Anda sendiri",
+		"Account closed successfully":          "Akun berhasil ditutup",
+		"Failed to close account":              "Gagal menutup akun",
+		"Failed to export data":                "Gagal mengekspor data",
+		"Email verified successfully":          "Email berhasil diverifikasi",
+		"Failed to verify email":               "Gagal memverifikasi email",
+		"Failed to send verification link":     "Gagal mengirim tautan verifikasi",
+		"If the email is registered and unverified, a verification link has been sent": "Jika email terdaftar dan belum diverifikasi, tautan verifikasi telah dikirim",
+		"If the email is registered, a reset link has been sent":                       "Jika email terdaftar, tautan pengaturan ulang telah dikirim",
+		"Password reset successfully":                                                  "Kata sandi berhasil diatur ulang",
+		"Failed to reset password":                                                     "Gagal mengatur ulang kata sandi",
+		"Failed to get settings":                                                       "Gagal memuat pengaturan",
+		"Failed to update settings":                                                    "Gagal memperbarui pengaturan",
+		"Failed to list audit log":                                                     "Gagal memuat log audit",
+
+		// Workspaces and boards
+		"Joined workspace successfully":        "Berhasil bergabung dengan workspace",
+		"Left workspace successfully":          "Berhasil keluar dari workspace",
+		"Workspace moved to the trash":         "Workspace dipindahkan ke tempat sampah",
+		"WorkspacesUsers deleted successfully": "Anggota workspace berhasil dihapus",
+		"Failed to delete workspacesUsers":     "Gagal menghapus anggota workspace",
+		"Role updated successfully":            "Peran berhasil diperbarui",
+		"Invitation declined":                  "Undangan ditolak",
+		"Board created successfully":           "Board berhasil dibuat",
+		"Board updated successfully":           "Board berhasil diperbarui",
+		"Board deleted successfully":           "Board berhasil dihapus",
+		"Joined board successfully":            "Berhasil bergabung dengan board",
+		"Board user deleted successfully":      "Anggota board berhasil dihapus",
+		"Failed to delete board user":          "Gagal menghapus anggota board",
+		"Template deleted successfully":        "Template berhasil dihapus",
+		"Failed to list activity":              "Gagal memuat aktivitas",
+		"Failed to export board":               "Gagal mengekspor board",
+		"Failed to import board":               "Gagal mengimpor board",
+		"failed to create task card user":      "Gagal menugaskan pengguna ke kartu",
+		"failed to get task card users":        "Gagal memuat pengguna kartu",
+
+		// Chat
+		"room chat deleted successfully": "Room chat berhasil dihapus",
+		"success join room":              "Berhasil bergabung dengan room",
+	},
+}
@@ -0,0 +1,202 @@
+// Package jwtkeys holds the keys used to sign and verify our JWTs.
+//
+// One asymmetric key (RS256 or EdDSA) signs new tokens and is named in the
+// "kid" header. Older keys stay configured as verification-only keys, so keys
+// can be rotated without logging everyone out, and the public halves are
+// published as a JWKS for other services to verify tokens offline.
+// The legacy HS256 secret still verifies tokens without a "kid" while it is set.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"hrm-app/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoSigningKey = errors.New("no JWT signing key configured")
+	ErrUnknownKey   = errors.New("unknown signing key")
+)
+
+// Key is one signing or verification key
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private crypto.PrivateKey // nil for verification-only keys
+	public  crypto.PublicKey
+}
+
+// CanSign reports whether the private half of the key is available
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	legacy  *Key // HS256 secret, matched by tokens without kid
+}
+
+// Load builds the key set from cfg.JWT. Without a signing_key_id new tokens are
+// signed with the legacy HS256 secret, as before.
+func Load(cfg *config.Config) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key)}
+
+	if cfg.JWT.Secret != "" {
+		secret := []byte(cfg.JWT.Secret)
+		ks.legacy = &Key{Method: jwt.SigningMethodHS256, private: secret, public: secret}
+	}
+
+	for _, kc := range cfg.JWT.Keys {
+		if kc.ID == "" {
+			return nil, errors.New("jwt key without id")
+		}
+		if _, ok := ks.keys[kc.ID]; ok {
+			return nil, fmt.Errorf("duplicate jwt key id %q", kc.ID)
+		}
+
+		key, err := loadKey(kc.ID, kc.PrivateKeyFile, kc.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", kc.ID, err)
+		}
+		ks.keys[kc.ID] = key
+	}
+
+	switch {
+	case cfg.JWT.SigningKeyID != "":
+		key, ok := ks.keys[cfg.JWT.SigningKeyID]
+		if !ok {
+			return nil, fmt.Errorf("signing key %q is not configured", cfg.JWT.SigningKeyID)
+		}
+		if !key.CanSign() {
+			return nil, fmt.Errorf("signing key %q has no private key", cfg.JWT.SigningKeyID)
+		}
+		ks.signing = key
+	case ks.legacy != nil:
+		ks.signing = ks.legacy
+	default:
+		return nil, ErrNoSigningKey
+	}
+
+	return ks, nil
+}
+
+func loadKey(id, privateFile, publicFile string) (*Key, error) {
+	key := &Key{ID: id}
+
+	if privateFile != "" {
+		pem, err := os.ReadFile(privateFile)
+		if err != nil {
+			return nil, err
+		}
+		if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(pem); err == nil {
+			key.Method, key.private, key.public = jwt.SigningMethodRS256, rsaKey, &rsaKey.PublicKey
+		} else if edKey, err := jwt.ParseEdPrivateKeyFromPEM(pem); err == nil {
+			key.Method, key.private, key.public = jwt.SigningMethodEdDSA, edKey, edKey.(ed25519.PrivateKey).Public()
+		} else {
+			return nil, errors.New("private key must be an RSA or Ed25519 PEM key")
+		}
+	}
+
+	if publicFile != "" {
+		pem, err := os.ReadFile(publicFile)
+		if err != nil {
+			return nil, err
+		}
+		var method jwt.SigningMethod
+		var public crypto.PublicKey
+		if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+			method, public = jwt.SigningMethodRS256, rsaKey
+		} else if edKey, err := jwt.ParseEdPublicKeyFromPEM(pem); err == nil {
+			method, public = jwt.SigningMethodEdDSA, edKey
+		} else {
+			return nil, errors.New("public key must be an RSA or Ed25519 PEM key")
+		}
+		if key.Method != nil && key.Method != method {
+			return nil, errors.New("public key does not match private key")
+		}
+		key.Method, key.public = method, public
+	}
+
+	if key.public == nil {
+		return nil, errors.New("private_key_file or public_key_file is required")
+	}
+	return key, nil
+}
+
+// Sign signs claims with the active signing key
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	if ks.signing.ID != "" {
+		token.Header["kid"] = ks.signing.ID
+	}
+	return token.SignedString(ks.signing.private)
+}
+
+// Keyfunc resolves the verification key of a token for jwt.Parse. The token's
+// algorithm must match the key, so a public key can never be used as an HMAC secret.
+func (ks *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	var key *Key
+	if kid, ok := t.Header["kid"].(string); ok && kid != "" {
+		key = ks.keys[kid]
+	} else {
+		key = ks.legacy
+	}
+	if key == nil {
+		return nil, ErrUnknownKey
+	}
+
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.public, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of every asymmetric key. The HS256 secret is never published.
+func (ks *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range ks.keys {
+		jwk := JWK{Use: "sig", Alg: key.Method.Alg(), Kid: key.ID}
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
@@ -0,0 +1,168 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hrm-app/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeKey(t *testing.T, name string, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writePublicKey(t *testing.T, name string, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+type keyConfig = struct {
+	ID             string `mapstructure:"id"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+}
+
+func testClaims() jwt.Claims {
+	return jwt.RegisteredClaims{Subject: "access_token", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+}
+
+func parse(ks *KeySet, token string) error {
+	_, err := jwt.ParseWithClaims(token, &jwt.RegisteredClaims{}, ks.Keyfunc)
+	return err
+}
+
+func TestRotation(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Old deployment: signs with the RSA key
+	oldCfg := &config.Config{}
+	oldCfg.JWT.SigningKeyID = "old"
+	oldCfg.JWT.Keys = []keyConfig{{ID: "old", PrivateKeyFile: writeKey(t, "old.pem", rsaKey)}}
+	oldKeys, err := Load(oldCfg)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	oldToken, err := oldKeys.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// New deployment: signs with Ed25519, keeps only the public half of the old key
+	cfg := &config.Config{}
+	cfg.JWT.SigningKeyID = "new"
+	cfg.JWT.Keys = []keyConfig{
+		{ID: "new", PrivateKeyFile: writeKey(t, "new.pem", edKey)},
+		{ID: "old", PublicKeyFile: writePublicKey(t, "old.pub.pem", &rsaKey.PublicKey)},
+	}
+	keys, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	newToken, err := keys.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, _, _ := jwt.NewParser().ParseUnverified(newToken, &jwt.RegisteredClaims{})
+	if token.Header["kid"] != "new" || token.Method.Alg() != "EdDSA" {
+		t.Errorf("header = %v, want kid new and EdDSA", token.Header)
+	}
+
+	if err := parse(keys, newToken); err != nil {
+		t.Errorf("new token rejected: %v", err)
+	}
+	if err := parse(keys, oldToken); err != nil {
+		t.Errorf("token signed by the retired key rejected: %v", err)
+	}
+	if err := parse(oldKeys, newToken); err == nil {
+		t.Error("token with unknown kid accepted")
+	}
+
+	jwks := keys.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Kid != "new" || jwks.Keys[0].Kty != "OKP" || jwks.Keys[1].Kty != "RSA" {
+		t.Errorf("JWKS = %+v", jwks)
+	}
+}
+
+func TestLegacySecret(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	legacyCfg := &config.Config{}
+	legacyCfg.JWT.Secret = "secret"
+	legacyKeys, err := Load(legacyCfg)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	legacyToken, _ := legacyKeys.Sign(testClaims())
+
+	cfg := &config.Config{}
+	cfg.JWT.Secret = "secret"
+	cfg.JWT.SigningKeyID = "k1"
+	cfg.JWT.Keys = []keyConfig{{ID: "k1", PrivateKeyFile: writeKey(t, "k1.pem", edKey)}}
+	keys, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if err := parse(keys, legacyToken); err != nil {
+		t.Errorf("legacy HS256 token rejected during migration: %v", err)
+	}
+	if len(keys.JWKS().Keys) != 1 {
+		t.Error("HS256 secret must not be published")
+	}
+
+	// An HS256 token naming an asymmetric key must not be verified with its public key
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	forged.Header["kid"] = "k1"
+	forgedStr, _ := forged.SignedString([]byte(edKey.Public().(ed25519.PublicKey)))
+	if err := parse(keys, forgedStr); err == nil {
+		t.Error("algorithm confusion token accepted")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	if _, err := Load(&config.Config{}); err != ErrNoSigningKey {
+		t.Errorf("empty config: err = %v, want ErrNoSigningKey", err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{}
+	cfg.JWT.SigningKeyID = "pub"
+	cfg.JWT.Keys = []keyConfig{{ID: "pub", PublicKeyFile: writePublicKey(t, "pub.pem", &rsaKey.PublicKey)}}
+	if _, err := Load(cfg); err == nil {
+		t.Error("verification-only key accepted as signing key")
+	}
+}
@@ -0,0 +1,150 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockIdP is a minimal OpenID provider serving discovery, keys and the token endpoint
+type mockIdP struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	// codes maps an authorization code to the PKCE challenge and nonce it was issued for
+	codes map[string][2]string
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &mockIdP{key: key, clientID: "traspac", codes: map[string][2]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		issued, ok := idp.codes[r.PostForm.Get("code")]
+		if !ok || CodeChallenge(r.PostForm.Get("code_verifier")) != issued[0] {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "opaque",
+			"id_token":     idp.idToken(t, issued[1]),
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *mockIdP) idToken(t *testing.T, nonce string) string {
+	claims := &Claims{
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Nonce:         nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    idp.server.URL,
+			Subject:   "idp-user-1",
+			Audience:  jwt.ClaimStrings{idp.clientID},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test-key"
+	signed, err := token.SignedString(idp.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// authorize simulates the user logging in at the IdP and returns the code
+func (idp *mockIdP) authorize(t *testing.T, authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		t.Fatalf("expected S256 PKCE, got %q", q.Get("code_challenge_method"))
+	}
+	idp.codes["code-1"] = [2]string{q.Get("code_challenge"), q.Get("nonce")}
+	return "code-1"
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp := newMockIdP(t)
+	provider := NewProvider(Config{
+		Issuer:      idp.server.URL,
+		ClientID:    idp.clientID,
+		RedirectURL: "http://localhost:8080/api/v1/oidc/callback",
+	}, idp.server.Client())
+	ctx := context.Background()
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	code := idp.authorize(t, authURL)
+
+	t.Run("wrong verifier is rejected", func(t *testing.T) {
+		if _, err := provider.Exchange(ctx, code, "other-verifier", "nonce-1"); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("wrong nonce is rejected", func(t *testing.T) {
+		if _, err := provider.Exchange(ctx, code, "verifier-1", "nonce-2"); err != ErrNonceMismatch {
+			t.Errorf("expected ErrNonceMismatch, got %v", err)
+		}
+	})
+
+	t.Run("valid exchange", func(t *testing.T) {
+		claims, err := provider.Exchange(ctx, code, "verifier-1", "nonce-1")
+		if err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		if claims.Subject != "idp-user-1" || claims.Email != "jane@example.com" || !claims.EmailVerified {
+			t.Errorf("unexpected claims %+v", claims)
+		}
+	})
+
+	t.Run("wrong audience is rejected", func(t *testing.T) {
+		other := NewProvider(Config{Issuer: idp.server.URL, ClientID: "someone-else"}, idp.server.Client())
+		if _, err := other.verify(ctx, idp.idToken(t, "n")); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"errors"
+
+	"hrm-app/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// ErrTargetNotFound is returned when the entity an action targets does not exist
+var ErrTargetNotFound = errors.New("target not found")
+
+// BoardLocator finds the board that owns a board-scoped entity, walking
+// card -> tab -> board without loading the entities themselves.
+type BoardLocator interface {
+	BoardIDByTaskTab(taskTabID uint) (uint, error)
+	BoardIDByTaskCard(taskCardID uint) (uint, error)
+	BoardIDByLabel(labelID uint) (uint, error)
+	BoardIDByComment(commentID uint) (uint, error)
+	BoardIDByTaskCardUser(taskCardUserID uint) (uint, error)
+	BoardIDByBoardUser(boardUserID uint) (uint, error)
+}
+
+type locator struct{}
+
+func NewBoardLocator() BoardLocator {
+	return &locator{}
+}
+
+func (l *locator) BoardIDByTaskTab(taskTabID uint) (uint, error) {
+	return pluckBoardID(database.DB.Table("task_tabs").
+		Select("task_tabs.board_id").
+		Where("task_tabs.id = ?", taskTabID))
+}
+
+func (l *locator) BoardIDByTaskCard(taskCardID uint) (uint, error) {
+	return pluckBoardID(database.DB.Table("task_cards").
+		Select("task_tabs.board_id").
+		Joins("JOIN task_tabs ON task_tabs.id = task_cards.task_tab_id").
+		Where("task_cards.id = ?", taskCardID))
+}
+
+func (l *locator) BoardIDByLabel(labelID uint) (uint, error) {
+	return pluckBoardID(database.DB.Table("task_card_labels").
+		Select("task_tabs.board_id").
+		Joins("JOIN task_cards ON task_cards.id = task_card_labels.task_card_id").
+		Joins("JOIN task_tabs ON task_tabs.id = task_cards.task_tab_id").
+		Where("task_card_labels.id = ?", labelID))
+}
+
+func (l *locator) BoardIDByComment(commentID uint) (uint, error) {
+	return pluckBoardID(database.DB.Table("task_card_comments").
+		Select("task_tabs.board_id").
+		Joins("JOIN task_cards ON task_cards.id = task_card_comments.task_card_id").
+		Joins("JOIN task_tabs ON task_tabs.id = task_cards.task_tab_id").
+		Where("task_card_comments.id = ?", commentID))
+}
+
+func (l *locator) BoardIDByTaskCardUser(taskCardUserID uint) (uint, error) {
+	return pluckBoardID(database.DB.Table("task_card_users").
+		Select("task_tabs.board_id").
+		Joins("JOIN task_cards ON task_cards.id = task_card_users.task_card_id").
+		Joins("JOIN task_tabs ON task_tabs.id = task_cards.task_tab_id").
+		Where("task_card_users.id = ?", taskCardUserID))
+}
+
+func (l *locator) BoardIDByBoardUser(boardUserID uint) (uint, error) {
+	return pluckBoardID(database.DB.Table("boards_users").
+		Select("boards_users.board_id").
+		Where("boards_users.id = ?", boardUserID))
+}
+
+func pluckBoardID(query *gorm.DB) (uint, error) {
+	var ids []uint
+	if err := query.Limit(1).Pluck("board_id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, ErrTargetNotFound
+	}
+	return ids[0], nil
+}
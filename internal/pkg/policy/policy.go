@@ -0,0 +1,132 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotMember is returned when the user has no role on the workspace or board
+	ErrNotMember = errors.New("unauthorized: you are not a member")
+	// ErrForbidden is returned when the user's role does not allow the action
+	ErrForbidden = errors.New("unauthorized: insufficient role for this action")
+	// ErrReadOnly is returned for anything but viewing an archived or trashed workspace
+	ErrReadOnly = fmt.Errorf("%w: workspace is archived and read-only", ErrForbidden)
+)
+
+// IsForbidden reports whether err was produced by a failed policy check
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrNotMember) || errors.Is(err, ErrForbidden)
+}
+
+// Authorizer resolves roles and checks them against actions. It is shared by
+// the REST usecases and the WebSocket handlers so both enforce the same rules.
+type Authorizer interface {
+	WorkspaceRole(workspaceID, userID uint) (Role, error)
+	BoardRole(boardID, userID uint) (Role, error)
+	AuthorizeWorkspace(workspaceID, userID uint, action Action) error
+	AuthorizeBoard(boardID, userID uint, action Action) error
+	CheckWritable(workspaceID uint) error
+}
+
+type authorizer struct {
+	repo Repository
+}
+
+func New(repo Repository) Authorizer {
+	return &authorizer{repo: repo}
+}
+
+func (a *authorizer) WorkspaceRole(workspaceID, userID uint) (Role, error) {
+	return a.repo.FindWorkspaceRole(workspaceID, userID)
+}
+
+// BoardRole returns the effective role on a board. Workspace owners and admins
+// inherit their workspace role on every board in the workspace; otherwise the
+// board membership role applies.
+func (a *authorizer) BoardRole(boardID, userID uint) (Role, error) {
+	role, _, err := a.boardRole(boardID, userID)
+	return role, err
+}
+
+func (a *authorizer) boardRole(boardID, userID uint) (Role, uint, error) {
+	boardRole, err := a.repo.FindBoardRole(boardID, userID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	workspaceID, err := a.repo.FindBoardWorkspaceID(boardID)
+	if err != nil {
+		return "", 0, err
+	}
+	if workspaceID == 0 {
+		return boardRole, 0, nil
+	}
+
+	workspaceRole, err := a.repo.FindWorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return "", 0, err
+	}
+	if workspaceRole.AtLeast(RoleAdmin) && !boardRole.AtLeast(workspaceRole) {
+		return workspaceRole, workspaceID, nil
+	}
+
+	return boardRole, workspaceID, nil
+}
+
+func (a *authorizer) AuthorizeWorkspace(workspaceID, userID uint, action Action) error {
+	role, err := a.WorkspaceRole(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if err := check(role, action); err != nil {
+		return err
+	}
+	return a.checkWritable(workspaceID, action)
+}
+
+func (a *authorizer) AuthorizeBoard(boardID, userID uint, action Action) error {
+	role, workspaceID, err := a.boardRole(boardID, userID)
+	if err != nil {
+		return err
+	}
+	if err := check(role, action); err != nil {
+		return err
+	}
+	return a.checkWritable(workspaceID, action)
+}
+
+// CheckWritable refuses any change to an archived or trashed workspace.
+// AuthorizeWorkspace and AuthorizeBoard apply it already; membership paths
+// that check roles with CanAssignRole or CanAssignBoardRole call it themselves.
+func (a *authorizer) CheckWritable(workspaceID uint) error {
+	if workspaceID == 0 {
+		return nil
+	}
+	readOnly, err := a.repo.IsWorkspaceReadOnly(workspaceID)
+	if err != nil {
+		return err
+	}
+	if readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// checkWritable refuses everything but viewing in an archived or trashed workspace
+func (a *authorizer) checkWritable(workspaceID uint, action Action) error {
+	if action == ActionView {
+		return nil
+	}
+	return a.CheckWritable(workspaceID)
+}
+
+func check(role Role, action Action) error {
+	if !role.Valid() {
+		return ErrNotMember
+	}
+	if !Can(role, action) {
+		return fmt.Errorf("%w: %s cannot %s", ErrForbidden, role, action)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+)
+
+type mockRepository struct {
+	workspaceRoles map[uint]Role
+	boardRoles     map[uint]Role
+	workspaceID    uint
+	readOnly       bool
+}
+
+func (m *mockRepository) FindWorkspaceRole(workspaceID, userID uint) (Role, error) {
+	return m.workspaceRoles[userID], nil
+}
+
+func (m *mockRepository) FindBoardRole(boardID, userID uint) (Role, error) {
+	return m.boardRoles[userID], nil
+}
+
+func (m *mockRepository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return m.workspaceID, nil
+}
+
+func (m *mockRepository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return m.readOnly, nil
+}
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		role   Role
+		action Action
+		want   bool
+	}{
+		{RoleOwner, ActionDelete, true},
+		{RoleAdmin, ActionDelete, false},
+		{RoleAdmin, ActionManageMembers, true},
+		{RoleMember, ActionEdit, true},
+		{RoleMember, ActionInvite, false},
+		{RoleGuest, ActionComment, true},
+		{RoleGuest, ActionEdit, false},
+		{RoleViewer, ActionView, true},
+		{RoleViewer, ActionComment, false},
+		{RoleEditor, ActionEdit, true},
+		{RoleEditor, ActionManageMembers, false},
+		{RoleCommenter, ActionComment, true},
+		{RoleCommenter, ActionEdit, false},
+		{"", ActionView, false},
+		{"superuser", ActionView, false},
+	}
+
+	for _, tt := range tests {
+		if got := Can(tt.role, tt.action); got != tt.want {
+			t.Errorf("Can(%q, %q) = %v, want %v", tt.role, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestCanAssignRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		actor, from, to Role
+		want            bool
+	}{
+		{"owner promotes member to admin", RoleOwner, RoleMember, RoleAdmin, true},
+		{"owner cannot grant ownership", RoleOwner, RoleAdmin, RoleOwner, false},
+		{"admin adds member", RoleAdmin, "", RoleMember, true},
+		{"admin cannot create admin", RoleAdmin, "", RoleAdmin, false},
+		{"admin cannot demote admin", RoleAdmin, RoleAdmin, RoleMember, false},
+		{"admin cannot touch owner", RoleAdmin, RoleOwner, RoleViewer, false},
+		{"member cannot assign", RoleMember, "", RoleViewer, false},
+		{"invalid target", RoleOwner, RoleMember, "root", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanAssignRole(tt.actor, tt.from, tt.to); got != tt.want {
+				t.Errorf("CanAssignRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanAssignBoardRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		actor, from, to Role
+		want            bool
+	}{
+		{"owner adds editor", RoleOwner, "", RoleEditor, true},
+		{"admin adds commenter", RoleAdmin, "", RoleCommenter, true},
+		{"admin demotes editor to viewer", RoleAdmin, RoleEditor, RoleViewer, true},
+		{"admin cannot create admin", RoleAdmin, "", RoleAdmin, false},
+		{"editor cannot assign", RoleEditor, "", RoleViewer, false},
+		{"workspace roles are not board roles", RoleOwner, "", RoleMember, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanAssignBoardRole(tt.actor, tt.from, tt.to); got != tt.want {
+				t.Errorf("CanAssignBoardRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// And board roles are not workspace roles
+	if CanAssignRole(RoleOwner, "", RoleEditor) {
+		t.Error("CanAssignRole() allowed a board role on a workspace")
+	}
+}
+
+func TestAuthorizer_BoardRoleInheritsWorkspaceAdmin(t *testing.T) {
+	repo := &mockRepository{
+		workspaceRoles: map[uint]Role{1: RoleAdmin, 2: RoleMember},
+		boardRoles:     map[uint]Role{2: RoleViewer, 3: RoleMember},
+		workspaceID:    7,
+	}
+	authz := New(repo)
+
+	t.Run("workspace admin without board membership", func(t *testing.T) {
+		role, _ := authz.BoardRole(1, 1)
+		if role != RoleAdmin {
+			t.Errorf("expected %v, got %v", RoleAdmin, role)
+		}
+	})
+
+	t.Run("workspace member keeps board role", func(t *testing.T) {
+		if err := authz.AuthorizeBoard(1, 2, ActionEdit); !IsForbidden(err) {
+			t.Errorf("expected forbidden error, got %v", err)
+		}
+	})
+
+	t.Run("non member", func(t *testing.T) {
+		if err := authz.AuthorizeBoard(1, 4, ActionView); err != ErrNotMember {
+			t.Errorf("expected ErrNotMember, got %v", err)
+		}
+	})
+
+	t.Run("board member edits", func(t *testing.T) {
+		if err := authz.AuthorizeBoard(1, 3, ActionEdit); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+func TestAuthorizer_ReadOnlyWorkspace(t *testing.T) {
+	repo := &mockRepository{
+		workspaceRoles: map[uint]Role{1: RoleOwner},
+		boardRoles:     map[uint]Role{2: RoleMember},
+		workspaceID:    7,
+		readOnly:       true,
+	}
+	authz := New(repo)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "owner views the workspace", err: authz.AuthorizeWorkspace(7, 1, ActionView)},
+		{name: "owner cannot update the workspace", err: authz.AuthorizeWorkspace(7, 1, ActionUpdate), want: ErrReadOnly},
+		{name: "member views a board", err: authz.AuthorizeBoard(3, 2, ActionView)},
+		{name: "member cannot edit a board", err: authz.AuthorizeBoard(3, 2, ActionEdit), want: ErrReadOnly},
+		{name: "role is checked first", err: authz.AuthorizeWorkspace(7, 9, ActionEdit), want: ErrNotMember},
+		{name: "membership changes are refused", err: authz.CheckWritable(7), want: ErrReadOnly},
+		{name: "no workspace is always writable", err: authz.CheckWritable(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("got %v, want %v", tt.err, tt.want)
+			}
+		})
+	}
+	if !IsForbidden(ErrReadOnly) {
+		t.Error("ErrReadOnly must count as forbidden")
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	granted := []Scope{ScopeBoardsRead, ScopeCardsWrite}
+
+	tests := []struct {
+		resource string
+		write    bool
+		want     bool
+	}{
+		{ResourceBoards, false, true},
+		{ResourceBoards, true, false},
+		{ResourceCards, false, true},
+		{ResourceCards, true, true},
+		{ResourceChat, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := HasScope(granted, tt.resource, tt.write); got != tt.want {
+			t.Errorf("HasScope(%s, write=%v) = %v, want %v", tt.resource, tt.write, got, tt.want)
+		}
+	}
+}
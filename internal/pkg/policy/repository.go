@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"hrm-app/internal/pkg/database"
+)
+
+// Repository reads membership roles. Missing memberships resolve to an empty role.
+type Repository interface {
+	FindWorkspaceRole(workspaceID, userID uint) (Role, error)
+	FindBoardRole(boardID, userID uint) (Role, error)
+	FindBoardWorkspaceID(boardID uint) (uint, error)
+	IsWorkspaceReadOnly(workspaceID uint) (bool, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+func (r *repository) FindWorkspaceRole(workspaceID, userID uint) (Role, error) {
+	var roles []Role
+	err := database.DB.Table("workspaces_users").
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Limit(1).
+		Pluck("role", &roles).Error
+	if err != nil || len(roles) == 0 {
+		return "", err
+	}
+	return roles[0], nil
+}
+
+func (r *repository) FindBoardRole(boardID, userID uint) (Role, error) {
+	var roles []Role
+	err := database.DB.Table("boards_users").
+		Where("board_id = ? AND user_id = ?", boardID, userID).
+		Limit(1).
+		Pluck("role", &roles).Error
+	if err != nil || len(roles) == 0 {
+		return "", err
+	}
+	return roles[0], nil
+}
+
+func (r *repository) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	var ids []uint
+	err := database.DB.Table("boards").
+		Where("id = ?", boardID).
+		Limit(1).
+		Pluck("workspace_id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// IsWorkspaceReadOnly reports whether the workspace is archived or in the trash
+func (r *repository) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	var count int64
+	err := database.DB.Table("workspaces").
+		Where("id = ? AND (archived_at IS NOT NULL OR trashed_at IS NOT NULL)", workspaceID).
+		Count(&count).Error
+	return count > 0, err
+}
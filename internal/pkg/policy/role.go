@@ -0,0 +1,122 @@
+package policy
+
+// Role is the access level a user holds on a workspace or board
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+	RoleGuest  Role = "guest"
+	RoleViewer Role = "viewer"
+
+	// Board-only roles, ranked like member and guest
+	RoleEditor    Role = "editor"
+	RoleCommenter Role = "commenter"
+)
+
+// workspaceRoles and boardRoles are the roles each kind of membership can
+// hold. Owner is never assigned, it marks the creator.
+var (
+	workspaceRoles = map[Role]bool{RoleOwner: true, RoleAdmin: true, RoleMember: true, RoleGuest: true, RoleViewer: true}
+	boardRoles     = map[Role]bool{RoleOwner: true, RoleAdmin: true, RoleEditor: true, RoleCommenter: true, RoleViewer: true}
+)
+
+// Action is an operation that is checked against a role
+type Action string
+
+const (
+	ActionView          Action = "view"
+	ActionComment       Action = "comment"
+	ActionEdit          Action = "edit"
+	ActionInvite        Action = "invite"
+	ActionManageMembers Action = "manage_members"
+	ActionUpdate        Action = "update"
+	ActionDelete        Action = "delete"
+)
+
+// rank orders roles from least to most privileged. Unknown roles rank 0.
+var rank = map[Role]int{
+	RoleViewer:    1,
+	RoleGuest:     2,
+	RoleCommenter: 2,
+	RoleMember:    3,
+	RoleEditor:    3,
+	RoleAdmin:     4,
+	RoleOwner:     5,
+}
+
+// minRole is the lowest role allowed to perform each action
+var minRole = map[Action]Role{
+	ActionView:          RoleViewer,
+	ActionComment:       RoleGuest,
+	ActionEdit:          RoleMember,
+	ActionInvite:        RoleAdmin,
+	ActionManageMembers: RoleAdmin,
+	ActionUpdate:        RoleAdmin,
+	ActionDelete:        RoleOwner,
+}
+
+// Valid reports whether r is one of the known roles
+func (r Role) Valid() bool {
+	_, ok := rank[r]
+	return ok
+}
+
+// ValidForWorkspace reports whether r can be held on a workspace
+func (r Role) ValidForWorkspace() bool {
+	return workspaceRoles[r]
+}
+
+// ValidForBoard reports whether r can be held on a board
+func (r Role) ValidForBoard() bool {
+	return boardRoles[r]
+}
+
+// AtLeast reports whether r is as privileged as other
+func (r Role) AtLeast(other Role) bool {
+	return rank[r] >= rank[other] && rank[r] > 0
+}
+
+// Can reports whether a user holding role may perform action
+func Can(role Role, action Action) bool {
+	required, ok := minRole[action]
+	if !ok {
+		return false
+	}
+	return role.AtLeast(required)
+}
+
+// CanAssignRole reports whether actor may move a member from role `from` to role `to`.
+// Ownership is never granted or taken away through role assignment, and admins may
+// only manage roles strictly below their own.
+func CanAssignRole(actor, from, to Role) bool {
+	return to.ValidForWorkspace() && canAssign(actor, from, to)
+}
+
+// CanAssignBoardRole is CanAssignRole for board roles. actor is the effective
+// board role, which may be inherited from the workspace.
+func CanAssignBoardRole(actor, from, to Role) bool {
+	return to.ValidForBoard() && canAssign(actor, from, to)
+}
+
+func canAssign(actor, from, to Role) bool {
+	if !Can(actor, ActionManageMembers) {
+		return false
+	}
+	if from == RoleOwner || to == RoleOwner {
+		return false
+	}
+	if actor == RoleOwner {
+		return true
+	}
+	return rank[actor] > rank[to] && rank[actor] > rank[from]
+}
+
+// CanRemoveMember reports whether actor may remove a member holding role target
+func CanRemoveMember(actor, target Role) bool {
+	if !Can(actor, ActionManageMembers) || target == RoleOwner {
+		return false
+	}
+	return actor == RoleOwner || rank[actor] > rank[target]
+}
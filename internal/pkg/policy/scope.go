@@ -0,0 +1,53 @@
+package policy
+
+// Scope limits what a personal access token may do, as "<resource>:<read|write>"
+type Scope string
+
+const (
+	ScopeWorkspacesRead  Scope = "workspaces:read"
+	ScopeWorkspacesWrite Scope = "workspaces:write"
+	ScopeBoardsRead      Scope = "boards:read"
+	ScopeBoardsWrite     Scope = "boards:write"
+	ScopeCardsRead       Scope = "cards:read"
+	ScopeCardsWrite      Scope = "cards:write"
+	ScopeChatRead        Scope = "chat:read"
+	ScopeChatWrite       Scope = "chat:write"
+)
+
+// Scope resources, one per protected route group
+const (
+	ResourceWorkspaces = "workspaces"
+	ResourceBoards     = "boards"
+	ResourceCards      = "cards"
+	ResourceChat       = "chat"
+)
+
+var knownScopes = map[Scope]bool{
+	ScopeWorkspacesRead:  true,
+	ScopeWorkspacesWrite: true,
+	ScopeBoardsRead:      true,
+	ScopeBoardsWrite:     true,
+	ScopeCardsRead:       true,
+	ScopeCardsWrite:      true,
+	ScopeChatRead:        true,
+	ScopeChatWrite:       true,
+}
+
+// Valid reports whether s is one of the known scopes
+func (s Scope) Valid() bool {
+	return knownScopes[s]
+}
+
+// HasScope reports whether granted allows reading (or writing) resource.
+// A write scope implies the read scope of the same resource.
+func HasScope(granted []Scope, resource string, write bool) bool {
+	for _, s := range granted {
+		if s == Scope(resource+":write") {
+			return true
+		}
+		if !write && s == Scope(resource+":read") {
+			return true
+		}
+	}
+	return false
+}
@@ -24,6 +24,14 @@ const (
 	Prefetch     = 10
 )
 
+// Board imports too large to run in the request are queued here for
+// cmd/rabbitmq_worker. The queue is durable so pending imports survive a
+// broker restart.
+const (
+	ImportQueueName  = "board.import.queue"
+	ImportRoutingKey = "board.import"
+)
+
 // GetUserQueueName returns queue name for specific user
 func GetUserQueueName(userID string) string {
 	return "user." + userID + ".messages"
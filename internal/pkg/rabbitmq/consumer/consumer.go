@@ -101,3 +101,55 @@ func StartForUser(ctx context.Context, ch *amqp.Channel, userID string, handler
 	log.Printf("✅ Consumer started for user %s", userID)
 	return nil
 }
+
+// StartJobs consumes a shared job queue without blocking. A failed job is
+// dropped to the dead letter exchange rather than requeued, since running it
+// again would fail the same way.
+func StartJobs(ctx context.Context, ch *amqp.Channel, queue string, handler MessageHandler) error {
+	if err := ch.Qos(config.Prefetch, 0, false); err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(
+		queue,
+		"",    // consumer tag
+		false, // auto-ack
+		false, // exclusive
+		false, // no-local
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg, ok := <-msgs:
+				if !ok {
+					log.Printf("Consumer channel closed for queue %s", queue)
+					return
+				}
+
+				if err := handler(ctx, msg); err != nil {
+					log.Printf("Error handling job from %s: %v", queue, err)
+					if nackErr := msg.Nack(false, false); nackErr != nil {
+						log.Printf("Failed to Nack job from %s: %v", queue, nackErr)
+					}
+				} else {
+					if ackErr := msg.Ack(false); ackErr != nil {
+						log.Printf("Failed to Ack job from %s: %v", queue, ackErr)
+					}
+				}
+
+			case <-ctx.Done():
+				log.Printf("Consumer stopped for queue %s", queue)
+				return
+			}
+		}
+	}()
+
+	log.Printf("✅ Consumer started for queue %s", queue)
+	return nil
+}
@@ -50,6 +50,11 @@ func (m *ChannelManager) SetPool(pool *pool.ChannelPool) {
 	m.pool = pool
 }
 
+// Channel opens a new channel on the shared connection; the caller closes it
+func (m *ChannelManager) Channel() (*amqp.Channel, error) {
+	return m.conn.Channel()
+}
+
 func (m *ChannelManager) Start() {
 	// Start idle cleanup
 	go m.startIdleCleanup()
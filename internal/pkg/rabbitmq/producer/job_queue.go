@@ -0,0 +1,39 @@
+package producer
+
+import (
+	"hrm-app/internal/pkg/rabbitmq/config"
+	"hrm-app/internal/pkg/rabbitmq/setup"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Channels opens channels on a shared connection (implemented by manager.ChannelManager)
+type Channels interface {
+	Channel() (*amqp.Channel, error)
+}
+
+// JobQueue publishes jobs to a durable queue for cmd/rabbitmq_worker
+type JobQueue struct {
+	channels   Channels
+	queue      string
+	routingKey string
+}
+
+func NewJobQueue(channels Channels, queue, routingKey string) *JobQueue {
+	return &JobQueue{channels: channels, queue: queue, routingKey: routingKey}
+}
+
+// Enqueue declares the queue, so jobs are kept even before a worker has
+// started, and publishes body to it
+func (q *JobQueue) Enqueue(body []byte) error {
+	ch, err := q.channels.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := setup.DeclareJobQueue(ch, config.ExchangeName, config.ExchangeType, q.queue, q.routingKey); err != nil {
+		return err
+	}
+	return Publish(ch, config.ExchangeName, q.routingKey, body)
+}
@@ -86,3 +86,42 @@ func DeclareUserQueue(ch *amqp.Channel, exchange, exchangeType, userID string) e
 		nil,
 	)
 }
+
+// DeclareJobQueue declares a durable queue shared by all workers, for jobs that
+// must not be lost when nobody is consuming
+func DeclareJobQueue(ch *amqp.Channel, exchange, exchangeType, queue, routingKey string) error {
+	if err := ch.ExchangeDeclare(
+		exchange,
+		exchangeType,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	args := amqp.Table{
+		"x-dead-letter-exchange": exchange + ".dlx",
+	}
+
+	if _, err := ch.QueueDeclare(
+		queue,
+		true,  // durable
+		false, // auto-delete
+		false, // exclusive
+		false,
+		args,
+	); err != nil {
+		return err
+	}
+
+	return ch.QueueBind(
+		queue,
+		routingKey,
+		exchange,
+		false,
+		nil,
+	)
+}
@@ -0,0 +1,183 @@
+// Package rank generates lexicographic order keys for drag-and-drop lists.
+// A key sorts between any two others without renumbering its neighbours, so
+// moving an item writes one row. Keys use the digits 0-9A-Za-z and must be
+// compared byte-wise (COLLATE "C" in Postgres). Keys have no length limit:
+// inserting into the same gap over and over adds a digit every few moves.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var (
+	ErrInvalidOrder     = errors.New("rank: before must sort below after")
+	ErrNotFound         = errors.New("neighbour not found in this list")
+	ErrNotAdjacent      = errors.New("neighbours are not next to each other, reload the list")
+	ErrInvalidCharacter = errors.New("rank: invalid key")
+)
+
+// Between returns a key that sorts after before and ahead of after. An empty
+// before means the start of the list, an empty after its end.
+func Between(before, after string) (string, error) {
+	if !valid(before) || !valid(after) {
+		return "", ErrInvalidCharacter
+	}
+	if after != "" && before >= after {
+		return "", ErrInvalidOrder
+	}
+	return midpoint(before, after), nil
+}
+
+// Sequence returns n increasing keys, for seeding a new list
+func Sequence(n int) []string {
+	keys := make([]string, 0, n)
+	last := ""
+	for i := 0; i < n; i++ {
+		last = midpoint(last, "")
+		keys = append(keys, last)
+	}
+	return keys
+}
+
+// Item is an entry of an ordered list
+type Item struct {
+	ID  uint
+	Key string
+}
+
+// Place returns the key for an item dropped between the neighbours beforeID
+// and afterID of items, which is sorted by key and does not hold the moved
+// item. A zero id means no neighbour on that side; with neither the item goes
+// to the end. When both are given they must be adjacent, which catches a
+// client working from a stale list.
+func Place(items []Item, beforeID, afterID uint) (string, error) {
+	lower, upper := "", ""
+	switch {
+	case beforeID != 0 && afterID != 0:
+		i, j := indexOf(items, beforeID), indexOf(items, afterID)
+		if i < 0 || j < 0 {
+			return "", ErrNotFound
+		}
+		if j != i+1 {
+			return "", ErrNotAdjacent
+		}
+		lower, upper = items[i].Key, items[j].Key
+	case beforeID != 0:
+		i := indexOf(items, beforeID)
+		if i < 0 {
+			return "", ErrNotFound
+		}
+		lower = items[i].Key
+		if i+1 < len(items) {
+			upper = items[i+1].Key
+		}
+	case afterID != 0:
+		j := indexOf(items, afterID)
+		if j < 0 {
+			return "", ErrNotFound
+		}
+		upper = items[j].Key
+		if j > 0 {
+			lower = items[j-1].Key
+		}
+	default:
+		if len(items) > 0 {
+			lower = items[len(items)-1].Key
+		}
+	}
+
+	// Equal keys can appear when two moves land in the same gap at once;
+	// the item then goes right after the lower one
+	if upper != "" && lower >= upper {
+		upper = ""
+		for _, item := range items {
+			if item.Key > lower {
+				upper = item.Key
+				break
+			}
+		}
+	}
+	return Between(lower, upper)
+}
+
+// PlaceAt returns the key that puts an item at the 1-based position of items,
+// which does not hold the item. Positions past the end mean the end.
+func PlaceAt(items []Item, position int) (string, error) {
+	switch {
+	case position <= 1:
+		if len(items) == 0 {
+			return Place(items, 0, 0)
+		}
+		return Place(items, 0, items[0].ID)
+	case position > len(items):
+		return Place(items, 0, 0)
+	default:
+		return Place(items, items[position-2].ID, items[position-1].ID)
+	}
+}
+
+func indexOf(items []Item, id uint) int {
+	for i, item := range items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// midpoint finds a key strictly between a and b, with b == "" meaning no upper
+// bound. Keys never end in the lowest digit, so there is always room below.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix, treating a as padded with the lowest digit
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	low := strings.IndexByte(digits, digitAt(a, 0))
+	high := len(digits)
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+
+	// The first digits are consecutive
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[low]) + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return digits[0]
+}
+
+func valid(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package rank
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		before, after string
+	}{
+		{"", ""},
+		{"", "V"},
+		{"V", ""},
+		{"V", "W"},
+		{"V", "V1"},
+		{"0001", "0002"},
+		{"000001V", "V"},
+		{"z", ""},
+		{"zzz", ""},
+		{"", "01"},
+		{"Vz", "W"},
+	}
+
+	for _, tt := range tests {
+		got, err := Between(tt.before, tt.after)
+		if err != nil {
+			t.Fatalf("Between(%q, %q) error = %v", tt.before, tt.after, err)
+		}
+		if got <= tt.before || (tt.after != "" && got >= tt.after) {
+			t.Errorf("Between(%q, %q) = %q, not in between", tt.before, tt.after, got)
+		}
+		if got[len(got)-1] == digits[0] {
+			t.Errorf("Between(%q, %q) = %q ends in the lowest digit", tt.before, tt.after, got)
+		}
+	}
+
+	if _, err := Between("W", "V"); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Between() out of order error = %v, want %v", err, ErrInvalidOrder)
+	}
+	if _, err := Between("V-", ""); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Between() with an invalid key error = %v, want %v", err, ErrInvalidCharacter)
+	}
+}
+
+// TestBetween_RepeatedInserts keeps inserting at random gaps and checks the
+// keys stay strictly ordered
+func TestBetween_RepeatedInserts(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	keys := Sequence(3)
+
+	for i := 0; i < 2000; i++ {
+		gap := random.Intn(len(keys) + 1)
+		before, after := "", ""
+		if gap > 0 {
+			before = keys[gap-1]
+		}
+		if gap < len(keys) {
+			after = keys[gap]
+		}
+		key, err := Between(before, after)
+		if err != nil {
+			t.Fatalf("Between(%q, %q) error = %v", before, after, err)
+		}
+		keys = append(keys[:gap], append([]string{key}, keys[gap:]...)...)
+	}
+
+	if !sort.StringsAreSorted(keys) {
+		t.Fatal("keys are no longer sorted")
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i] == keys[i-1] {
+			t.Fatalf("duplicate key %q", keys[i])
+		}
+	}
+}
+
+// TestBetween_SamePosition keeps dropping items right after the first one,
+// the worst case for key length. The keys must stay ordered well past 255
+// characters, which is why the rank columns are TEXT.
+func TestBetween_SamePosition(t *testing.T) {
+	first, last := Sequence(2)[0], Sequence(2)[1]
+	upper := last
+
+	for i := 0; i < 2000; i++ {
+		key, err := Between(first, upper)
+		if err != nil {
+			t.Fatalf("insert %d: Between(%q, %q) error = %v", i, first, upper, err)
+		}
+		if key <= first || key >= upper {
+			t.Fatalf("insert %d: Between(%q, %q) = %q, not in between", i, first, upper, key)
+		}
+		upper = key
+	}
+
+	if len(upper) <= 255 {
+		t.Errorf("key length after 2000 inserts = %d, expected it to pass 255", len(upper))
+	}
+}
+
+func TestPlace(t *testing.T) {
+	items := []Item{{1, "V"}, {2, "k"}, {3, "s"}}
+
+	tests := []struct {
+		name              string
+		beforeID, afterID uint
+		wantAfter         string // the key that must sort below the result, "" for the start
+		wantBelow         string // the key the result must sort below, "" for the end
+		wantErr           error
+	}{
+		{name: "to the end", wantAfter: "s"},
+		{name: "to the start", afterID: 1, wantBelow: "V"},
+		{name: "after the first", beforeID: 1, wantAfter: "V", wantBelow: "k"},
+		{name: "before the last", afterID: 3, wantAfter: "k", wantBelow: "s"},
+		{name: "between adjacent", beforeID: 2, afterID: 3, wantAfter: "k", wantBelow: "s"},
+		{name: "after the last", beforeID: 3, wantAfter: "s"},
+		{name: "not adjacent", beforeID: 1, afterID: 3, wantErr: ErrNotAdjacent},
+		{name: "reversed", beforeID: 2, afterID: 1, wantErr: ErrNotAdjacent},
+		{name: "unknown neighbour", beforeID: 9, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := Place(items, tt.beforeID, tt.afterID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Place() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if key <= tt.wantAfter || (tt.wantBelow != "" && key >= tt.wantBelow) {
+				t.Errorf("Place() = %q, want between %q and %q", key, tt.wantAfter, tt.wantBelow)
+			}
+		})
+	}
+
+	if key, err := Place(nil, 0, 0); err != nil || key == "" {
+		t.Errorf("Place() in an empty list = %q, %v", key, err)
+	}
+	// Two items that raced into the same gap share a key; dropping after the
+	// first still lands between them and the next one
+	tied := []Item{{1, "V"}, {2, "k"}, {3, "k"}, {4, "s"}}
+	if key, err := Place(tied, 2, 3); err != nil || key <= "k" || key >= "s" {
+		t.Errorf("Place() between tied keys = %q, %v, want between %q and %q", key, err, "k", "s")
+	}
+}
+
+func TestPlaceAt(t *testing.T) {
+	items := []Item{{1, "V"}, {2, "k"}, {3, "s"}}
+
+	for position, want := range map[int][2]string{
+		0:  {"", "V"},
+		1:  {"", "V"},
+		2:  {"V", "k"},
+		3:  {"k", "s"},
+		4:  {"s", ""},
+		10: {"s", ""},
+	} {
+		key, err := PlaceAt(items, position)
+		if err != nil {
+			t.Fatalf("PlaceAt(%d) error = %v", position, err)
+		}
+		if key <= want[0] || (want[1] != "" && key >= want[1]) {
+			t.Errorf("PlaceAt(%d) = %q, want between %q and %q", position, key, want[0], want[1])
+		}
+	}
+}
@@ -2,20 +2,97 @@ package utils
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"hrm-app/config"
+	"hrm-app/internal/pkg/jwtkeys"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// keySets caches the loaded keys per config, so key files are read once
+var keySets sync.Map
+
+// SigningKeys returns the JWT key set configured in cfg
+func SigningKeys(cfg *config.Config) (*jwtkeys.KeySet, error) {
+	if ks, ok := keySets.Load(cfg); ok {
+		return ks.(*jwtkeys.KeySet), nil
+	}
+
+	ks, err := jwtkeys.Load(cfg)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := keySets.LoadOrStore(cfg, ks)
+	return actual.(*jwtkeys.KeySet), nil
+}
+
+func signToken(cfg *config.Config, claims jwt.Claims) (string, error) {
+	ks, err := SigningKeys(cfg)
+	if err != nil {
+		return "", err
+	}
+	return ks.Sign(claims)
+}
+
+func parseToken(cfg *config.Config, tokenStr string, claims jwt.Claims) (*jwt.Token, error) {
+	ks, err := SigningKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.ParseWithClaims(tokenStr, claims, ks.Keyfunc)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.New("token expired")
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
 type Claims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
+	Family string `json:"family,omitempty"` // refresh token family, only set on refresh tokens
 	jwt.RegisteredClaims
 }
 
-func GenerateTokens(cfg *config.Config, userID uint, email string) (string, string, error) {
+// RefreshFamily identifies a refresh token (TokenID) within its chain of rotations (ID)
+type RefreshFamily struct {
+	ID      string
+	TokenID string
+}
+
+// NewRefreshFamily starts a new family, used on login
+func NewRefreshFamily() (RefreshFamily, error) {
+	id, err := GenerateSecureToken(16)
+	if err != nil {
+		return RefreshFamily{}, err
+	}
+	tokenID, err := GenerateSecureToken(16)
+	if err != nil {
+		return RefreshFamily{}, err
+	}
+	return RefreshFamily{ID: id, TokenID: tokenID}, nil
+}
+
+// Next returns the successor of f in the same family, used on rotation
+func (f RefreshFamily) Next() (RefreshFamily, error) {
+	tokenID, err := GenerateSecureToken(16)
+	if err != nil {
+		return RefreshFamily{}, err
+	}
+	return RefreshFamily{ID: f.ID, TokenID: tokenID}, nil
+}
+
+// FamilyOf returns the refresh family of validated refresh token claims
+func FamilyOf(claims *Claims) RefreshFamily {
+	return RefreshFamily{ID: claims.Family, TokenID: claims.ID}
+}
+
+func GenerateTokens(cfg *config.Config, userID uint, email string, family RefreshFamily) (string, string, error) {
 	tokenTTL := cfg.JWT.TokenTTLMinutes
 	if tokenTTL == 0 {
 		tokenTTL = 1440 // default 24 jam
@@ -37,7 +114,7 @@ func GenerateTokens(cfg *config.Config, userID uint, email string) (string, stri
 			Subject:   "access_token",
 		},
 	}
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWT.Secret))
+	accessToken, err := signToken(cfg, claims)
 	if err != nil {
 		return "", "", err
 	}
@@ -46,14 +123,16 @@ func GenerateTokens(cfg *config.Config, userID uint, email string) (string, stri
 	refreshClaims := &Claims{
 		UserID: userID,
 		Email:  email,
+		Family: family.ID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        family.TokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().AddDate(0, 0, refreshExpDays)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "hrm-app",
 			Subject:   "refresh_token",
 		},
 	}
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(cfg.JWT.Secret))
+	refreshToken, err := signToken(cfg, refreshClaims)
 	if err != nil {
 		return "", "", err
 	}
@@ -70,17 +149,8 @@ func ValidateRefreshToken(cfg *config.Config, tokenStr string) (*Claims, error)
 }
 
 func validateToken(cfg *config.Config, tokenStr string, expectedSubject string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(cfg.JWT.Secret), nil
-	})
-
+	token, err := parseToken(cfg, tokenStr, &Claims{})
 	if err != nil {
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, errors.New("token expired")
-		}
 		return nil, err
 	}
 
@@ -94,58 +164,25 @@ func validateToken(cfg *config.Config, tokenStr string, expectedSubject string)
 	return nil, errors.New("invalid token")
 }
 
-type JoinClaims struct {
-	EntityID   uint   `json:"entity_id"`
-	EntityType string `json:"entity_type"` // "board" or "workspace"
-	PassCode   string `json:"pass_code"`
-	jwt.RegisteredClaims
-}
-
-func GenerateJoinToken(cfg *config.Config, entityID uint, entityType string, passCode string) (string, error) {
-	// Join Token valid for 7 days (adjustable)
-	expirationTime := time.Now().Add(7 * 24 * time.Hour)
+// MFAPendingTTL is how long a user has to enter a second factor after the password
+const MFAPendingTTL = 5 * time.Minute
 
-	claims := &JoinClaims{
-		EntityID:   entityID,
-		EntityType: entityType,
-		PassCode:   passCode,
+// GenerateMFAPendingToken issues a short-lived token proving the password step passed.
+// It cannot be used as an access token because of its subject.
+func GenerateMFAPendingToken(cfg *config.Config, userID uint, email string) (string, error) {
+	claims := &Claims{
+		UserID: userID,
+		Email:  email,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(MFAPendingTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "hrm-app",
-			Subject:   "join_token",
+			Subject:   "mfa_pending",
 		},
 	}
-
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWT.Secret))
-	if err != nil {
-		return "", err
-	}
-
-	return token, nil
+	return signToken(cfg, claims)
 }
 
-func ValidateJoinToken(cfg *config.Config, tokenStr string) (*JoinClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &JoinClaims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(cfg.JWT.Secret), nil
-	})
-
-	if err != nil {
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, errors.New("token expired")
-		}
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*JoinClaims); ok && token.Valid {
-		if claims.Subject != "join_token" {
-			return nil, errors.New("invalid token subject")
-		}
-		return claims, nil
-	}
-
-	return nil, errors.New("invalid token")
+func ValidateMFAPendingToken(cfg *config.Config, tokenStr string) (*Claims, error) {
+	return validateToken(cfg, tokenStr, "mfa_pending")
 }
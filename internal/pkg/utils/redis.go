@@ -2,17 +2,45 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"hrm-app/internal/pkg/database"
+
+	"github.com/redis/go-redis/v9"
 )
 
 var ctx = context.Background()
 
-func SetSession(userID uint, token string, exp time.Duration) error {
+// SetSession stores an access token session together with its metadata
+func SetSession(userID uint, token string, meta SessionMeta, exp time.Duration) error {
+	key := fmt.Sprintf("session:%d:%s", userID, token)
+	meta.ID = SessionID(token)
+	value, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return database.RDB.Set(ctx, key, value, exp).Err()
+}
+
+// UpdateSession rewrites the metadata of a session only if it still exists, so
+// a session revoked meanwhile is not brought back. It returns redis.Nil otherwise.
+func UpdateSession(userID uint, token string, meta SessionMeta, exp time.Duration) error {
 	key := fmt.Sprintf("session:%d:%s", userID, token)
-	return database.RDB.Set(ctx, key, "active", exp).Err()
+	meta.ID = SessionID(token)
+	value, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	updated, err := database.RDB.SetXX(ctx, key, value, exp).Result()
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return redis.Nil
+	}
+	return nil
 }
 
 func GetSession(userID uint, token string) (string, error) {
@@ -29,3 +57,103 @@ func ExtendSession(userID uint, token string, exp time.Duration) error {
 	key := fmt.Sprintf("session:%d:%s", userID, token)
 	return database.RDB.Expire(ctx, key, exp).Err()
 }
+
+// DeleteAllSessions removes every active session of a user and disconnects their WebSocket clients
+func DeleteAllSessions(userID uint) error {
+	if err := deleteByPattern(fmt.Sprintf("session:%d:*", userID)); err != nil {
+		return err
+	}
+	return PublishSessionRevoked(userID, "")
+}
+
+func deleteByPattern(pattern string) error {
+	iter := database.RDB.Scan(ctx, 0, pattern, 100).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return database.RDB.Del(ctx, keys...).Err()
+}
+
+// RevokeAllSessions removes every access session and refresh token family of a user
+func RevokeAllSessions(userID uint) error {
+	if err := DeleteAllSessions(userID); err != nil {
+		return err
+	}
+	return DeleteAllRefreshFamilies(userID)
+}
+
+// RotateResult is the outcome of presenting a refresh token for rotation
+type RotateResult int
+
+const (
+	RotateOK      RotateResult = iota
+	RotateUnknown              // family revoked or expired
+	RotateReused               // token was already rotated away: likely stolen
+)
+
+// rotateScript swaps the family's current token id only if the presented one is current
+var rotateScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	return -1
+end
+redis.call('SET', KEYS[1], ARGV[2], 'EX', ARGV[3])
+return 1
+`)
+
+func refreshFamilyKey(userID uint, familyID string) string {
+	return fmt.Sprintf("refresh:%d:%s", userID, familyID)
+}
+
+// SetRefreshFamily stores family.TokenID as the only valid refresh token of the family
+func SetRefreshFamily(userID uint, family RefreshFamily, exp time.Duration) error {
+	return database.RDB.Set(ctx, refreshFamilyKey(userID, family.ID), family.TokenID, exp).Err()
+}
+
+// RotateRefreshFamily atomically replaces current with next
+func RotateRefreshFamily(userID uint, current, next RefreshFamily, exp time.Duration) (RotateResult, error) {
+	res, err := rotateScript.Run(ctx, database.RDB,
+		[]string{refreshFamilyKey(userID, current.ID)},
+		current.TokenID, next.TokenID, int64(exp/time.Second),
+	).Int()
+	if err != nil {
+		return RotateUnknown, err
+	}
+
+	switch res {
+	case 1:
+		return RotateOK, nil
+	case -1:
+		return RotateReused, nil
+	default:
+		return RotateUnknown, nil
+	}
+}
+
+// RevokeReusedFamily handles a refresh token that was already rotated away:
+// it assumes the token was stolen and logs the user out everywhere
+func RevokeReusedFamily(userID uint, familyID string) error {
+	if err := DeleteRefreshFamily(userID, familyID); err != nil {
+		return err
+	}
+	return DeleteAllSessions(userID)
+}
+
+func DeleteRefreshFamily(userID uint, familyID string) error {
+	return database.RDB.Del(ctx, refreshFamilyKey(userID, familyID)).Err()
+}
+
+func DeleteAllRefreshFamilies(userID uint) error {
+	return deleteByPattern(fmt.Sprintf("refresh:%d:*", userID))
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"hrm-app/internal/pkg/database"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedis points database.RDB at an in-memory Redis for the test
+func newTestRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	previous := database.RDB
+	database.RDB = redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		database.RDB.Close()
+		database.RDB = previous
+	})
+	return server
+}
+
+func TestRotateRefreshFamily(t *testing.T) {
+	server := newTestRedis(t)
+
+	first := RefreshFamily{ID: "fam", TokenID: "t1"}
+	second := RefreshFamily{ID: "fam", TokenID: "t2"}
+	third := RefreshFamily{ID: "fam", TokenID: "t3"}
+	if err := SetRefreshFamily(7, first, time.Hour); err != nil {
+		t.Fatalf("SetRefreshFamily() error = %v", err)
+	}
+
+	if got, err := RotateRefreshFamily(7, first, second, 2*time.Hour); err != nil || got != RotateOK {
+		t.Fatalf("RotateRefreshFamily(current) = %v, %v, want %v", got, err, RotateOK)
+	}
+	if got, _ := server.Get(refreshFamilyKey(7, "fam")); got != "t2" {
+		t.Errorf("family token = %q, want %q", got, "t2")
+	}
+	if ttl := server.TTL(refreshFamilyKey(7, "fam")); ttl != 2*time.Hour {
+		t.Errorf("family TTL = %v, want %v", ttl, 2*time.Hour)
+	}
+
+	// The rotated-away token comes back: reuse, and the family is left alone
+	if got, err := RotateRefreshFamily(7, first, third, time.Hour); err != nil || got != RotateReused {
+		t.Fatalf("RotateRefreshFamily(old) = %v, %v, want %v", got, err, RotateReused)
+	}
+	if got, _ := server.Get(refreshFamilyKey(7, "fam")); got != "t2" {
+		t.Errorf("family token after reuse = %q, want %q", got, "t2")
+	}
+
+	unknown := RefreshFamily{ID: "other", TokenID: "t1"}
+	if got, err := RotateRefreshFamily(7, unknown, third, time.Hour); err != nil || got != RotateUnknown {
+		t.Errorf("RotateRefreshFamily(unknown family) = %v, %v, want %v", got, err, RotateUnknown)
+	}
+}
+
+func TestRevokeReusedFamily(t *testing.T) {
+	newTestRedis(t)
+
+	current := RefreshFamily{ID: "fam", TokenID: "t2"}
+	if err := SetRefreshFamily(7, current, time.Hour); err != nil {
+		t.Fatalf("SetRefreshFamily() error = %v", err)
+	}
+	for _, token := range []string{"access-a", "access-b"} {
+		if err := SetSession(7, token, SessionMeta{}, time.Hour); err != nil {
+			t.Fatalf("SetSession() error = %v", err)
+		}
+	}
+	if err := SetSession(8, "someone-else", SessionMeta{}, time.Hour); err != nil {
+		t.Fatalf("SetSession() error = %v", err)
+	}
+
+	if err := RevokeReusedFamily(7, current.ID); err != nil {
+		t.Fatalf("RevokeReusedFamily() error = %v", err)
+	}
+
+	// Even the token that was current can no longer be rotated
+	next := RefreshFamily{ID: "fam", TokenID: "t3"}
+	if got, err := RotateRefreshFamily(7, current, next, time.Hour); err != nil || got != RotateUnknown {
+		t.Errorf("RotateRefreshFamily() after revoke = %v, %v, want %v", got, err, RotateUnknown)
+	}
+	if sessions, err := ListSessions(7); err != nil || len(sessions) != 0 {
+		t.Errorf("ListSessions() after revoke = %d sessions, %v, want none", len(sessions), err)
+	}
+	if _, err := GetSession(8, "someone-else"); err != nil {
+		t.Errorf("RevokeReusedFamily() removed another user's session: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"hrm-app/internal/pkg/database"
+)
+
+// SessionRevokedChannel is the Redis pub/sub channel announcing revoked sessions,
+// so every server instance can drop the matching WebSocket clients.
+const SessionRevokedChannel = "sessions:revoked"
+
+// SessionMeta describes the device behind an access token session
+type SessionMeta struct {
+	ID         string    `json:"id"`
+	Family     string    `json:"family,omitempty"` // refresh token family issued with the session
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// SessionRevoked is published on SessionRevokedChannel. An empty SessionID means every session of the user.
+type SessionRevoked struct {
+	UserID    uint   `json:"user_id"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// SessionID is the public identifier of a session. The token itself is never exposed.
+func SessionID(token string) string {
+	return HashToken(token)[:16]
+}
+
+// GetSessionMeta returns the metadata of a session. Sessions stored before metadata
+// existed only carry their ID.
+func GetSessionMeta(userID uint, token string) (SessionMeta, error) {
+	value, err := GetSession(userID, token)
+	if err != nil {
+		return SessionMeta{}, err
+	}
+
+	var meta SessionMeta
+	if err := json.Unmarshal([]byte(value), &meta); err != nil {
+		meta = SessionMeta{}
+	}
+	meta.ID = SessionID(token)
+	return meta, nil
+}
+
+// TouchSession records activity on a session and slides its expiry. The write
+// only succeeds while the session exists, so revoking it between the read and
+// the write wins.
+func TouchSession(userID uint, token, ip, userAgent string, exp time.Duration) error {
+	meta, err := GetSessionMeta(userID, token)
+	if err != nil {
+		return err
+	}
+
+	meta.IP = ip
+	meta.UserAgent = userAgent
+	meta.LastSeenAt = time.Now()
+	if meta.CreatedAt.IsZero() {
+		meta.CreatedAt = meta.LastSeenAt
+	}
+	return UpdateSession(userID, token, meta, exp)
+}
+
+// ListSessions returns every active session of a user
+func ListSessions(userID uint) ([]SessionMeta, error) {
+	tokens, err := sessionTokens(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]SessionMeta, 0, len(tokens))
+	for _, token := range tokens {
+		meta, err := GetSessionMeta(userID, token)
+		if err != nil {
+			continue // expired between SCAN and GET
+		}
+		sessions = append(sessions, meta)
+	}
+	return sessions, nil
+}
+
+// RevokeSession deletes a single session by its public ID, along with its refresh
+// token family, and disconnects its WebSocket clients. It reports false if no session matched.
+func RevokeSession(userID uint, sessionID string) (bool, error) {
+	tokens, err := sessionTokens(userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, token := range tokens {
+		if SessionID(token) != sessionID {
+			continue
+		}
+
+		meta, _ := GetSessionMeta(userID, token)
+		if err := DeleteSession(userID, token); err != nil {
+			return false, err
+		}
+		if meta.Family != "" {
+			if err := DeleteRefreshFamily(userID, meta.Family); err != nil {
+				return false, err
+			}
+		}
+		return true, PublishSessionRevoked(userID, sessionID)
+	}
+	return false, nil
+}
+
+func PublishSessionRevoked(userID uint, sessionID string) error {
+	payload, err := json.Marshal(SessionRevoked{UserID: userID, SessionID: sessionID})
+	if err != nil {
+		return err
+	}
+	return database.RDB.Publish(ctx, SessionRevokedChannel, payload).Err()
+}
+
+func sessionTokens(userID uint) ([]string, error) {
+	prefix := fmt.Sprintf("session:%d:", userID)
+	iter := database.RDB.Scan(ctx, 0, prefix+"*", 100).Iterator()
+
+	var tokens []string
+	for iter.Next(ctx) {
+		tokens = append(tokens, strings.TrimPrefix(iter.Val(), prefix))
+	}
+	return tokens, iter.Err()
+}
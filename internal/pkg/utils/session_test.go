@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestTouchSession(t *testing.T) {
+	newTestRedis(t)
+
+	if err := SetSession(7, "access", SessionMeta{IP: "10.0.0.1"}, time.Minute); err != nil {
+		t.Fatalf("SetSession() error = %v", err)
+	}
+	if err := TouchSession(7, "access", "10.0.0.2", "test-agent", time.Hour); err != nil {
+		t.Fatalf("TouchSession() error = %v", err)
+	}
+
+	meta, err := GetSessionMeta(7, "access")
+	if err != nil {
+		t.Fatalf("GetSessionMeta() error = %v", err)
+	}
+	if meta.IP != "10.0.0.2" || meta.UserAgent != "test-agent" || meta.LastSeenAt.IsZero() {
+		t.Errorf("GetSessionMeta() = %+v, want the touched IP, user agent and last seen time", meta)
+	}
+}
+
+func TestTouchSession_RevokedStaysRevoked(t *testing.T) {
+	newTestRedis(t)
+
+	if err := SetSession(7, "access", SessionMeta{}, time.Hour); err != nil {
+		t.Fatalf("SetSession() error = %v", err)
+	}
+	if err := DeleteSession(7, "access"); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+
+	if err := TouchSession(7, "access", "10.0.0.2", "test-agent", time.Hour); !errors.Is(err, redis.Nil) {
+		t.Errorf("TouchSession() error = %v, want %v", err, redis.Nil)
+	}
+	// The write itself must not recreate the key either, even when the read raced ahead of the revoke
+	if err := UpdateSession(7, "access", SessionMeta{}, time.Hour); !errors.Is(err, redis.Nil) {
+		t.Errorf("UpdateSession() error = %v, want %v", err, redis.Nil)
+	}
+	if _, err := GetSession(7, "access"); !errors.Is(err, redis.Nil) {
+		t.Errorf("GetSession() after touching a revoked session error = %v, want %v", err, redis.Nil)
+	}
+}
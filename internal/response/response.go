@@ -3,17 +3,49 @@ package response
 import (
 	"net/http"
 
+	"hrm-app/internal/pkg/i18n"
+
 	"github.com/gin-gonic/gin"
 )
 
+// LanguageKey holds the caller's language in the gin context: either the
+// language itself or, until first needed, a func(*gin.Context) string that
+// looks it up (set by middleware.Language)
+const LanguageKey = "language"
+
+// Language returns the language messages to the caller are written in. Without
+// middleware.Language it is taken from the Accept-Language header.
+func Language(c *gin.Context) string {
+	if value, ok := c.Get(LanguageKey); ok {
+		switch lang := value.(type) {
+		case string:
+			return lang
+		case func(*gin.Context) string:
+			resolved := lang(c)
+			c.Set(LanguageKey, resolved)
+			return resolved
+		}
+	}
+	return i18n.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+}
+
+// Message translates an API message into the caller's language, formatted with args
+func Message(c *gin.Context, message string, args ...interface{}) string {
+	return i18n.Message(Language(c), message, args...)
+}
+
 func Error(c *gin.Context, status int, message string) {
-	c.JSON(status, gin.H{"error": message})
+	c.JSON(status, gin.H{"error": Message(c, message)})
 }
 
+// Success wraps data; a plain string is a message and is translated
 func Success(c *gin.Context, data interface{}) {
+	if message, ok := data.(string); ok {
+		data = Message(c, message)
+	}
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
 func DeleteSuccess(c *gin.Context, message string) {
-	c.JSON(http.StatusOK, gin.H{"message": message})
+	c.JSON(http.StatusOK, gin.H{"message": Message(c, message)})
 }
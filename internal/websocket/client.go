@@ -292,6 +292,8 @@ type Client struct {
 	UserID       uint
 	UserName     string
 	UserUsername string
+	SessionID    string // public ID of the access token session used to connect
+	IP           string // remote address at connect time, recorded in the audit log
 	Ctx          context.Context
 	Cancel       context.CancelFunc
 	limiter      *RateLimiter
@@ -301,6 +303,7 @@ type Client struct {
 func (c *Client) GetUserID() uint         { return c.UserID }
 func (c *Client) GetUserName() string     { return c.UserName }
 func (c *Client) GetUserUsername() string { return c.UserUsername }
+func (c *Client) GetIP() string           { return c.IP }
 func (c *Client) Send(message []byte) {
 	select {
 	case c.send <- message:
@@ -16,6 +16,7 @@ import (
 	"hrm-app/internal/domain/taskTab"
 	"hrm-app/internal/domain/user"
 	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
 	"hrm-app/internal/response"
 	"hrm-app/internal/websocket/handlerWebsocket"
 	"log"
@@ -55,20 +56,22 @@ type Handler struct {
 	labelHandler     *handlerWebsocket.LabelHandler
 	workspaceHandler *handlerWebsocket.WorkspaceHandler
 	chatHandler      *handlerWebsocket.ChatHandler
+	guard            *handlerWebsocket.BoardGuard
 	contactUC        contact.UseCase
 	userUC           user.UseCase
 }
 
-func NewHandler(hub *Hub, taskCardUC taskCard.UseCase, taskTabUC taskTab.UseCase, commentUC taskCardComment.UseCase, labelsUC labels.UseCase, taskCardUsersUC taskCardUsers.UseCase, boardsUsersUC boardsUsers.UseCase, workspacesUsersUC workspacesUsers.UseCase, boardsUC boards.UseCase, roomMessageUC room_messages.UseCase, roomChatUC room_chats.UseCase, roomUserUC roomUsers.UseCase, contactUC contact.UseCase, userUC user.UseCase) *Handler {
+func NewHandler(hub *Hub, taskCardUC taskCard.UseCase, taskTabUC taskTab.UseCase, commentUC taskCardComment.UseCase, labelsUC labels.UseCase, taskCardUsersUC taskCardUsers.UseCase, boardsUsersUC boardsUsers.UseCase, workspacesUsersUC workspacesUsers.UseCase, boardsUC boards.UseCase, roomMessageUC room_messages.UseCase, roomChatUC room_chats.UseCase, roomUserUC roomUsers.UseCase, contactUC contact.UseCase, userUC user.UseCase, authz policy.Authorizer, auditor handlerWebsocket.Auditor) *Handler {
 	return &Handler{
 		hub:              hub,
-		boardHandler:     handlerWebsocket.NewBoardHandler(boardsUC, boardsUsersUC, hub),
-		taskCardHandler:  handlerWebsocket.NewTaskCardHandler(taskCardUC, taskTabUC, taskCardUsersUC, hub),
-		taskTabHandler:   handlerWebsocket.NewTaskTabHandler(taskTabUC, hub),
-		commentHandler:   handlerWebsocket.NewCommentHandler(commentUC, taskCardUC, taskTabUC, hub),
-		labelHandler:     handlerWebsocket.NewLabelHandler(labelsUC, taskCardUC, taskTabUC, hub),
-		workspaceHandler: handlerWebsocket.NewWorkspaceHandler(workspacesUsersUC, hub),
+		boardHandler:     handlerWebsocket.NewBoardHandler(boardsUC, boardsUsersUC, authz, auditor, hub),
+		taskCardHandler:  handlerWebsocket.NewTaskCardHandler(taskCardUC, taskTabUC, taskCardUsersUC, auditor, hub),
+		taskTabHandler:   handlerWebsocket.NewTaskTabHandler(taskTabUC, auditor, hub),
+		commentHandler:   handlerWebsocket.NewCommentHandler(commentUC, taskCardUC, taskTabUC, auditor, hub),
+		labelHandler:     handlerWebsocket.NewLabelHandler(labelsUC, taskCardUC, taskTabUC, auditor, hub),
+		workspaceHandler: handlerWebsocket.NewWorkspaceHandler(workspacesUsersUC, auditor, hub),
 		chatHandler:      handlerWebsocket.NewChatHandler(roomMessageUC, roomChatUC, roomUserUC, hub),
+		guard:            handlerWebsocket.NewBoardGuard(authz, policy.NewBoardLocator()),
 		contactUC:        contactUC,
 		userUC:           userUC,
 	}
@@ -91,6 +94,7 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 	uID, _ := c.Get("user_id")
 	userID := uID.(uint)
 	userIDStr := strconv.FormatUint(uint64(userID), 10)
+	sessionID := c.GetString("session_id")
 
 	// Fetch user's name from contact domain and username from user domain
 	userName := "User"
@@ -113,6 +117,8 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 		UserID:       userID,
 		UserName:     userName,
 		UserUsername: userUsername,
+		SessionID:    sessionID,
+		IP:           c.ClientIP(),
 		Ctx:          ctx,
 		Cancel:       cancel,
 	}
@@ -177,6 +183,12 @@ func (h *Handler) handleMessages(client *Client) {
 			continue
 		}
 
+		// Reject board-scoped actions before any handler writes
+		if err := h.guard.Authorize(client, msg.Action, msg.Payload); err != nil {
+			h.guard.SendError(client, msg.Action, err.Error())
+			continue
+		}
+
 		// Handle different actions using domain handlers
 		switch msg.Action {
 		// Board Actions
@@ -194,6 +206,8 @@ func (h *Handler) handleMessages(client *Client) {
 			h.taskCardHandler.HandleUpdateTaskTabID(client, msg.Payload)
 		case "update_task_card":
 			h.taskCardHandler.HandleUpdateTaskCard(client, msg.Payload)
+		case "move_task_card":
+			h.taskCardHandler.HandleMoveTaskCard(client, msg.Payload)
 		case "assign_task_card_user":
 			h.taskCardHandler.HandleAssignTaskCardUser(client, msg.Payload)
 		case "unassign_task_card_user":
@@ -202,6 +216,8 @@ func (h *Handler) handleMessages(client *Client) {
 		// Task Tab Actions
 		case "update_task_tab":
 			h.taskTabHandler.HandleUpdateTaskTab(client, msg.Payload)
+		case "move_task_tab":
+			h.taskTabHandler.HandleMoveTaskTab(client, msg.Payload)
 
 		// Comment Actions
 		case "create_task_card_comment":
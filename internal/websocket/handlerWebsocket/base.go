@@ -3,6 +3,8 @@ package handlerWebsocket
 import (
 	"context"
 	"encoding/json"
+
+	"hrm-app/internal/domain/audit"
 )
 
 // Client interface defines the methods needed by handlers to interact with a client
@@ -10,6 +12,7 @@ type Client interface {
 	GetUserID() uint
 	GetUserName() string
 	GetUserUsername() string
+	GetIP() string
 	Send(message []byte)
 	Close()
 	GetContext() context.Context
@@ -25,8 +28,29 @@ type Hub interface {
 	BroadcastMessage(message []byte)
 }
 
+// Auditor records mutations (implemented by audit.UseCase, or
+// activity.Recorder to also feed the board activity)
+type Auditor interface {
+	Record(entry audit.Entry)
+}
+
 // BaseHandler provides common utility methods for all WebSocket handlers
-type BaseHandler struct{}
+type BaseHandler struct {
+	auditor Auditor
+}
+
+// Audit records a mutation made by client. Handlers call it next to
+// BroadcastSuccess, once the change is stored.
+func (bh *BaseHandler) Audit(client Client, entry audit.Entry) {
+	if bh.auditor == nil {
+		return
+	}
+	actorID := client.GetUserID()
+	entry.ActorID = &actorID
+	entry.ActorUsername = client.GetUserUsername()
+	entry.IP = client.GetIP()
+	bh.auditor.Record(entry)
+}
 
 // SendError sends an error message to a client
 func (bh *BaseHandler) SendError(client Client, action string, errorMsg string) {
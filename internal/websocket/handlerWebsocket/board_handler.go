@@ -2,8 +2,10 @@ package handlerWebsocket
 
 import (
 	"encoding/json"
+	"hrm-app/internal/domain/audit"
 	"hrm-app/internal/domain/boards"
 	"hrm-app/internal/domain/boardsUsers"
+	"hrm-app/internal/pkg/policy"
 	"log"
 )
 
@@ -11,13 +13,16 @@ type BoardHandler struct {
 	BaseHandler
 	boardsUseCase      boards.UseCase
 	boardsUsersUseCase boardsUsers.UseCase
+	authz              policy.Authorizer
 	hub                Hub
 }
 
-func NewBoardHandler(boardsUseCase boards.UseCase, boardsUsersUseCase boardsUsers.UseCase, hub Hub) *BoardHandler {
+func NewBoardHandler(boardsUseCase boards.UseCase, boardsUsersUseCase boardsUsers.UseCase, authz policy.Authorizer, auditor Auditor, hub Hub) *BoardHandler {
 	return &BoardHandler{
+		BaseHandler:        BaseHandler{auditor: auditor},
 		boardsUseCase:      boardsUseCase,
 		boardsUsersUseCase: boardsUsersUseCase,
+		authz:              authz,
 		hub:                hub,
 	}
 }
@@ -27,8 +32,9 @@ type JoinBoardPayload struct {
 }
 
 type AssignBoardUserPayload struct {
-	BoardID uint `json:"board_id"`
-	UserID  uint `json:"user_id"`
+	BoardID uint        `json:"board_id"`
+	UserID  uint        `json:"user_id"`
+	Role    policy.Role `json:"role,omitempty"`
 }
 
 type UnassignBoardUserPayload struct {
@@ -42,9 +48,8 @@ func (h *BoardHandler) HandleJoinBoard(client Client, payload json.RawMessage) {
 		return
 	}
 
-	// Check if user is member of the board
-	hasAccess, err := h.boardsUsersUseCase.HasAccess(msg.BoardID, client.GetUserID())
-	if err != nil || !hasAccess {
+	// Check if user may view the board (members, or workspace owners/admins)
+	if err := h.authz.AuthorizeBoard(msg.BoardID, client.GetUserID(), policy.ActionView); err != nil {
 		log.Printf("[WS Auth] Unauthorized board join attempt: UserID=%d, BoardID=%d", client.GetUserID(), msg.BoardID)
 		h.SendError(client, "join_board", "Unauthorized: You are not a member of this board")
 		return
@@ -67,9 +72,10 @@ func (h *BoardHandler) HandleAssignBoardUser(client Client, payload json.RawMess
 	assignment := &boardsUsers.BoardsUsers{
 		BoardID: msg.BoardID,
 		UserID:  msg.UserID,
+		Role:    msg.Role,
 	}
 
-	if err := h.boardsUsersUseCase.Create(assignment, msg.UserID); err != nil {
+	if err := h.boardsUsersUseCase.Create(assignment, client.GetUserID()); err != nil {
 		h.SendError(client, "assign_board_user", "Failed to assign user to board: "+err.Error())
 		return
 	}
@@ -82,6 +88,7 @@ func (h *BoardHandler) HandleAssignBoardUser(client Client, payload json.RawMess
 
 	h.SendSuccess(client, "assign_board_user", msg, fullAssignment)
 	h.BroadcastSuccess(h.hub, msg.BoardID, "assign_board_user", msg, fullAssignment)
+	h.Audit(client, audit.New("assign_board_user", audit.EntityBoardUser, assignment.ID).WithBoard(msg.BoardID).WithChanges(nil, assignment))
 }
 
 func (h *BoardHandler) HandleUnassignBoardUser(client Client, payload json.RawMessage) {
@@ -97,11 +104,12 @@ func (h *BoardHandler) HandleUnassignBoardUser(client Client, payload json.RawMe
 		return
 	}
 
-	if err := h.boardsUsersUseCase.Delete(msg.ID); err != nil {
+	if err := h.boardsUsersUseCase.Delete(msg.ID, client.GetUserID()); err != nil {
 		h.SendError(client, "unassign_board_user", "Failed to unassign user from board: "+err.Error())
 		return
 	}
 
 	h.SendSuccess(client, "unassign_board_user", msg, map[string]interface{}{"id": msg.ID})
 	h.BroadcastSuccess(h.hub, assignment.BoardID, "unassign_board_user", msg, map[string]interface{}{"id": msg.ID})
+	h.Audit(client, audit.New("unassign_board_user", audit.EntityBoardUser, msg.ID).WithBoard(assignment.BoardID).WithChanges(assignment, nil))
 }
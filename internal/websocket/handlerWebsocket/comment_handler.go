@@ -3,6 +3,7 @@ package handlerWebsocket
 import (
 	"context"
 	"encoding/json"
+	"hrm-app/internal/domain/audit"
 	"hrm-app/internal/domain/taskCard"
 	"hrm-app/internal/domain/taskCardComment"
 	"hrm-app/internal/domain/taskTab"
@@ -16,8 +17,9 @@ type CommentHandler struct {
 	hub                    Hub
 }
 
-func NewCommentHandler(taskCardCommentUseCase taskCardComment.UseCase, taskCardUseCase taskCard.UseCase, taskTabUseCase taskTab.UseCase, hub Hub) *CommentHandler {
+func NewCommentHandler(taskCardCommentUseCase taskCardComment.UseCase, taskCardUseCase taskCard.UseCase, taskTabUseCase taskTab.UseCase, auditor Auditor, hub Hub) *CommentHandler {
 	return &CommentHandler{
+		BaseHandler:            BaseHandler{auditor: auditor},
 		taskCardCommentUseCase: taskCardCommentUseCase,
 		taskCardUseCase:        taskCardUseCase,
 		taskTabUseCase:         taskTabUseCase,
@@ -84,6 +86,7 @@ func (h *CommentHandler) HandleCreateTaskCardComment(client Client, payload json
 
 	h.SendSuccess(client, "create_task_card_comment", msg, fullComment)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "create_task_card_comment", msg, fullComment)
+	h.Audit(client, audit.New("create_task_card_comment", audit.EntityTaskCardComment, uint(comment.ID)).WithBoard(taskTab.BoardID).WithChanges(nil, fullComment))
 }
 
 func (h *CommentHandler) HandleUpdateTaskCardComment(client Client, payload json.RawMessage) {
@@ -102,10 +105,11 @@ func (h *CommentHandler) HandleUpdateTaskCardComment(client Client, payload json
 		h.SendError(client, "update_task_card_comment", "Comment not found")
 		return
 	}
+	before := *comment
 
 	comment.Comment = msg.Comment
 
-	if err := h.taskCardCommentUseCase.Update(comment); err != nil {
+	if err := h.taskCardCommentUseCase.Update(comment, client.GetUserID()); err != nil {
 		h.SendError(client, "update_task_card_comment", "Failed to update comment: "+err.Error())
 		return
 	}
@@ -127,6 +131,7 @@ func (h *CommentHandler) HandleUpdateTaskCardComment(client Client, payload json
 
 	h.SendSuccess(client, "update_task_card_comment", msg, comment)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "update_task_card_comment", msg, comment)
+	h.Audit(client, audit.New("update_task_card_comment", audit.EntityTaskCardComment, uint(comment.ID)).WithBoard(taskTab.BoardID).WithChanges(before, comment))
 }
 
 func (h *CommentHandler) HandleDeleteTaskCardComment(client Client, payload json.RawMessage) {
@@ -161,11 +166,12 @@ func (h *CommentHandler) HandleDeleteTaskCardComment(client Client, payload json
 		return
 	}
 
-	if err := h.taskCardCommentUseCase.Delete(uint(msg.ID)); err != nil {
+	if err := h.taskCardCommentUseCase.Delete(uint(msg.ID), client.GetUserID()); err != nil {
 		h.SendError(client, "delete_task_card_comment", "Failed to delete comment: "+err.Error())
 		return
 	}
 
 	h.SendSuccess(client, "delete_task_card_comment", msg, map[string]interface{}{"id": msg.ID})
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "delete_task_card_comment", msg, map[string]interface{}{"id": msg.ID})
+	h.Audit(client, audit.New("delete_task_card_comment", audit.EntityTaskCardComment, uint(msg.ID)).WithBoard(taskTab.BoardID).WithChanges(comment, nil))
 }
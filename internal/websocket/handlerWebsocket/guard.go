@@ -0,0 +1,171 @@
+package handlerWebsocket
+
+import (
+	"encoding/json"
+	"errors"
+	"hrm-app/internal/pkg/policy"
+	"log"
+)
+
+// guardPayload holds every field the guard may need to locate the target board
+type guardPayload struct {
+	ID         uint `json:"id"`
+	BoardID    uint `json:"board_id"`
+	TaskTabID  uint `json:"task_tab_id"`
+	TaskCardID uint `json:"task_card_id"`
+}
+
+// guardRule maps an action to the role it requires and the boards it touches
+type guardRule struct {
+	action policy.Action
+	boards func(p guardPayload) ([]uint, error)
+}
+
+// BoardGuard authorizes board-scoped WebSocket actions before they are
+// dispatched, so no handler writes to a board the caller cannot access.
+type BoardGuard struct {
+	BaseHandler
+	authz   policy.Authorizer
+	locator policy.BoardLocator
+	rules   map[string]guardRule
+}
+
+// selfAuthorized lists the actions that are not board-scoped; their handlers
+// check workspace and room membership themselves
+var selfAuthorized = map[string]bool{
+	"assign_workspace_user":    true,
+	"unassign_workspace_user":  true,
+	"join_room_chat":           true,
+	"send_room_chat_message":   true,
+	"edit_room_chat_message":   true,
+	"delete_room_chat_message": true,
+	"typing_indicator":         true,
+}
+
+func NewBoardGuard(authz policy.Authorizer, locator policy.BoardLocator) *BoardGuard {
+	g := &BoardGuard{
+		authz:   authz,
+		locator: locator,
+	}
+
+	// Board roles map onto actions: viewers may only join and receive
+	// broadcasts, commenters may also comment, editors change cards, tabs and
+	// labels, and admins manage members
+	g.rules = map[string]guardRule{
+		// Board Actions
+		"join_board":          {policy.ActionView, g.byBoard},
+		"assign_board_user":   {policy.ActionManageMembers, g.byBoard},
+		"unassign_board_user": {policy.ActionManageMembers, g.byBoardUser},
+
+		// Task Card Actions
+		"create_task_card":        {policy.ActionEdit, g.byTaskTab},
+		"update_task_tab_id":      {policy.ActionEdit, g.byTaskCardAndTargetTab},
+		"update_task_card":        {policy.ActionEdit, g.byTaskCardAndTargetTab},
+		"move_task_card":          {policy.ActionEdit, g.byTaskCardAndTargetTab},
+		"assign_task_card_user":   {policy.ActionEdit, g.byTaskCard},
+		"unassign_task_card_user": {policy.ActionEdit, g.byTaskCardUser},
+
+		// Task Tab Actions
+		"update_task_tab": {policy.ActionEdit, g.byTaskTab},
+		"move_task_tab":   {policy.ActionEdit, g.byTaskTab},
+
+		// Comment Actions
+		"create_task_card_comment": {policy.ActionComment, g.byTaskCard},
+		"update_task_card_comment": {policy.ActionComment, g.byComment},
+		"delete_task_card_comment": {policy.ActionComment, g.byComment},
+
+		// Label Actions
+		"create_label": {policy.ActionEdit, g.byTaskCard},
+		"update_label": {policy.ActionEdit, g.byLabel},
+		"delete_label": {policy.ActionEdit, g.byLabel},
+	}
+
+	return g
+}
+
+// Authorize checks the caller's board role for a board-scoped action.
+// Workspace and chat actions authorize themselves. Any other action is
+// rejected, so a new board action cannot skip the guard by missing a rule.
+func (g *BoardGuard) Authorize(client Client, action string, payload json.RawMessage) error {
+	if selfAuthorized[action] {
+		return nil
+	}
+	rule, ok := g.rules[action]
+	if !ok {
+		return errors.New("Unknown action")
+	}
+
+	var p guardPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return errors.New("Invalid payload")
+	}
+
+	boardIDs, err := rule.boards(p)
+	if err != nil {
+		if errors.Is(err, policy.ErrTargetNotFound) {
+			return errors.New("Target not found")
+		}
+		return err
+	}
+
+	for _, boardID := range boardIDs {
+		if err := g.authz.AuthorizeBoard(boardID, client.GetUserID(), rule.action); err != nil {
+			log.Printf("[WS Auth] Rejected %s: UserID=%d, BoardID=%d: %v", action, client.GetUserID(), boardID, err)
+			return errors.New("Unauthorized: you do not have permission to " + string(rule.action) + " on this board")
+		}
+	}
+
+	return nil
+}
+
+func (g *BoardGuard) byBoard(p guardPayload) ([]uint, error) {
+	return []uint{p.BoardID}, nil
+}
+
+func (g *BoardGuard) byBoardUser(p guardPayload) ([]uint, error) {
+	boardID, err := g.locator.BoardIDByBoardUser(p.ID)
+	return []uint{boardID}, err
+}
+
+func (g *BoardGuard) byTaskTab(p guardPayload) ([]uint, error) {
+	boardID, err := g.locator.BoardIDByTaskTab(p.TaskTabID)
+	return []uint{boardID}, err
+}
+
+func (g *BoardGuard) byTaskCard(p guardPayload) ([]uint, error) {
+	boardID, err := g.locator.BoardIDByTaskCard(p.TaskCardID)
+	return []uint{boardID}, err
+}
+
+// byTaskCardAndTargetTab also checks the destination tab when a card is moved,
+// so cards cannot be moved onto a board the caller cannot edit.
+func (g *BoardGuard) byTaskCardAndTargetTab(p guardPayload) ([]uint, error) {
+	boardID, err := g.locator.BoardIDByTaskCard(p.TaskCardID)
+	if err != nil {
+		return nil, err
+	}
+	if p.TaskTabID == 0 {
+		return []uint{boardID}, nil
+	}
+
+	targetBoardID, err := g.locator.BoardIDByTaskTab(p.TaskTabID)
+	if err != nil {
+		return nil, err
+	}
+	return []uint{boardID, targetBoardID}, nil
+}
+
+func (g *BoardGuard) byTaskCardUser(p guardPayload) ([]uint, error) {
+	boardID, err := g.locator.BoardIDByTaskCardUser(p.ID)
+	return []uint{boardID}, err
+}
+
+func (g *BoardGuard) byComment(p guardPayload) ([]uint, error) {
+	boardID, err := g.locator.BoardIDByComment(p.ID)
+	return []uint{boardID}, err
+}
+
+func (g *BoardGuard) byLabel(p guardPayload) ([]uint, error) {
+	boardID, err := g.locator.BoardIDByLabel(p.ID)
+	return []uint{boardID}, err
+}
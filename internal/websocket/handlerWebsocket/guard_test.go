@@ -0,0 +1,132 @@
+package handlerWebsocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"hrm-app/internal/pkg/policy"
+)
+
+// mockBoardRoles gives user 1 editor on board 1 and commenter on board 2,
+// user 2 viewer on board 1, and user 4 editor on both boards
+type mockBoardRoles struct{}
+
+func (m mockBoardRoles) FindWorkspaceRole(workspaceID, userID uint) (policy.Role, error) {
+	return "", nil
+}
+
+func (m mockBoardRoles) FindBoardRole(boardID, userID uint) (policy.Role, error) {
+	roles := map[[2]uint]policy.Role{
+		{1, 1}: policy.RoleEditor,
+		{2, 1}: policy.RoleCommenter,
+		{1, 2}: policy.RoleViewer,
+		{1, 4}: policy.RoleEditor,
+		{2, 4}: policy.RoleEditor,
+	}
+	return roles[[2]uint{boardID, userID}], nil
+}
+
+func (m mockBoardRoles) FindBoardWorkspaceID(boardID uint) (uint, error) {
+	return 0, nil
+}
+
+func (m mockBoardRoles) IsWorkspaceReadOnly(workspaceID uint) (bool, error) {
+	return false, nil
+}
+
+// mockLocator places tab 10, card 100, comment 1000 and label 5000 on board 1,
+// and tab 20 and card 200 on board 2
+type mockLocator struct{}
+
+func locate(boards map[uint]uint, id uint) (uint, error) {
+	if board, ok := boards[id]; ok {
+		return board, nil
+	}
+	return 0, policy.ErrTargetNotFound
+}
+
+func (mockLocator) BoardIDByTaskTab(id uint) (uint, error) {
+	return locate(map[uint]uint{10: 1, 20: 2}, id)
+}
+
+func (mockLocator) BoardIDByTaskCard(id uint) (uint, error) {
+	return locate(map[uint]uint{100: 1, 200: 2}, id)
+}
+
+func (mockLocator) BoardIDByLabel(id uint) (uint, error) {
+	return locate(map[uint]uint{5000: 1}, id)
+}
+
+func (mockLocator) BoardIDByComment(id uint) (uint, error) {
+	return locate(map[uint]uint{1000: 1}, id)
+}
+
+func (mockLocator) BoardIDByTaskCardUser(id uint) (uint, error) {
+	return locate(nil, id)
+}
+
+func (mockLocator) BoardIDByBoardUser(id uint) (uint, error) {
+	return locate(nil, id)
+}
+
+type mockClient struct {
+	userID uint
+}
+
+func (c *mockClient) GetUserID() uint             { return c.userID }
+func (c *mockClient) GetUserName() string         { return "" }
+func (c *mockClient) GetUserUsername() string     { return "" }
+func (c *mockClient) GetIP() string               { return "" }
+func (c *mockClient) Send(message []byte)         {}
+func (c *mockClient) Close()                      {}
+func (c *mockClient) GetContext() context.Context { return context.Background() }
+
+func TestBoardGuard_Authorize(t *testing.T) {
+	guard := NewBoardGuard(policy.New(mockBoardRoles{}), mockLocator{})
+
+	tests := []struct {
+		name    string
+		userID  uint
+		action  string
+		payload string
+		wantErr bool
+	}{
+		{"unknown action", 1, "drop_board", `{"board_id": 1}`, true},
+		{"chat action authorizes itself", 3, "send_room_chat_message", `{}`, false},
+		{"viewer joins", 2, "join_board", `{"board_id": 1}`, false},
+		{"non member cannot join", 3, "join_board", `{"board_id": 1}`, true},
+		{"viewer cannot create a card", 2, "create_task_card", `{"task_tab_id": 10}`, true},
+		{"editor creates a card", 1, "create_task_card", `{"task_tab_id": 10}`, false},
+		{"viewer cannot comment", 2, "create_task_card_comment", `{"task_card_id": 100}`, true},
+		{"commenter comments", 1, "create_task_card_comment", `{"task_card_id": 200}`, false},
+		{"commenter cannot edit a card", 1, "update_task_card", `{"task_card_id": 200}`, true},
+		{"editor cannot manage members", 1, "assign_board_user", `{"board_id": 1}`, true},
+		{"editor updates a label", 1, "update_label", `{"id": 5000}`, false},
+		{"editor moves a card within the board", 1, "move_task_card", `{"task_card_id": 100, "task_tab_id": 10}`, false},
+		{"editor cannot move a card onto a board they only comment on", 1, "move_task_card", `{"task_card_id": 100, "task_tab_id": 20}`, true},
+		{"editor cannot move a card off a board they only comment on", 1, "move_task_card", `{"task_card_id": 200, "task_tab_id": 10}`, true},
+		{"editor of both boards moves a card across", 4, "move_task_card", `{"task_card_id": 100, "task_tab_id": 20}`, false},
+		{"unknown target tab", 4, "move_task_card", `{"task_card_id": 100, "task_tab_id": 30}`, true},
+		{"invalid payload", 1, "join_board", `[]`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.Authorize(&mockClient{userID: tt.userID}, tt.action, json.RawMessage(tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authorize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Every action is either board-scoped or authorizes itself, never both
+func TestBoardGuard_RulesDoNotOverlapSelfAuthorized(t *testing.T) {
+	guard := NewBoardGuard(policy.New(mockBoardRoles{}), mockLocator{})
+	for action := range selfAuthorized {
+		if _, ok := guard.rules[action]; ok {
+			t.Errorf("%s has a board rule and is also self-authorized", action)
+		}
+	}
+}
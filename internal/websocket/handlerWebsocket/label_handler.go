@@ -3,6 +3,7 @@ package handlerWebsocket
 import (
 	"context"
 	"encoding/json"
+	"hrm-app/internal/domain/audit"
 	"hrm-app/internal/domain/labels"
 	"hrm-app/internal/domain/taskCard"
 	"hrm-app/internal/domain/taskTab"
@@ -16,8 +17,9 @@ type LabelHandler struct {
 	hub             Hub
 }
 
-func NewLabelHandler(labelsUseCase labels.UseCase, taskCardUseCase taskCard.UseCase, taskTabUseCase taskTab.UseCase, hub Hub) *LabelHandler {
+func NewLabelHandler(labelsUseCase labels.UseCase, taskCardUseCase taskCard.UseCase, taskTabUseCase taskTab.UseCase, auditor Auditor, hub Hub) *LabelHandler {
 	return &LabelHandler{
+		BaseHandler:     BaseHandler{auditor: auditor},
 		labelsUseCase:   labelsUseCase,
 		taskCardUseCase: taskCardUseCase,
 		taskTabUseCase:  taskTabUseCase,
@@ -72,6 +74,7 @@ func (h *LabelHandler) HandleCreateLabel(client Client, payload json.RawMessage)
 
 	h.SendSuccess(client, "create_label", msg, label)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "create_label", msg, label)
+	h.Audit(client, audit.New("create_label", audit.EntityLabel, label.ID).WithBoard(taskTab.BoardID).WithChanges(nil, label))
 }
 
 func (h *LabelHandler) HandleUpdateLabel(client Client, payload json.RawMessage) {
@@ -86,6 +89,7 @@ func (h *LabelHandler) HandleUpdateLabel(client Client, payload json.RawMessage)
 		h.SendError(client, "update_label", "Label not found")
 		return
 	}
+	before := *label
 
 	if msg.Title != "" {
 		label.Title = msg.Title
@@ -112,6 +116,7 @@ func (h *LabelHandler) HandleUpdateLabel(client Client, payload json.RawMessage)
 
 	h.SendSuccess(client, "update_label", msg, label)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "update_label", msg, label)
+	h.Audit(client, audit.New("update_label", audit.EntityLabel, label.ID).WithBoard(taskTab.BoardID).WithChanges(before, label))
 }
 
 func (h *LabelHandler) HandleDeleteLabel(client Client, payload json.RawMessage) {
@@ -144,4 +149,5 @@ func (h *LabelHandler) HandleDeleteLabel(client Client, payload json.RawMessage)
 
 	h.SendSuccess(client, "delete_label", msg, map[string]interface{}{"id": msg.ID})
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "delete_label", msg, map[string]interface{}{"id": msg.ID})
+	h.Audit(client, audit.New("delete_label", audit.EntityLabel, msg.ID).WithBoard(taskTab.BoardID).WithChanges(label, nil))
 }
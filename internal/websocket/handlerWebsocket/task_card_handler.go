@@ -3,6 +3,7 @@ package handlerWebsocket
 import (
 	"context"
 	"encoding/json"
+	"hrm-app/internal/domain/audit"
 	"hrm-app/internal/domain/taskCard"
 	"hrm-app/internal/domain/taskCardUsers"
 	"hrm-app/internal/domain/taskTab"
@@ -16,8 +17,9 @@ type TaskCardHandler struct {
 	hub                  Hub
 }
 
-func NewTaskCardHandler(taskCardUseCase taskCard.UseCase, taskTabUseCase taskTab.UseCase, taskCardUsersUseCase taskCardUsers.UseCase, hub Hub) *TaskCardHandler {
+func NewTaskCardHandler(taskCardUseCase taskCard.UseCase, taskTabUseCase taskTab.UseCase, taskCardUsersUseCase taskCardUsers.UseCase, auditor Auditor, hub Hub) *TaskCardHandler {
 	return &TaskCardHandler{
+		BaseHandler:          BaseHandler{auditor: auditor},
 		taskCardUseCase:      taskCardUseCase,
 		taskTabUseCase:       taskTabUseCase,
 		taskCardUsersUseCase: taskCardUsersUseCase,
@@ -40,6 +42,17 @@ type UpdateTaskCardPayload struct {
 	Name       string `json:"name,omitempty"`
 }
 
+// MoveTaskCardPayload drops a card into TaskTabID (its own tab when omitted)
+// between BeforeID, the card that ends up above it, and AfterID, the card that
+// ends up below it. Omit one of them at the top or bottom of the tab, and both
+// to move to the bottom.
+type MoveTaskCardPayload struct {
+	TaskCardID uint `json:"task_card_id"`
+	TaskTabID  uint `json:"task_tab_id,omitempty"`
+	BeforeID   uint `json:"before_id,omitempty"`
+	AfterID    uint `json:"after_id,omitempty"`
+}
+
 type AssignTaskCardUserPayload struct {
 	TaskCardID uint `json:"task_card_id"`
 	UserID     uint `json:"user_id"`
@@ -68,6 +81,7 @@ func (h *TaskCardHandler) HandleUpdateTaskTabID(client Client, payload json.RawM
 		h.SendError(client, "update_task_tab_id", "Task card not found")
 		return
 	}
+	before := *taskCardData
 
 	taskCardData.TaskTabID = msg.TaskTabID
 
@@ -91,6 +105,7 @@ func (h *TaskCardHandler) HandleUpdateTaskTabID(client Client, payload json.RawM
 
 	h.SendSuccess(client, "update_task_tab_id", msg, freshTaskCard)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "update_task_tab_id", msg, freshTaskCard)
+	h.Audit(client, audit.New("update_task_tab_id", audit.EntityTaskCard, freshTaskCard.ID).WithBoard(taskTab.BoardID).WithChanges(before, freshTaskCard))
 }
 
 func (h *TaskCardHandler) HandleUpdateTaskCard(client Client, payload json.RawMessage) {
@@ -105,6 +120,7 @@ func (h *TaskCardHandler) HandleUpdateTaskCard(client Client, payload json.RawMe
 		h.SendError(client, "update_task_card", "Task card not found")
 		return
 	}
+	before := *taskCardData
 
 	if msg.TaskTabID != 0 {
 		taskCardData.TaskTabID = msg.TaskTabID
@@ -142,6 +158,46 @@ func (h *TaskCardHandler) HandleUpdateTaskCard(client Client, payload json.RawMe
 
 	h.SendSuccess(client, "update_task_card", msg, freshTaskCard)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "update_task_card", msg, freshTaskCard)
+	h.Audit(client, audit.New("update_task_card", audit.EntityTaskCard, freshTaskCard.ID).WithBoard(taskTab.BoardID).WithChanges(before, freshTaskCard))
+}
+
+func (h *TaskCardHandler) HandleMoveTaskCard(client Client, payload json.RawMessage) {
+	var msg MoveTaskCardPayload
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		h.SendError(client, "move_task_card", "Invalid payload")
+		return
+	}
+
+	before, err := h.taskCardUseCase.FindByID(context.Background(), msg.TaskCardID)
+	if err != nil {
+		h.SendError(client, "move_task_card", "Task card not found")
+		return
+	}
+	fromTab, err := h.taskTabUseCase.FindByID(before.TaskTabID)
+	if err != nil {
+		h.SendError(client, "move_task_card", "Task tab not found")
+		return
+	}
+
+	movedTaskCard, err := h.taskCardUseCase.Move(context.Background(), msg.TaskCardID, msg.TaskTabID, msg.BeforeID, msg.AfterID)
+	if err != nil {
+		h.SendError(client, "move_task_card", "Failed to move task card: "+err.Error())
+		return
+	}
+
+	taskTab, err := h.taskTabUseCase.FindByID(movedTaskCard.TaskTabID)
+	if err != nil {
+		h.SendError(client, "move_task_card", "Task tab not found")
+		return
+	}
+
+	h.SendSuccess(client, "move_task_card", msg, movedTaskCard)
+	h.BroadcastSuccess(h.hub, taskTab.BoardID, "move_task_card", msg, movedTaskCard)
+	// A card moved to another board also leaves the board it came from
+	if fromTab.BoardID != taskTab.BoardID {
+		h.BroadcastSuccess(h.hub, fromTab.BoardID, "move_task_card", msg, movedTaskCard)
+	}
+	h.Audit(client, audit.New("move_task_card", audit.EntityTaskCard, movedTaskCard.ID).WithBoard(taskTab.BoardID).WithChanges(before, movedTaskCard))
 }
 
 func (h *TaskCardHandler) HandleAssignTaskCardUser(client Client, payload json.RawMessage) {
@@ -180,6 +236,7 @@ func (h *TaskCardHandler) HandleAssignTaskCardUser(client Client, payload json.R
 
 	h.SendSuccess(client, "assign_task_card_user", msg, fullAssignment)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "assign_task_card_user", msg, fullAssignment)
+	h.Audit(client, audit.New("assign_task_card_user", audit.EntityTaskCardUser, assignment.ID).WithBoard(taskTab.BoardID).WithChanges(nil, assignment))
 }
 
 func (h *TaskCardHandler) HandleUnassignTaskCardUser(client Client, payload json.RawMessage) {
@@ -212,6 +269,7 @@ func (h *TaskCardHandler) HandleUnassignTaskCardUser(client Client, payload json
 
 	h.SendSuccess(client, "unassign_task_card_user", msg, map[string]interface{}{"id": msg.ID})
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "unassign_task_card_user", msg, map[string]interface{}{"id": msg.ID})
+	h.Audit(client, audit.New("unassign_task_card_user", audit.EntityTaskCardUser, msg.ID).WithBoard(taskTab.BoardID).WithChanges(assignment, nil))
 }
 
 func (h *TaskCardHandler) HandleCreateTaskCard(client Client, payload json.RawMessage) {
@@ -249,4 +307,5 @@ func (h *TaskCardHandler) HandleCreateTaskCard(client Client, payload json.RawMe
 
 	h.SendSuccess(client, "create_task_card", msg, freshTaskCard)
 	h.BroadcastSuccess(h.hub, taskTab.BoardID, "create_task_card", msg, freshTaskCard)
+	h.Audit(client, audit.New("create_task_card", audit.EntityTaskCard, freshTaskCard.ID).WithBoard(taskTab.BoardID).WithChanges(nil, freshTaskCard))
 }
@@ -2,6 +2,7 @@ package handlerWebsocket
 
 import (
 	"encoding/json"
+	"hrm-app/internal/domain/audit"
 	"hrm-app/internal/domain/taskTab"
 )
 
@@ -11,8 +12,9 @@ type TaskTabHandler struct {
 	hub            Hub
 }
 
-func NewTaskTabHandler(taskTabUseCase taskTab.UseCase, hub Hub) *TaskTabHandler {
+func NewTaskTabHandler(taskTabUseCase taskTab.UseCase, auditor Auditor, hub Hub) *TaskTabHandler {
 	return &TaskTabHandler{
+		BaseHandler:    BaseHandler{auditor: auditor},
 		taskTabUseCase: taskTabUseCase,
 		hub:            hub,
 	}
@@ -24,6 +26,15 @@ type UpdateTaskTabPayload struct {
 	Position  int    `json:"position,omitempty"`
 }
 
+// MoveTaskTabPayload drops a tab between BeforeID, the tab that ends up to its
+// left, and AfterID, the tab that ends up to its right. Omit one of them at
+// either end of the board, and both to move to the end.
+type MoveTaskTabPayload struct {
+	TaskTabID uint `json:"task_tab_id"`
+	BeforeID  uint `json:"before_id,omitempty"`
+	AfterID   uint `json:"after_id,omitempty"`
+}
+
 func (h *TaskTabHandler) HandleUpdateTaskTab(client Client, payload json.RawMessage) {
 	var msg UpdateTaskTabPayload
 	if err := json.Unmarshal(payload, &msg); err != nil {
@@ -36,6 +47,7 @@ func (h *TaskTabHandler) HandleUpdateTaskTab(client Client, payload json.RawMess
 		h.SendError(client, "update_task_tab", "Task tab not found")
 		return
 	}
+	before := *taskTabData
 
 	if msg.Name != "" {
 		taskTabData.Name = msg.Name
@@ -51,4 +63,29 @@ func (h *TaskTabHandler) HandleUpdateTaskTab(client Client, payload json.RawMess
 
 	h.SendSuccess(client, "update_task_tab", msg, taskTabData)
 	h.BroadcastSuccess(h.hub, taskTabData.BoardID, "update_task_tab", msg, taskTabData)
+	h.Audit(client, audit.New("update_task_tab", audit.EntityTaskTab, taskTabData.ID).WithBoard(taskTabData.BoardID).WithChanges(before, taskTabData))
+}
+
+func (h *TaskTabHandler) HandleMoveTaskTab(client Client, payload json.RawMessage) {
+	var msg MoveTaskTabPayload
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		h.SendError(client, "move_task_tab", "Invalid payload")
+		return
+	}
+
+	before, err := h.taskTabUseCase.FindByID(msg.TaskTabID)
+	if err != nil {
+		h.SendError(client, "move_task_tab", "Task tab not found")
+		return
+	}
+
+	taskTabData, err := h.taskTabUseCase.Move(msg.TaskTabID, msg.BeforeID, msg.AfterID)
+	if err != nil {
+		h.SendError(client, "move_task_tab", "Failed to move task tab: "+err.Error())
+		return
+	}
+
+	h.SendSuccess(client, "move_task_tab", msg, taskTabData)
+	h.BroadcastSuccess(h.hub, taskTabData.BoardID, "move_task_tab", msg, taskTabData)
+	h.Audit(client, audit.New("move_task_tab", audit.EntityTaskTab, taskTabData.ID).WithBoard(taskTabData.BoardID).WithChanges(before, taskTabData))
 }
@@ -2,7 +2,9 @@ package handlerWebsocket
 
 import (
 	"encoding/json"
+	"hrm-app/internal/domain/audit"
 	"hrm-app/internal/domain/workspacesUsers"
+	"hrm-app/internal/pkg/policy"
 )
 
 type WorkspaceHandler struct {
@@ -11,16 +13,18 @@ type WorkspaceHandler struct {
 	hub                    Hub
 }
 
-func NewWorkspaceHandler(workspacesUsersUseCase workspacesUsers.UseCase, hub Hub) *WorkspaceHandler {
+func NewWorkspaceHandler(workspacesUsersUseCase workspacesUsers.UseCase, auditor Auditor, hub Hub) *WorkspaceHandler {
 	return &WorkspaceHandler{
+		BaseHandler:            BaseHandler{auditor: auditor},
 		workspacesUsersUseCase: workspacesUsersUseCase,
 		hub:                    hub,
 	}
 }
 
 type AssignWorkspaceUserPayload struct {
-	WorkspaceID uint `json:"workspace_id"`
-	UserID      uint `json:"user_id"`
+	WorkspaceID uint        `json:"workspace_id"`
+	UserID      uint        `json:"user_id"`
+	Role        policy.Role `json:"role,omitempty"`
 }
 
 type UnassignWorkspaceUserPayload struct {
@@ -37,6 +41,7 @@ func (h *WorkspaceHandler) HandleAssignWorkspaceUser(client Client, payload json
 	assignment := &workspacesUsers.WorkspacesUsers{
 		WorkspaceID: msg.WorkspaceID,
 		UserID:      msg.UserID,
+		Role:        msg.Role,
 	}
 
 	if err := h.workspacesUsersUseCase.Create(assignment, client.GetUserID()); err != nil {
@@ -52,6 +57,7 @@ func (h *WorkspaceHandler) HandleAssignWorkspaceUser(client Client, payload json
 
 	h.SendSuccess(client, "assign_workspace_user", msg, fullAssignment)
 	h.BroadcastGlobalSuccess(h.hub, "assign_workspace_user", msg, fullAssignment)
+	h.Audit(client, audit.New("assign_workspace_user", audit.EntityWorkspaceUser, assignment.ID).WithWorkspace(msg.WorkspaceID).WithChanges(nil, assignment))
 }
 
 func (h *WorkspaceHandler) HandleUnassignWorkspaceUser(client Client, payload json.RawMessage) {
@@ -61,11 +67,18 @@ func (h *WorkspaceHandler) HandleUnassignWorkspaceUser(client Client, payload js
 		return
 	}
 
-	if err := h.workspacesUsersUseCase.Delete(msg.ID); err != nil {
+	assignment, err := h.workspacesUsersUseCase.GetByID(msg.ID)
+	if err != nil || assignment.ID == 0 {
+		h.SendError(client, "unassign_workspace_user", "Assignment not found")
+		return
+	}
+
+	if err := h.workspacesUsersUseCase.Delete(msg.ID, client.GetUserID()); err != nil {
 		h.SendError(client, "unassign_workspace_user", "Failed to unassign user from workspace: "+err.Error())
 		return
 	}
 
 	h.SendSuccess(client, "unassign_workspace_user", msg, map[string]interface{}{"id": msg.ID})
 	h.BroadcastGlobalSuccess(h.hub, "unassign_workspace_user", msg, map[string]interface{}{"id": msg.ID})
+	h.Audit(client, audit.New("unassign_workspace_user", audit.EntityWorkspaceUser, msg.ID).WithWorkspace(assignment.WorkspaceID).WithChanges(assignment, nil))
 }
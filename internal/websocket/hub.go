@@ -13,6 +13,7 @@ import (
 	rmqpool "hrm-app/internal/pkg/rabbitmq/pool"
 	rmqproducer "hrm-app/internal/pkg/rabbitmq/producer"
 	rmqsetup "hrm-app/internal/pkg/rabbitmq/setup"
+	"hrm-app/internal/pkg/utils"
 	"hrm-app/internal/websocket/handlerWebsocket"
 	"log"
 	"os"
@@ -196,6 +197,7 @@ func (h *Hub) Shutdown() {
 func (h *Hub) Run() {
 	// Start subscribing to Redis channels
 	go h.subscribeToRedis()
+	go h.subscribeToSessionRevocations()
 
 	// COMMENTED: Start subscribing to Kafka messages - Replaced with RabbitMQ
 	// go h.subscribeToKafka()
@@ -340,6 +342,49 @@ func (h *Hub) subscribeToRedis() {
 	}
 }
 
+// subscribeToSessionRevocations disconnects local clients whose session was revoked on any instance
+func (h *Hub) subscribeToSessionRevocations() {
+	pubsub := h.rdb.Subscribe(h.ctx, utils.SessionRevokedChannel)
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			var revoked utils.SessionRevoked
+			if err := json.Unmarshal([]byte(msg.Payload), &revoked); err != nil {
+				log.Printf("Invalid session revocation payload: %v", err)
+				continue
+			}
+			h.DisconnectSessions(revoked.UserID, revoked.SessionID)
+		}
+	}
+}
+
+// DisconnectSessions closes local clients of a user. An empty sessionID closes all of them.
+func (h *Hub) DisconnectSessions(userID uint, sessionID string) {
+	h.clientsMu.RLock()
+	var matched []*Client
+	for client := range h.clients {
+		if client.UserID == userID && (sessionID == "" || client.SessionID == sessionID) {
+			matched = append(matched, client)
+		}
+	}
+	h.clientsMu.RUnlock()
+
+	for _, client := range matched {
+		log.Printf("Disconnecting client of UserID %d: session revoked", userID)
+		client.Close()
+	}
+}
+
 // broadcastToLocalBoard sends a message to local clients subscribed to a specific board
 func (h *Hub) broadcastToLocalBoard(boardID uint, message []byte) {
 	h.mu.RLock()
@@ -373,6 +418,19 @@ func (h *Hub) BroadcastToBoard(boardID uint, message []byte) {
 	}
 }
 
+// PublishToUser delivers a message to one user's RabbitMQ queue, which forwards it to
+// every WebSocket connection of that user on any instance
+func (h *Hub) PublishToUser(userID uint, message []byte) error {
+	ch, err := h.rmqPool.Get()
+	if err != nil {
+		return err
+	}
+	defer h.rmqPool.Put(ch)
+
+	recipientID := strconv.FormatUint(uint64(userID), 10)
+	return rmqproducer.PublishToUser(ch, rmqconfig.ExchangeName, rmqconfig.GetUserRoutingKey(recipientID), message, h.instanceID)
+}
+
 // BroadcastToChatRoom - Using RabbitMQ (Kafka version commented out)
 func (h *Hub) BroadcastToChatRoom(roomID uint, message []byte) {
 	// 1. Local broadcast dulu (immediate feedback)
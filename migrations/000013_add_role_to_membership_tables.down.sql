@@ -0,0 +1,2 @@
+ALTER TABLE boards_users DROP COLUMN IF EXISTS role;
+ALTER TABLE workspaces_users DROP COLUMN IF EXISTS role;
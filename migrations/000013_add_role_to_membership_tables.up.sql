@@ -0,0 +1,16 @@
+ALTER TABLE workspaces_users
+ADD COLUMN role VARCHAR(15) NOT NULL DEFAULT 'member'
+CHECK (role IN ('owner', 'admin', 'member', 'guest', 'viewer'));
+
+ALTER TABLE boards_users
+ADD COLUMN role VARCHAR(15) NOT NULL DEFAULT 'member'
+CHECK (role IN ('owner', 'admin', 'member', 'guest', 'viewer'));
+
+-- Creators become owners of what they created
+INSERT INTO workspaces_users (workspace_id, user_id, role)
+SELECT id, created_by, 'owner' FROM workspaces
+ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = 'owner';
+
+INSERT INTO boards_users (board_id, user_id, role)
+SELECT id, created_by, 'owner' FROM boards
+ON CONFLICT (board_id, user_id) DO UPDATE SET role = 'owner';
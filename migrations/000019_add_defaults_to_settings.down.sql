@@ -0,0 +1,11 @@
+ALTER TABLE settings
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS updated_at;
+
+ALTER TABLE settings
+    ALTER COLUMN language DROP DEFAULT,
+    ALTER COLUMN language DROP NOT NULL,
+    ALTER COLUMN notification DROP DEFAULT,
+    ALTER COLUMN notification DROP NOT NULL;
+
+ALTER TABLE settings DROP CONSTRAINT IF EXISTS uq_settings_user_id;
@@ -0,0 +1,24 @@
+-- One settings row per user, with defaults for new rows
+DELETE FROM settings s
+USING settings newer
+WHERE s.user_id = newer.user_id AND s.id < newer.id;
+
+ALTER TABLE settings ADD CONSTRAINT uq_settings_user_id UNIQUE (user_id);
+
+UPDATE settings SET language = 'en_us' WHERE language IS NULL;
+UPDATE settings SET notification = 'allowed' WHERE notification IS NULL;
+
+ALTER TABLE settings
+    ALTER COLUMN language SET DEFAULT 'en_us',
+    ALTER COLUMN language SET NOT NULL,
+    ALTER COLUMN notification SET DEFAULT 'allowed',
+    ALTER COLUMN notification SET NOT NULL;
+
+ALTER TABLE settings
+    ADD COLUMN created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+-- Existing users get the defaults
+INSERT INTO settings (user_id)
+SELECT id FROM users
+WHERE NOT EXISTS (SELECT 1 FROM settings WHERE settings.user_id = users.id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_system_role;
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS system_role;
@@ -0,0 +1,7 @@
+ALTER TABLE users
+ADD COLUMN system_role VARCHAR(15) NOT NULL DEFAULT 'user'
+CHECK (system_role IN ('user', 'admin'));
+
+ALTER TABLE users ADD COLUMN disabled_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX idx_users_system_role ON users(system_role) WHERE system_role <> 'user';
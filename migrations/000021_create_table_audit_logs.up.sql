@@ -0,0 +1,22 @@
+-- Audit rows outlive the boards and workspaces they describe, so only the actor has a foreign key
+CREATE TABLE audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id INT NULL,
+    board_id INT NULL,
+    actor_id INT NULL,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id INT NULL,
+    changes JSONB NULL,
+    ip VARCHAR(45) NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_users_audit_logs
+    FOREIGN KEY (actor_id)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_audit_logs_workspace_id_created_at ON audit_logs(workspace_id, created_at DESC);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id);
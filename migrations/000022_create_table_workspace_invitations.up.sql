@@ -0,0 +1,43 @@
+CREATE TABLE workspace_invitations (
+    id SERIAL PRIMARY KEY,
+    workspace_id INT NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(15) NOT NULL DEFAULT 'member',
+    status VARCHAR(15) NOT NULL DEFAULT 'pending',
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by INT NULL,
+    accepted_by INT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE NULL,
+    last_sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_workspace_invitations_status
+    CHECK (status IN ('pending', 'accepted', 'declined', 'expired', 'revoked')),
+
+    CONSTRAINT chk_workspace_invitations_role
+    CHECK (role IN ('admin', 'member', 'guest', 'viewer')),
+
+    CONSTRAINT fk_workspaces_workspace_invitations
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_workspace_invitations_invited_by
+    FOREIGN KEY (invited_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL,
+
+    CONSTRAINT fk_users_workspace_invitations_accepted_by
+    FOREIGN KEY (accepted_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL
+);
+
+-- One outstanding invite per address and workspace; answered invites are kept as history
+CREATE UNIQUE INDEX idx_workspace_invitations_pending ON workspace_invitations(workspace_id, LOWER(email)) WHERE status = 'pending';
+CREATE INDEX idx_workspace_invitations_email ON workspace_invitations(LOWER(email));
@@ -0,0 +1,3 @@
+ALTER TABLE workspaces ADD COLUMN join_link VARCHAR(255) NOT NULL DEFAULT '';
+
+DROP TABLE IF EXISTS invite_links;
@@ -0,0 +1,56 @@
+CREATE TABLE invite_links (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(15) NOT NULL,
+    workspace_id INT NOT NULL,
+    board_id INT NULL,
+    role VARCHAR(15) NOT NULL DEFAULT 'member',
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    max_uses INT NULL,
+    use_count INT NOT NULL DEFAULT 0,
+    email_domain VARCHAR(255) NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by INT NULL,
+    revoked_by INT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_invite_links_entity_type
+    CHECK ((entity_type = 'workspace' AND board_id IS NULL) OR (entity_type = 'board' AND board_id IS NOT NULL)),
+
+    CONSTRAINT chk_invite_links_role
+    CHECK (role IN ('admin', 'member', 'guest', 'viewer')),
+
+    CONSTRAINT chk_invite_links_max_uses
+    CHECK (max_uses IS NULL OR max_uses > 0),
+
+    CONSTRAINT fk_workspaces_invite_links
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_boards_invite_links
+    FOREIGN KEY (board_id)
+    REFERENCES boards(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_invite_links_created_by
+    FOREIGN KEY (created_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL,
+
+    CONSTRAINT fk_users_invite_links_revoked_by
+    FOREIGN KEY (revoked_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_invite_links_workspace_id ON invite_links(workspace_id);
+CREATE INDEX idx_invite_links_board_id ON invite_links(board_id);
+
+-- The stored join links were passcode JWTs, which no longer grant access
+ALTER TABLE workspaces DROP COLUMN join_link;
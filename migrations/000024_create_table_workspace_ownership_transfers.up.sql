@@ -0,0 +1,34 @@
+CREATE TABLE workspace_ownership_transfers (
+    id SERIAL PRIMARY KEY,
+    workspace_id INT NOT NULL,
+    from_user_id INT NOT NULL,
+    to_user_id INT NOT NULL,
+    status VARCHAR(15) NOT NULL DEFAULT 'pending',
+    responded_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_workspace_ownership_transfers_status
+    CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+
+    CONSTRAINT fk_workspaces_workspace_ownership_transfers
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_workspace_ownership_transfers_from
+    FOREIGN KEY (from_user_id)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_workspace_ownership_transfers_to
+    FOREIGN KEY (to_user_id)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE
+);
+
+-- At most one transfer awaits confirmation per workspace
+CREATE UNIQUE INDEX idx_workspace_ownership_transfers_pending ON workspace_ownership_transfers(workspace_id) WHERE status = 'pending';
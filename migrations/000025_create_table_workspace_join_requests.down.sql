@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS workspace_join_requests;
+
+DROP INDEX IF EXISTS idx_workspaces_public_name;
+ALTER TABLE workspaces ALTER COLUMN privacy DROP NOT NULL;
+ALTER TABLE workspaces ALTER COLUMN privacy DROP DEFAULT;
@@ -0,0 +1,48 @@
+-- Privacy decides how people get in, so it can no longer be missing or unknown
+UPDATE workspaces SET privacy = 'private' WHERE privacy IS NULL OR privacy NOT IN ('private', 'public', 'team');
+
+ALTER TABLE workspaces DROP CONSTRAINT IF EXISTS workspaces_privacy_check;
+ALTER TABLE workspaces ALTER COLUMN privacy SET DEFAULT 'private';
+ALTER TABLE workspaces ALTER COLUMN privacy SET NOT NULL;
+ALTER TABLE workspaces
+ADD CONSTRAINT workspaces_privacy_check
+CHECK (privacy IN ('private', 'public', 'team'));
+
+CREATE INDEX idx_workspaces_public_name ON workspaces(LOWER(name)) WHERE privacy = 'public';
+
+CREATE TABLE workspace_join_requests (
+    id SERIAL PRIMARY KEY,
+    workspace_id INT NOT NULL,
+    user_id INT NOT NULL,
+    message VARCHAR(500) NULL,
+    status VARCHAR(15) NOT NULL DEFAULT 'pending',
+    role VARCHAR(15) NULL,
+    reviewed_by INT NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_workspace_join_requests_status
+    CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+
+    CONSTRAINT fk_workspaces_workspace_join_requests
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_workspace_join_requests
+    FOREIGN KEY (user_id)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_workspace_join_requests_reviewed_by
+    FOREIGN KEY (reviewed_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL
+);
+
+-- One open request per user and workspace; answered requests are kept as history
+CREATE UNIQUE INDEX idx_workspace_join_requests_pending ON workspace_join_requests(workspace_id, user_id) WHERE status = 'pending';
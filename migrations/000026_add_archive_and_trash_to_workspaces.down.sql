@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_workspaces_trashed_at;
+
+ALTER TABLE workspaces
+    DROP CONSTRAINT IF EXISTS fk_workspaces_trashed_by,
+    DROP CONSTRAINT IF EXISTS fk_workspaces_archived_by,
+    DROP COLUMN IF EXISTS trashed_by,
+    DROP COLUMN IF EXISTS trashed_at,
+    DROP COLUMN IF EXISTS archived_by,
+    DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,21 @@
+-- Archived workspaces are read-only and hidden from lists. Trashed workspaces
+-- can be restored until the purge job deletes them with everything they own.
+ALTER TABLE workspaces
+    ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE NULL,
+    ADD COLUMN archived_by INT NULL,
+    ADD COLUMN trashed_at TIMESTAMP WITH TIME ZONE NULL,
+    ADD COLUMN trashed_by INT NULL,
+
+    ADD CONSTRAINT fk_workspaces_archived_by
+    FOREIGN KEY (archived_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL,
+
+    ADD CONSTRAINT fk_workspaces_trashed_by
+    FOREIGN KEY (trashed_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_workspaces_trashed_at ON workspaces(trashed_at) WHERE trashed_at IS NOT NULL;
@@ -0,0 +1,25 @@
+-- Templates saved from boards; the built-in ones live in code
+CREATE TABLE board_templates (
+    id SERIAL PRIMARY KEY,
+    workspace_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NULL,
+    content JSONB NOT NULL DEFAULT '[]',
+    created_by INT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_workspaces_board_templates
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_board_templates
+    FOREIGN KEY (created_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_board_templates_workspace_id ON board_templates(workspace_id);
@@ -0,0 +1,18 @@
+ALTER TABLE invite_links DROP CONSTRAINT IF EXISTS chk_invite_links_role;
+
+UPDATE invite_links SET role = 'member' WHERE role = 'editor';
+UPDATE invite_links SET role = 'guest' WHERE role = 'commenter';
+
+ALTER TABLE invite_links
+ADD CONSTRAINT chk_invite_links_role
+CHECK (role IN ('admin', 'member', 'guest', 'viewer'));
+
+ALTER TABLE boards_users DROP CONSTRAINT IF EXISTS boards_users_role_check;
+
+UPDATE boards_users SET role = 'member' WHERE role = 'editor';
+UPDATE boards_users SET role = 'guest' WHERE role = 'commenter';
+
+ALTER TABLE boards_users ALTER COLUMN role SET DEFAULT 'member';
+ALTER TABLE boards_users
+ADD CONSTRAINT boards_users_role_check
+CHECK (role IN ('owner', 'admin', 'member', 'guest', 'viewer'));
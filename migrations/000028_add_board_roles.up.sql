@@ -0,0 +1,24 @@
+-- Boards get their own roles: admin, editor, commenter and viewer. Owner stays
+-- on the creator's row.
+ALTER TABLE boards_users DROP CONSTRAINT IF EXISTS boards_users_role_check;
+
+UPDATE boards_users SET role = 'editor' WHERE role = 'member';
+UPDATE boards_users SET role = 'commenter' WHERE role = 'guest';
+
+ALTER TABLE boards_users ALTER COLUMN role SET DEFAULT 'editor';
+ALTER TABLE boards_users
+ADD CONSTRAINT boards_users_role_check
+CHECK (role IN ('owner', 'admin', 'editor', 'commenter', 'viewer'));
+
+-- Board invite links grant board roles
+ALTER TABLE invite_links DROP CONSTRAINT IF EXISTS chk_invite_links_role;
+
+UPDATE invite_links SET role = 'editor' WHERE entity_type = 'board' AND role = 'member';
+UPDATE invite_links SET role = 'commenter' WHERE entity_type = 'board' AND role = 'guest';
+
+ALTER TABLE invite_links
+ADD CONSTRAINT chk_invite_links_role
+CHECK (
+    (entity_type = 'workspace' AND role IN ('admin', 'member', 'guest', 'viewer'))
+    OR (entity_type = 'board' AND role IN ('admin', 'editor', 'commenter', 'viewer'))
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_task_cards_task_tab_rank;
+DROP INDEX IF EXISTS idx_task_tabs_board_rank;
+
+ALTER TABLE task_cards DROP COLUMN IF EXISTS rank;
+ALTER TABLE task_tabs DROP COLUMN IF EXISTS rank;
@@ -0,0 +1,27 @@
+-- Tabs and cards are ordered by a lexicographic rank, so a drag and drop
+-- writes only the moved row. Ranks compare byte-wise, hence COLLATE "C".
+ALTER TABLE task_tabs ADD COLUMN rank VARCHAR(255) COLLATE "C";
+ALTER TABLE task_cards ADD COLUMN rank VARCHAR(255) COLLATE "C";
+
+-- Keep the current order: tabs by position, cards by creation
+UPDATE task_tabs t
+SET rank = lpad(o.n::text, 6, '0') || 'V', position = o.n
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY board_id ORDER BY position, id) AS n
+    FROM task_tabs
+) o
+WHERE t.id = o.id;
+
+UPDATE task_cards c
+SET rank = lpad(o.n::text, 6, '0') || 'V'
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY task_tab_id ORDER BY id) AS n
+    FROM task_cards
+) o
+WHERE c.id = o.id;
+
+ALTER TABLE task_tabs ALTER COLUMN rank SET NOT NULL;
+ALTER TABLE task_cards ALTER COLUMN rank SET NOT NULL;
+
+CREATE INDEX idx_task_tabs_board_rank ON task_tabs (board_id, rank);
+CREATE INDEX idx_task_cards_task_tab_rank ON task_cards (task_tab_id, rank);
@@ -0,0 +1,35 @@
+-- The activity feed belongs to its board and goes with it; a deleted card
+-- keeps its lines in the board feed
+CREATE TABLE board_activities (
+    id BIGSERIAL PRIMARY KEY,
+    board_id INT NOT NULL,
+    task_card_id INT NULL,
+    actor_id INT NULL,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id INT NULL,
+    message TEXT NOT NULL,
+    changes JSONB NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_boards_board_activities
+    FOREIGN KEY (board_id)
+    REFERENCES boards(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_task_cards_board_activities
+    FOREIGN KEY (task_card_id)
+    REFERENCES task_cards(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL,
+
+    CONSTRAINT fk_users_board_activities
+    FOREIGN KEY (actor_id)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_board_activities_board_id_created_at ON board_activities(board_id, created_at DESC);
+CREATE INDEX idx_board_activities_task_card_id_created_at ON board_activities(task_card_id, created_at DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS board_import_jobs;
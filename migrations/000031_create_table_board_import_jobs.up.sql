@@ -0,0 +1,39 @@
+-- Large board imports are queued here and run by cmd/rabbitmq_worker
+CREATE TABLE board_import_jobs (
+    id SERIAL PRIMARY KEY,
+    workspace_id INT NOT NULL,
+    created_by INT NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    name VARCHAR(255) NULL,
+    source TEXT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    board_id INT NULL,
+    skipped JSONB NULL,
+    error TEXT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE NULL,
+
+    CONSTRAINT board_import_jobs_status_check
+    CHECK (status IN ('pending', 'running', 'done', 'failed')),
+
+    CONSTRAINT fk_workspaces_board_import_jobs
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_users_board_import_jobs
+    FOREIGN KEY (created_by)
+    REFERENCES users(id)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_boards_board_import_jobs
+    FOREIGN KEY (board_id)
+    REFERENCES boards(id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_board_import_jobs_created_by ON board_import_jobs(created_by);
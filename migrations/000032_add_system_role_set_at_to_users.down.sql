@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN IF EXISTS system_role_set_at;
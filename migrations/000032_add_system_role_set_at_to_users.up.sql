@@ -0,0 +1,3 @@
+-- Set when an administrator changes a user's system role, so the configured
+-- admin emails do not promote a demoted account again on the next startup
+ALTER TABLE users ADD COLUMN system_role_set_at TIMESTAMP WITH TIME ZONE NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE task_cards ALTER COLUMN rank TYPE VARCHAR(255) COLLATE "C";
+ALTER TABLE task_tabs ALTER COLUMN rank TYPE VARCHAR(255) COLLATE "C";
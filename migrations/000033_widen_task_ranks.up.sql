@@ -0,0 +1,4 @@
+-- Repeated drops into the same gap lengthen a rank by about one character
+-- every six moves, so ranks are not capped at 255 characters
+ALTER TABLE task_tabs ALTER COLUMN rank TYPE TEXT COLLATE "C";
+ALTER TABLE task_cards ALTER COLUMN rank TYPE TEXT COLLATE "C";
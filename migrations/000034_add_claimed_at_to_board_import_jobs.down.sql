@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_board_import_jobs_running;
+
+ALTER TABLE board_import_jobs DROP COLUMN IF EXISTS claimed_at;
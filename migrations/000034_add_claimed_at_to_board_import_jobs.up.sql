@@ -0,0 +1,5 @@
+-- When a worker took the job, so a claim left behind by a crashed worker can
+-- be told apart from an import still running
+ALTER TABLE board_import_jobs ADD COLUMN claimed_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX idx_board_import_jobs_running ON board_import_jobs(claimed_at) WHERE status = 'running';